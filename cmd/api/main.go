@@ -4,13 +4,16 @@ import (
 	"avitoTechAutumn2025/internal/api/handlers"
 	"avitoTechAutumn2025/internal/api/server"
 	"avitoTechAutumn2025/internal/config"
+	"avitoTechAutumn2025/internal/domain"
 	"avitoTechAutumn2025/internal/logger"
 	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
 	storageGorm "avitoTechAutumn2025/internal/storage/gorm"
 	"context"
 	"fmt"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog/log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -21,7 +24,10 @@ func main() {
 	if err := godotenv.Load(".env"); err != nil {
 		fmt.Println("No .env file found")
 	}
-	envConfig := config.NewEnvConfig()
+	envConfig, err := config.NewEnvConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid configuration")
+	}
 	envConfig.PrintConfigWithHiddenSecrets()
 
 	logger.Setup(envConfig)
@@ -36,10 +42,27 @@ func main() {
 		log.Fatal().Err(err).Msg("failed to initialize database")
 	}
 
-	appService := service.New(txManager)
+	opts := append(assignmentOptions(envConfig.Assignment),
+		webhookOptions(envConfig.Webhook),
+		outboxOptions(envConfig.Outbox, txManager),
+		service.WithExternalUsers(domain.IngestionSourceGitHub, envConfig.GitHub.Logins),
+		service.WithExternalUsers(domain.IngestionSourceGitLab, envConfig.GitLab.Logins),
+	)
+	appService := service.New(txManager, opts...)
+	if err := appService.ValidateStrategies(); err != nil {
+		log.Fatal().Err(err).Msg("invalid assignment configuration")
+	}
 	appHandler := handlers.NewHandler(appService)
 	apiServer := server.NewServer(envConfig, appHandler)
 
+	// Фоновые задачи останавливаются вместе с сервером
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go appService.RunAbsenceWorker(workerCtx, envConfig.Absence.CheckInterval)
+	go appService.RunOutboxRelay(workerCtx, envConfig.Outbox.RelayInterval)
+	go appService.RunWebhookWorker(workerCtx, envConfig.Webhook.DeliveryInterval)
+
 	go apiServer.Run()
 
 	sig := make(chan os.Signal, 1)
@@ -50,7 +73,49 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	stopWorkers()
 	apiServer.Shutdown(ctx)
 
 	log.Info().Msg("service shutdown gracefully")
 }
+
+// assignmentOptions собирает настройки выбора ревьюверов для сервиса из конфигурации
+func assignmentOptions(cfg config.Assignment) []service.Option {
+	opts := []service.Option{
+		service.WithSelector(domain.SelectionStrategyWeighted, service.NewWeightedSelector(cfg.UserWeights)),
+	}
+
+	if cfg.Strategy != "" {
+		opts = append(opts, service.WithDefaultStrategy(domain.SelectionStrategy(cfg.Strategy)))
+	}
+
+	teamStrategies := make(map[string]domain.SelectionStrategy, len(cfg.TeamStrategies))
+	for team, strategy := range cfg.TeamStrategies {
+		teamStrategies[team] = domain.SelectionStrategy(strategy)
+	}
+	opts = append(opts, service.WithTeamStrategies(teamStrategies))
+
+	return opts
+}
+
+// webhookOptions включает доставку событий подписчикам с параметрами из конфигурации
+func webhookOptions(cfg config.Webhook) service.Option {
+	return service.WithWebhooks(&http.Client{Timeout: cfg.Timeout}, cfg.MaxAttempts, cfg.RetryBackoff)
+}
+
+// outboxOptions включает запись событий в outbox и их публикацию в приёмники из конфигурации
+func outboxOptions(cfg config.Outbox, txManager storage.TxManager) service.Option {
+	sinks := make([]service.EventSink, 0, len(cfg.Sinks))
+	for _, name := range cfg.Sinks {
+		switch name {
+		case "webhook":
+			sinks = append(sinks, service.NewWebhookSink(txManager))
+		case "log":
+			sinks = append(sinks, service.NewLogSink())
+		default:
+			log.Fatal().Str("sink", name).Msg("unknown outbox sink")
+		}
+	}
+
+	return service.WithOutbox(cfg.RetryBackoff, sinks...)
+}
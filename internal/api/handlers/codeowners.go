@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"avitoTechAutumn2025/internal/api"
+	"avitoTechAutumn2025/internal/api/middleware"
+	"avitoTechAutumn2025/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"net/http"
+)
+
+// SetCodeOwners обрабатывает регистрацию CODEOWNERS репозитория
+func (h *Handler) SetCodeOwners(c *gin.Context) {
+	var req struct {
+		Repository string `json:"repository" binding:"required"`
+		Content    string `json:"content"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("repository", req.Repository).
+		Msg("setting repository CODEOWNERS")
+
+	codeOwners, err := h.service.SetCodeOwners(c.Request.Context(), &domain.SetCodeOwnersInput{
+		Repository: req.Repository,
+		Content:    req.Content,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"codeowners": mapCodeOwnersToAPI(codeOwners),
+	})
+}
+
+// GetCodeOwners обрабатывает получение CODEOWNERS репозитория
+func (h *Handler) GetCodeOwners(c *gin.Context) {
+	repository := c.Query("repository")
+	if repository == "" {
+		log.Warn().
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("missing repository parameter")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "repository parameter is required",
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("repository", repository).
+		Msg("getting repository CODEOWNERS")
+
+	codeOwners, err := h.service.GetCodeOwners(c.Request.Context(), repository)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"codeowners": mapCodeOwnersToAPI(codeOwners),
+	})
+}
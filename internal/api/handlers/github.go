@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"avitoTechAutumn2025/internal/api"
+	"avitoTechAutumn2025/internal/api/middleware"
+	"avitoTechAutumn2025/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"net/http"
+)
+
+// Заголовки вебхука GitHub
+const (
+	githubEventHeader    = "X-GitHub-Event"
+	githubDeliveryHeader = "X-GitHub-Delivery"
+)
+
+// githubPullRequestPayload - поля события pull_request, которые использует сервис
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// githubAction сопоставляет action GitHub действию сервиса; false - событие сервис не интересует
+func githubAction(payload *githubPullRequestPayload) (domain.ExternalPullRequestAction, bool) {
+	switch payload.Action {
+	case "opened":
+		return domain.ExternalActionOpened, true
+	case "closed":
+		if payload.PullRequest.Merged {
+			return domain.ExternalActionMerged, true
+		}
+		return domain.ExternalActionClosed, true
+	case "reopened":
+		return domain.ExternalActionReopened, true
+	case "ready_for_review":
+		return domain.ExternalActionReadyForReview, true
+	default:
+		return "", false
+	}
+}
+
+// GitHubWebhook обрабатывает вебхук GitHub: события pull_request повторяются над PR сервиса
+func (h *Handler) GitHubWebhook(c *gin.Context) {
+	event := c.GetHeader(githubEventHeader)
+	deliveryID := c.GetHeader(githubDeliveryHeader)
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("event", event).
+		Str("delivery_id", deliveryID).
+		Msg("received github webhook")
+
+	switch event {
+	case "ping":
+		c.JSON(http.StatusOK, gin.H{"status": "pong"})
+		return
+	case "pull_request":
+	default:
+		c.JSON(http.StatusAccepted, gin.H{"status": "ignored"})
+		return
+	}
+
+	var payload githubPullRequestPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	action, ok := githubAction(&payload)
+	if !ok {
+		log.Info().
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Str("action", payload.Action).
+			Msg("github pull_request action ignored")
+
+		c.JSON(http.StatusAccepted, gin.H{"status": "ignored"})
+		return
+	}
+
+	result, err := h.service.IngestPullRequestEvent(c.Request.Context(), &domain.ExternalPullRequestEvent{
+		Source:      domain.IngestionSourceGitHub,
+		DeliveryID:  deliveryID,
+		Action:      action,
+		Repository:  payload.Repository.FullName,
+		Number:      payload.Number,
+		Title:       payload.PullRequest.Title,
+		AuthorLogin: payload.PullRequest.User.Login,
+		Draft:       payload.PullRequest.Draft,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapIngestionResultToAPI(result))
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"avitoTechAutumn2025/internal/api"
+	"avitoTechAutumn2025/internal/api/middleware"
+	"avitoTechAutumn2025/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"net/http"
+)
+
+// Заголовки вебхука GitLab
+const (
+	gitlabEventHeader    = "X-Gitlab-Event"
+	gitlabDeliveryHeader = "X-Gitlab-Event-UUID"
+)
+
+// gitlabMergeRequestEvent - значение X-Gitlab-Event для событий merge request
+const gitlabMergeRequestEvent = "Merge Request Hook"
+
+// gitlabMergeRequestPayload - поля события Merge Request Hook, которые использует сервис
+type gitlabMergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	// User - инициатор события, а не автор MR
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID    int    `json:"iid"`
+		Title  string `json:"title"`
+		Action string `json:"action"`
+		Draft  bool   `json:"draft"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft *struct {
+			Previous bool `json:"previous"`
+			Current  bool `json:"current"`
+		} `json:"draft"`
+	} `json:"changes"`
+}
+
+// gitlabUnsupportedDraftReason - причина ответа unsupported на перевод MR обратно в черновик
+const gitlabUnsupportedDraftReason = "converting a merge request back to draft is not supported: reviewers stay assigned"
+
+// gitlabConvertedToDraft сообщает, что событие переводит MR обратно в черновик.
+// У PR сервиса нет перехода OPEN → DRAFT, поэтому такое событие не повторяется, а отклоняется явно.
+func gitlabConvertedToDraft(payload *gitlabMergeRequestPayload) bool {
+	draft := payload.Changes.Draft
+	return payload.ObjectAttributes.Action == "update" && draft != nil && !draft.Previous && draft.Current
+}
+
+// gitlabAction сопоставляет action GitLab действию сервиса; false - событие сервис не интересует.
+// Из изменений черновика повторяется только снятие, обратный перевод обрабатывает gitlabConvertedToDraft.
+func gitlabAction(payload *gitlabMergeRequestPayload) (domain.ExternalPullRequestAction, bool) {
+	switch payload.ObjectAttributes.Action {
+	case "open":
+		return domain.ExternalActionOpened, true
+	case "merge":
+		return domain.ExternalActionMerged, true
+	case "close":
+		return domain.ExternalActionClosed, true
+	case "reopen":
+		return domain.ExternalActionReopened, true
+	case "update":
+		if draft := payload.Changes.Draft; draft != nil && draft.Previous && !draft.Current {
+			return domain.ExternalActionReadyForReview, true
+		}
+		return "", false
+	default:
+		return "", false
+	}
+}
+
+// GitLabWebhook обрабатывает вебхук GitLab: события merge request повторяются над PR сервиса
+func (h *Handler) GitLabWebhook(c *gin.Context) {
+	event := c.GetHeader(gitlabEventHeader)
+	deliveryID := c.GetHeader(gitlabDeliveryHeader)
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("event", event).
+		Str("delivery_id", deliveryID).
+		Msg("received gitlab webhook")
+
+	if event != gitlabMergeRequestEvent {
+		c.JSON(http.StatusAccepted, gin.H{"status": "ignored"})
+		return
+	}
+
+	var payload gitlabMergeRequestPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	// Ответ 202, а не 4xx: GitLab отключает вебхук после серии ошибок
+	if gitlabConvertedToDraft(&payload) {
+		log.Warn().
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Str("delivery_id", deliveryID).
+			Str("repository", payload.Project.PathWithNamespace).
+			Int("iid", payload.ObjectAttributes.IID).
+			Msg("gitlab merge request converted to draft, transition is not supported")
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"status": "unsupported",
+			"reason": gitlabUnsupportedDraftReason,
+		})
+		return
+	}
+
+	action, ok := gitlabAction(&payload)
+	if !ok {
+		log.Info().
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Str("action", payload.ObjectAttributes.Action).
+			Msg("gitlab merge request action ignored")
+
+		c.JSON(http.StatusAccepted, gin.H{"status": "ignored"})
+		return
+	}
+
+	// В GitLab автор есть в payload только числовым id, его username совпадает с инициатором открытия MR
+	authorLogin := ""
+	if action == domain.ExternalActionOpened {
+		authorLogin = payload.User.Username
+	}
+
+	result, err := h.service.IngestPullRequestEvent(c.Request.Context(), &domain.ExternalPullRequestEvent{
+		Source:      domain.IngestionSourceGitLab,
+		DeliveryID:  deliveryID,
+		Action:      action,
+		Repository:  payload.Project.PathWithNamespace,
+		Number:      payload.ObjectAttributes.IID,
+		Title:       payload.ObjectAttributes.Title,
+		AuthorLogin: authorLogin,
+		Draft:       payload.ObjectAttributes.Draft,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapGitLabIngestionResultToAPI(result))
+}
@@ -13,16 +13,57 @@ const (
 	AddTeamRoute    = "/add"
 	GetTeamRoute    = "/get"
 	DeactivateRoute = "/deactivate"
+	DeleteTeamRoute = "/delete"
 
-	UserPathRoute    = "/users"
-	SetIsActiveRoute = "/setIsActive"
-	GetReviewRoute   = "/getReview"
+	AddTeamMemberRoute    = "/members/add"
+	RemoveTeamMemberRoute = "/members/remove"
+	MoveTeamMemberRoute   = "/members/move"
+
+	GetTeamSettingsRoute = "/settings/get"
+	SetTeamSettingsRoute = "/settings/set"
+
+	UserPathRoute       = "/users"
+	SetIsActiveRoute    = "/setIsActive"
+	GetReviewRoute      = "/getReview"
+	SetReviewLimitRoute = "/setReviewLimit"
+
+	AddAbsenceRoute    = "/absence/add"
+	GetAbsencesRoute   = "/absence/list"
+	DeleteAbsenceRoute = "/absence/delete"
 
 	PullRequestPathRoute           = "/pullRequest"
 	CreatePullRequestRoute         = "/create"
 	MergePullRequestRoute          = "/merge"
 	ReassignPullRequestRoute       = "/reassign"
 	ReassignInactiveReviewersRoute = "/reassignInactive"
+	BulkReassignInactiveRoute      = "/reassignInactiveBulk"
+	SubmitReviewRoute              = "/review"
+	ClosePullRequestRoute          = "/close"
+	ReopenPullRequestRoute         = "/reopen"
+	MarkPullRequestReadyRoute      = "/ready"
+	PullRequestHistoryRoute        = "/history"
+	ExplainPullRequestRoute        = "/explain"
+	GetPullRequestRoute            = "/get"
+	ListPullRequestsRoute          = "/list"
+
+	WebhookPathRoute       = "/webhooks"
+	CreateWebhookRoute     = "/create"
+	ListWebhooksRoute      = "/list"
+	DeleteWebhookRoute     = "/delete"
+	WebhookDeliveriesRoute = "/deliveries"
+
+	RepositoryPathRoute   = "/repositories"
+	SetRepositoryRoute    = "/set"
+	GetRepositoryRoute    = "/get"
+	ListRepositoriesRoute = "/list"
+
+	CodeOwnersPathRoute = "/codeowners"
+	SetCodeOwnersRoute  = "/set"
+	GetCodeOwnersRoute  = "/get"
+
+	IntegrationPathRoute = "/integrations"
+	GitHubWebhookRoute   = "/github"
+	GitLabWebhookRoute   = "/gitlab/webhook"
 )
 
 type Handler struct {
@@ -52,12 +93,22 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		teamGroup.POST(AddTeamRoute, h.AddTeam)
 		teamGroup.GET(GetTeamRoute, middleware.RequireUser(), h.GetTeam)
 		teamGroup.POST(DeactivateRoute, middleware.RequireAdmin(), h.DeactivateTeam)
+		teamGroup.GET(GetTeamSettingsRoute, middleware.RequireUser(), h.GetTeamSettings)
+		teamGroup.POST(SetTeamSettingsRoute, middleware.RequireAdmin(), h.SetTeamSettings)
+		teamGroup.POST(AddTeamMemberRoute, middleware.RequireAdmin(), h.AddTeamMember)
+		teamGroup.POST(RemoveTeamMemberRoute, middleware.RequireAdmin(), h.RemoveTeamMember)
+		teamGroup.POST(MoveTeamMemberRoute, middleware.RequireAdmin(), h.MoveTeamMember)
+		teamGroup.POST(DeleteTeamRoute, middleware.RequireAdmin(), h.DeleteTeam)
 	}
 
 	userGroup := r.Group(UserPathRoute)
 	{
 		userGroup.POST(SetIsActiveRoute, middleware.RequireAdmin(), h.SetIsActive)
 		userGroup.GET(GetReviewRoute, middleware.RequireUser(), h.GetReview)
+		userGroup.POST(SetReviewLimitRoute, middleware.RequireAdmin(), h.SetReviewLimit)
+		userGroup.POST(AddAbsenceRoute, middleware.RequireAdmin(), h.AddAbsence)
+		userGroup.GET(GetAbsencesRoute, middleware.RequireUser(), h.GetAbsences)
+		userGroup.POST(DeleteAbsenceRoute, middleware.RequireAdmin(), h.DeleteAbsence)
 	}
 
 	prGroup := r.Group(PullRequestPathRoute)
@@ -66,6 +117,44 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		prGroup.POST(MergePullRequestRoute, middleware.RequireAdmin(), h.MergePullRequest)
 		prGroup.POST(ReassignPullRequestRoute, middleware.RequireAdmin(), h.ReassignPullRequest)
 		prGroup.POST(ReassignInactiveReviewersRoute, middleware.RequireAdmin(), h.ReassignInactiveReviewers)
+		prGroup.POST(BulkReassignInactiveRoute, middleware.RequireAdmin(), h.BulkReassignInactiveReviewers)
+		prGroup.POST(SubmitReviewRoute, middleware.RequireAdmin(), h.SubmitReview)
+		prGroup.POST(ClosePullRequestRoute, middleware.RequireAdmin(), h.ClosePullRequest)
+		prGroup.POST(ReopenPullRequestRoute, middleware.RequireAdmin(), h.ReopenPullRequest)
+		prGroup.POST(MarkPullRequestReadyRoute, middleware.RequireAdmin(), h.MarkPullRequestReady)
+		prGroup.GET(PullRequestHistoryRoute, middleware.RequireUser(), h.GetPullRequestHistory)
+		prGroup.GET(ExplainPullRequestRoute, middleware.RequireUser(), h.ExplainPullRequest)
+		prGroup.GET(GetPullRequestRoute, middleware.RequireUser(), h.GetPullRequest)
+		prGroup.GET(ListPullRequestsRoute, middleware.RequireUser(), h.ListPullRequests)
+	}
+
+	// Подписки содержат секреты и внешние адреса, поэтому доступны только администратору
+	webhookGroup := r.Group(WebhookPathRoute)
+	{
+		webhookGroup.POST(CreateWebhookRoute, middleware.RequireAdmin(), h.CreateWebhook)
+		webhookGroup.GET(ListWebhooksRoute, middleware.RequireAdmin(), h.ListWebhooks)
+		webhookGroup.POST(DeleteWebhookRoute, middleware.RequireAdmin(), h.DeleteWebhook)
+		webhookGroup.GET(WebhookDeliveriesRoute, middleware.RequireAdmin(), h.GetWebhookDeliveries)
+	}
+
+	repositoryGroup := r.Group(RepositoryPathRoute)
+	{
+		repositoryGroup.POST(SetRepositoryRoute, middleware.RequireAdmin(), h.SetRepository)
+		repositoryGroup.GET(GetRepositoryRoute, middleware.RequireUser(), h.GetRepository)
+		repositoryGroup.GET(ListRepositoriesRoute, middleware.RequireUser(), h.ListRepositories)
+	}
+
+	codeOwnersGroup := r.Group(CodeOwnersPathRoute)
+	{
+		codeOwnersGroup.POST(SetCodeOwnersRoute, middleware.RequireAdmin(), h.SetCodeOwners)
+		codeOwnersGroup.GET(GetCodeOwnersRoute, middleware.RequireUser(), h.GetCodeOwners)
+	}
+
+	// Внешние системы не знают токенов сервиса и подтверждают запрос подписью тела или общим секретом
+	integrationGroup := r.Group(IntegrationPathRoute)
+	{
+		integrationGroup.POST(GitHubWebhookRoute, middleware.RequireGitHubSignature(), h.GitHubWebhook)
+		integrationGroup.POST(GitLabWebhookRoute, middleware.RequireGitLabToken(), h.GitLabWebhook)
 	}
 
 	return r
@@ -2,19 +2,89 @@ package handlers
 
 import (
 	"avitoTechAutumn2025/internal/domain"
+	"encoding/json"
 )
 
 // mapPullRequestToAPI конвертирует domain.PullRequest в API response
 func mapPullRequestToAPI(pr *domain.PullRequest) map[string]interface{} {
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"pull_request_id":    pr.ID,
 		"pull_request_name":  pr.Name,
 		"author_id":          pr.AuthorID,
 		"status":             string(pr.Status),
 		"assigned_reviewers": pr.AssignedReviewers,
+		"reviewer_states":    mapReviewerStatesToAPI(pr),
 		"created_at":         pr.CreatedAt,
 		"merged_at":          pr.MergedAt,
+		"closed_at":          pr.ClosedAt,
+		"merge_forced":       pr.MergeForced,
+		"repository":         nullableString(pr.Repository),
+		"team_name":          nullableString(pr.TeamName),
+	}
+
+	// Команды ревьюверов известны только в ответе на назначение
+	if pr.ReviewerTeams != nil {
+		result["reviewer_teams"] = pr.ReviewerTeams
 	}
+
+	return result
+}
+
+// mapReviewerStatesToAPI возвращает решения всех назначенных ревьюверов PR.
+// Для ревьюверов, назначенных в текущем запросе, состояние ещё не прочитано из БД - они PENDING.
+func mapReviewerStatesToAPI(pr *domain.PullRequest) []map[string]interface{} {
+	known := make(map[string]domain.ReviewerState, len(pr.ReviewerStates))
+	for _, state := range pr.ReviewerStates {
+		known[state.ReviewerID] = state
+	}
+
+	states := make([]map[string]interface{}, len(pr.AssignedReviewers))
+	for i, reviewerID := range pr.AssignedReviewers {
+		state, ok := known[reviewerID]
+		if !ok {
+			states[i] = map[string]interface{}{
+				"reviewer_id": reviewerID,
+				"state":       string(domain.ReviewStatePending),
+				"updated_at":  nil,
+			}
+			continue
+		}
+		states[i] = map[string]interface{}{
+			"reviewer_id": reviewerID,
+			"state":       string(state.State),
+			"updated_at":  state.UpdatedAt,
+		}
+	}
+	return states
+}
+
+// mapPullRequestDetailsToAPI конвертирует domain.PullRequestDetails в API response:
+// полный PR, автор и ревьюверы с именами, активностью и решениями
+func mapPullRequestDetailsToAPI(details *domain.PullRequestDetails) map[string]interface{} {
+	result := mapPullRequestToAPI(&details.PullRequest)
+	states := result["reviewer_states"].([]map[string]interface{})
+
+	reviewers := make([]map[string]interface{}, len(details.Reviewers))
+	for i, reviewer := range details.Reviewers {
+		reviewers[i] = map[string]interface{}{
+			"user_id":          reviewer.UserID,
+			"username":         reviewer.Username,
+			"team_name":        reviewer.TeamName,
+			"is_active":        reviewer.IsActive,
+			"state":            states[i]["state"],
+			"state_updated_at": states[i]["updated_at"],
+		}
+	}
+
+	result["author"] = map[string]interface{}{
+		"user_id":   details.Author.UserID,
+		"username":  details.Author.Username,
+		"team_name": details.Author.TeamName,
+		"is_active": details.Author.IsActive,
+	}
+	result["reviewers"] = reviewers
+
+	return result
 }
 
 // mapPullRequestShortToAPI конвертирует domain.PullRequestShort в API response
@@ -24,6 +94,62 @@ func mapPullRequestShortToAPI(pr domain.PullRequestShort) map[string]interface{}
 		"pull_request_name": pr.Name,
 		"author_id":         pr.AuthorID,
 		"status":            string(pr.Status),
+		"created_at":        pr.CreatedAt,
+	}
+}
+
+// mapReassignInactiveResultToAPI конвертирует domain.ReassignInactiveResult в API response
+func mapReassignInactiveResultToAPI(result domain.ReassignInactiveResult) map[string]interface{} {
+	details := make([]map[string]interface{}, len(result.ReassignmentDetails))
+	for i, detail := range result.ReassignmentDetails {
+		details[i] = map[string]interface{}{
+			"old_reviewer_id":   detail.OldReviewerID,
+			"new_reviewer_id":   detail.NewReviewerID,
+			"new_reviewer_team": detail.NewReviewerTeam,
+			"was_removed":       detail.WasRemoved,
+		}
+	}
+
+	return map[string]interface{}{
+		"pull_request_id":      result.PullRequestID,
+		"reassignment_details": details,
+	}
+}
+
+// mapReassignmentsToAPI конвертирует результаты переназначения по нескольким PR в API response
+func mapReassignmentsToAPI(results []domain.ReassignInactiveResult) []map[string]interface{} {
+	reassignments := make([]map[string]interface{}, len(results))
+	for i, result := range results {
+		reassignments[i] = mapReassignInactiveResultToAPI(result)
+	}
+	return reassignments
+}
+
+// mapBulkReassignResultToAPI конвертирует domain.BulkReassignPullRequestResult в API response
+func mapBulkReassignResultToAPI(result domain.BulkReassignPullRequestResult) map[string]interface{} {
+	replaced := make([]map[string]interface{}, len(result.Replaced))
+	for i, detail := range result.Replaced {
+		replaced[i] = map[string]interface{}{
+			"old_reviewer_id":   detail.OldReviewerID,
+			"new_reviewer_id":   detail.NewReviewerID,
+			"new_reviewer_team": detail.NewReviewerTeam,
+		}
+	}
+
+	var failure interface{}
+	if result.Failure != nil {
+		failure = map[string]interface{}{
+			"code":    string(result.Failure.Code),
+			"message": result.Failure.Message,
+		}
+	}
+
+	return map[string]interface{}{
+		"pull_request_id": result.PullRequestID,
+		"replaced":        replaced,
+		"removed":         result.Removed,
+		"failed":          result.Failed,
+		"error":           failure,
 	}
 }
 
@@ -34,6 +160,7 @@ func mapTeamToAPI(team *domain.Team) map[string]interface{} {
 		members[i] = map[string]interface{}{
 			"user_id":   m.UserID,
 			"username":  m.Username,
+			"role":      string(m.Role),
 			"is_active": m.IsActive,
 		}
 	}
@@ -44,12 +171,248 @@ func mapTeamToAPI(team *domain.Team) map[string]interface{} {
 	}
 }
 
+// mapTeamMemberResultToAPI конвертирует domain.TeamMemberResult в API response
+func mapTeamMemberResultToAPI(result *domain.TeamMemberResult) map[string]interface{} {
+	var membership interface{}
+	if result.Membership != nil {
+		membership = map[string]interface{}{
+			"user_id":   result.Membership.UserID,
+			"role":      string(result.Membership.Role),
+			"is_active": result.Membership.IsActive,
+			"joined_at": result.Membership.JoinedAt,
+		}
+	}
+
+	return map[string]interface{}{
+		"team_name":     result.TeamName,
+		"membership":    membership,
+		"primary_team":  result.PrimaryTeam,
+		"reassignments": mapReassignmentsToAPI(result.Reassignments),
+	}
+}
+
+// mapTeamSettingsToAPI конвертирует domain.TeamSettings в API response
+func mapTeamSettingsToAPI(settings *domain.TeamSettings) map[string]interface{} {
+	fallbackTeams := settings.FallbackTeams
+	if fallbackTeams == nil {
+		fallbackTeams = []string{}
+	}
+
+	capacityPolicy := settings.CapacityPolicy
+	if capacityPolicy == "" {
+		capacityPolicy = domain.CapacityPolicyAssignLeastLoaded
+	}
+
+	return map[string]interface{}{
+		"team_name":          settings.TeamName,
+		"reviewer_count":     settings.ReviewerCount,
+		"min_reviewers":      settings.MinReviewers,
+		"required_approvals": settings.RequiredApprovals,
+		"selection_strategy": string(settings.SelectionStrategy),
+		"fallback_teams":     fallbackTeams,
+		"max_open_reviews":   mapReviewLimitToAPI(settings.MaxOpenReviews),
+		"capacity_policy":    string(capacityPolicy),
+	}
+}
+
+// mapUserAbsenceToAPI конвертирует domain.UserAbsence в API response
+func mapUserAbsenceToAPI(absence domain.UserAbsence) map[string]interface{} {
+	return map[string]interface{}{
+		"absence_id":    absence.ID,
+		"user_id":       absence.UserID,
+		"starts_at":     absence.StartsAt,
+		"ends_at":       absence.EndsAt,
+		"reason":        absence.Reason,
+		"reassigned_at": absence.ReassignedAt,
+	}
+}
+
+// mapPullRequestEventToAPI конвертирует domain.PullRequestEvent в API response
+func mapPullRequestEventToAPI(event domain.PullRequestEvent) map[string]interface{} {
+	return map[string]interface{}{
+		"event_id":        event.ID,
+		"event_type":      string(event.Type),
+		"actor":           event.ActorID,
+		"old_reviewer_id": nullableString(event.OldReviewerID),
+		"new_reviewer_id": nullableString(event.NewReviewerID),
+		"reason":          event.Reason,
+		"created_at":      event.CreatedAt,
+	}
+}
+
+// mapSelectionExplanationToAPI конвертирует объяснение выбора ревьюверов в API response
+func mapSelectionExplanationToAPI(explanation domain.SelectionExplanation) map[string]interface{} {
+	teams := make([]map[string]interface{}, len(explanation.Teams))
+	for i, team := range explanation.Teams {
+		teams[i] = map[string]interface{}{
+			"team_name":   team.TeamName,
+			"strategy":    nullableString(string(team.Strategy)),
+			"fallback":    team.Fallback,
+			"code_owners": team.CodeOwners,
+			"candidates":  team.Candidates,
+			"selected":    team.Selected,
+		}
+	}
+
+	excluded := make([]map[string]interface{}, len(explanation.Excluded))
+	for i, exclusion := range explanation.Excluded {
+		excluded[i] = map[string]interface{}{
+			"user_id": exclusion.UserID,
+			"reason":  string(exclusion.Reason),
+		}
+	}
+
+	// nil - CODEOWNERS при выборе не применялся
+	var codeOwners []map[string]interface{}
+	if explanation.CodeOwners != nil {
+		codeOwners = make([]map[string]interface{}, len(explanation.CodeOwners))
+		for i, match := range explanation.CodeOwners {
+			codeOwners[i] = map[string]interface{}{
+				"line":    match.Line,
+				"pattern": match.Pattern,
+				"owners":  match.Owners,
+				"paths":   match.Paths,
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"explanation_id":       explanation.ID,
+		"trigger":              string(explanation.Trigger),
+		"replaced_reviewer_id": nullableString(explanation.ReplacedReviewerID),
+		"requested_count":      explanation.RequestedCount,
+		"teams":                teams,
+		"excluded":             excluded,
+		"over_capacity":        explanation.OverCapacity,
+		"repository":           nullableString(explanation.Repository),
+		"code_owners":          codeOwners,
+		"selected":             explanation.Selected,
+		"created_at":           explanation.CreatedAt,
+	}
+}
+
+// nullableString возвращает nil для пустой строки, чтобы в JSON было null
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
 // mapUserToAPI конвертирует domain.User в API response
 func mapUserToAPI(user *domain.User) map[string]interface{} {
 	return map[string]interface{}{
-		"user_id":   user.UserID,
-		"username":  user.Username,
-		"team_name": user.TeamName,
-		"is_active": user.IsActive,
+		"user_id":          user.UserID,
+		"username":         user.Username,
+		"team_name":        user.TeamName,
+		"is_active":        user.IsActive,
+		"max_open_reviews": mapReviewLimitToAPI(user.MaxOpenReviews),
+	}
+}
+
+// mapReviewLimitToAPI конвертирует лимит открытых ревью в API response (null - лимит не задан)
+func mapReviewLimitToAPI(limit int) interface{} {
+	if limit <= 0 {
+		return nil
+	}
+	return limit
+}
+
+// mapWebhookSubscriptionToAPI конвертирует domain.WebhookSubscription в API response (без секрета)
+func mapWebhookSubscriptionToAPI(subscription domain.WebhookSubscription) map[string]interface{} {
+	eventTypes := make([]string, len(subscription.EventTypes))
+	for i, eventType := range subscription.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	return map[string]interface{}{
+		"subscription_id": subscription.ID,
+		"url":             subscription.URL,
+		"event_types":     eventTypes,
+		"created_at":      subscription.CreatedAt,
+	}
+}
+
+// mapWebhookDeliveryToAPI конвертирует domain.WebhookDelivery в API response
+func mapWebhookDeliveryToAPI(delivery domain.WebhookDelivery) map[string]interface{} {
+	var lastStatusCode interface{}
+	if delivery.LastStatusCode != 0 {
+		lastStatusCode = delivery.LastStatusCode
+	}
+
+	return map[string]interface{}{
+		"delivery_id":      delivery.ID,
+		"event_id":         delivery.EventID,
+		"event_type":       string(delivery.EventType),
+		"status":           string(delivery.Status),
+		"attempts":         delivery.Attempts,
+		"last_status_code": lastStatusCode,
+		"last_error":       nullableString(delivery.LastError),
+		"next_attempt_at":  delivery.NextAttemptAt,
+		"created_at":       delivery.CreatedAt,
+		"delivered_at":     delivery.DeliveredAt,
+		"payload":          json.RawMessage(delivery.Payload),
+	}
+}
+
+// mapIngestionResultToAPI конвертирует domain.IngestionResult в API response
+func mapIngestionResultToAPI(result *domain.IngestionResult) map[string]interface{} {
+	response := map[string]interface{}{
+		"status":          "processed",
+		"delivery_id":     result.DeliveryID,
+		"pull_request_id": result.PullRequestID,
+		"action":          string(result.Action),
+	}
+	if result.Duplicate {
+		response["status"] = "duplicate"
+	}
+	if result.PullRequest != nil {
+		response["pr"] = mapPullRequestToAPI(result.PullRequest)
+
+		reviewerLogins := result.ReviewerLogins
+		if reviewerLogins == nil {
+			reviewerLogins = []string{}
+		}
+		unmappedReviewers := result.UnmappedReviewers
+		if unmappedReviewers == nil {
+			unmappedReviewers = []string{}
+		}
+		response["reviewer_logins"] = reviewerLogins
+		response["unmapped_reviewers"] = unmappedReviewers
+	}
+	return response
+}
+
+// mapGitLabIngestionResultToAPI дополняет ответ quick action, которым CI назначает ревьюверов в MR
+func mapGitLabIngestionResultToAPI(result *domain.IngestionResult) map[string]interface{} {
+	response := mapIngestionResultToAPI(result)
+	if result.PullRequest == nil || result.PullRequest.Status != domain.PullRequestStatusOpen || len(result.ReviewerLogins) == 0 {
+		return response
+	}
+
+	quickAction := "/assign_reviewer"
+	for _, login := range result.ReviewerLogins {
+		quickAction += " @" + login
+	}
+	response["quick_action"] = quickAction
+	return response
+}
+
+// mapRepositoryToAPI конвертирует domain.Repository в API response
+func mapRepositoryToAPI(repository *domain.Repository) map[string]interface{} {
+	return map[string]interface{}{
+		"repository":  repository.Name,
+		"owner_teams": repository.OwnerTeams,
+		"created_at":  repository.CreatedAt,
+		"updated_at":  repository.UpdatedAt,
+	}
+}
+
+// mapCodeOwnersToAPI конвертирует domain.RepositoryCodeOwners в API response
+func mapCodeOwnersToAPI(codeOwners *domain.RepositoryCodeOwners) map[string]interface{} {
+	return map[string]interface{}{
+		"repository": codeOwners.Repository,
+		"content":    codeOwners.Content,
+		"updated_at": codeOwners.UpdatedAt,
 	}
 }
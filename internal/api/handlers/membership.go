@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"avitoTechAutumn2025/internal/api"
+	"avitoTechAutumn2025/internal/api/middleware"
+	"avitoTechAutumn2025/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"net/http"
+)
+
+// AddTeamMember обрабатывает добавление пользователя в команду или изменение его членства
+func (h *Handler) AddTeamMember(c *gin.Context) {
+	var req struct {
+		TeamName            string `json:"team_name" binding:"required"`
+		UserID              string `json:"user_id" binding:"required"`
+		Username            string `json:"username"`
+		Role                string `json:"role"`
+		IsActive            *bool  `json:"is_active"`
+		ReassignOpenReviews bool   `json:"reassign_open_reviews"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	// Без is_active участник добавляется активным
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("team_name", req.TeamName).
+		Str("user_id", req.UserID).
+		Str("role", req.Role).
+		Bool("is_active", isActive).
+		Bool("reassign_open_reviews", req.ReassignOpenReviews).
+		Msg("adding team member")
+
+	result, err := h.service.AddTeamMember(c.Request.Context(), &domain.AddTeamMemberInput{
+		TeamName:            req.TeamName,
+		UserID:              req.UserID,
+		Username:            req.Username,
+		Role:                domain.TeamRole(req.Role),
+		IsActive:            isActive,
+		ReassignOpenReviews: req.ReassignOpenReviews,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapTeamMemberResultToAPI(result))
+}
+
+// RemoveTeamMember обрабатывает удаление пользователя из команды
+func (h *Handler) RemoveTeamMember(c *gin.Context) {
+	var req struct {
+		TeamName            string `json:"team_name" binding:"required"`
+		UserID              string `json:"user_id" binding:"required"`
+		ReassignOpenReviews bool   `json:"reassign_open_reviews"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("team_name", req.TeamName).
+		Str("user_id", req.UserID).
+		Bool("reassign_open_reviews", req.ReassignOpenReviews).
+		Msg("removing team member")
+
+	result, err := h.service.RemoveTeamMember(c.Request.Context(), &domain.RemoveTeamMemberInput{
+		TeamName:            req.TeamName,
+		UserID:              req.UserID,
+		ReassignOpenReviews: req.ReassignOpenReviews,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapTeamMemberResultToAPI(result))
+}
+
+// MoveTeamMember обрабатывает перевод пользователя в другую команду
+func (h *Handler) MoveTeamMember(c *gin.Context) {
+	var req struct {
+		FromTeam            string `json:"from_team" binding:"required"`
+		ToTeam              string `json:"to_team" binding:"required"`
+		UserID              string `json:"user_id" binding:"required"`
+		ReassignOpenReviews bool   `json:"reassign_open_reviews"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("from_team", req.FromTeam).
+		Str("to_team", req.ToTeam).
+		Str("user_id", req.UserID).
+		Bool("reassign_open_reviews", req.ReassignOpenReviews).
+		Msg("moving team member")
+
+	result, err := h.service.MoveTeamMember(c.Request.Context(), &domain.MoveTeamMemberInput{
+		FromTeam:            req.FromTeam,
+		ToTeam:              req.ToTeam,
+		UserID:              req.UserID,
+		ReassignOpenReviews: req.ReassignOpenReviews,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapTeamMemberResultToAPI(result))
+}
+
+// DeleteTeam обрабатывает удаление команды
+func (h *Handler) DeleteTeam(c *gin.Context) {
+	var req struct {
+		TeamName string `json:"team_name" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("team_name", req.TeamName).
+		Msg("deleting team")
+
+	if err := h.service.DeleteTeam(c.Request.Context(), req.TeamName); err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team_name": req.TeamName,
+	})
+}
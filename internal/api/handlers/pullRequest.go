@@ -4,17 +4,27 @@ import (
 	"avitoTechAutumn2025/internal/api"
 	"avitoTechAutumn2025/internal/api/middleware"
 	"avitoTechAutumn2025/internal/domain"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // CreatePullRequest обрабатывает создание PR с автоматическим назначением ревьюверов
 func (h *Handler) CreatePullRequest(c *gin.Context) {
 	var req struct {
-		PullRequestID   string `json:"pull_request_id" binding:"required"`
-		PullRequestName string `json:"pull_request_name" binding:"required"`
-		AuthorID        string `json:"author_id" binding:"required"`
+		PullRequestID   string   `json:"pull_request_id" binding:"required"`
+		PullRequestName string   `json:"pull_request_name" binding:"required"`
+		AuthorID        string   `json:"author_id" binding:"required"`
+		Draft           bool     `json:"draft"`
+		DryRun          bool     `json:"dry_run"`
+		Repository      string   `json:"repository"`
+		TeamName        string   `json:"team_name"`
+		ChangedFiles    []string `json:"changed_files"`
+		CodeOwners      string   `json:"codeowners"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -38,12 +48,23 @@ func (h *Handler) CreatePullRequest(c *gin.Context) {
 		Str("layer", "handler").
 		Str("pull_request_id", req.PullRequestID).
 		Str("author_id", req.AuthorID).
+		Bool("draft", req.Draft).
+		Bool("dry_run", req.DryRun).
+		Str("repository", req.Repository).
+		Str("team_name", req.TeamName).
+		Int("changed_files_count", len(req.ChangedFiles)).
 		Msg("creating pull request")
 
 	input := &domain.CreatePullRequestInput{
 		PullRequestID:   req.PullRequestID,
 		PullRequestName: req.PullRequestName,
 		AuthorID:        req.AuthorID,
+		Draft:           req.Draft,
+		DryRun:          req.DryRun,
+		Repository:      req.Repository,
+		TeamName:        req.TeamName,
+		ChangedFiles:    req.ChangedFiles,
+		CodeOwners:      req.CodeOwners,
 	}
 
 	pr, err := h.service.CreatePullRequest(c.Request.Context(), input)
@@ -58,10 +79,18 @@ func (h *Handler) CreatePullRequest(c *gin.Context) {
 		Str("pull_request_id", pr.ID).
 		Str("author_id", pr.AuthorID).
 		Int("reviewers_assigned", len(pr.AssignedReviewers)).
+		Bool("dry_run", req.DryRun).
 		Msg("successfully created pull request")
 
-	c.JSON(http.StatusCreated, map[string]interface{}{
-		"pr": mapPullRequestToAPI(pr),
+	// При пробном запуске PR не создан - возвращаем 200 с тем, что было бы создано
+	status := http.StatusCreated
+	if req.DryRun {
+		status = http.StatusOK
+	}
+
+	c.JSON(status, map[string]interface{}{
+		"pr":      mapPullRequestToAPI(pr),
+		"dry_run": req.DryRun,
 	})
 }
 
@@ -69,6 +98,7 @@ func (h *Handler) CreatePullRequest(c *gin.Context) {
 func (h *Handler) MergePullRequest(c *gin.Context) {
 	var req struct {
 		PullRequestID string `json:"pull_request_id" binding:"required"`
+		Force         bool   `json:"force"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -91,10 +121,12 @@ func (h *Handler) MergePullRequest(c *gin.Context) {
 		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
 		Str("layer", "handler").
 		Str("pull_request_id", req.PullRequestID).
+		Bool("force", req.Force).
 		Msg("merging pull request")
 
 	input := &domain.MergePullRequestInput{
 		PullRequestID: req.PullRequestID,
+		Force:         req.Force,
 	}
 
 	pr, err := h.service.MergePullRequest(c.Request.Context(), input)
@@ -120,6 +152,7 @@ func (h *Handler) ReassignPullRequest(c *gin.Context) {
 	var req struct {
 		PullRequestID string `json:"pull_request_id" binding:"required"`
 		OldUserID     string `json:"old_reviewer_id" binding:"required"`
+		DryRun        bool   `json:"dry_run"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -143,11 +176,13 @@ func (h *Handler) ReassignPullRequest(c *gin.Context) {
 		Str("layer", "handler").
 		Str("pull_request_id", req.PullRequestID).
 		Str("old_reviewer_id", req.OldUserID).
+		Bool("dry_run", req.DryRun).
 		Msg("reassigning pull request reviewer")
 
 	input := &domain.ReassignPullRequestInput{
 		PullRequestID: req.PullRequestID,
 		OldUserID:     req.OldUserID,
+		DryRun:        req.DryRun,
 	}
 
 	result, err := h.service.ReassignPullRequest(c.Request.Context(), input)
@@ -165,8 +200,10 @@ func (h *Handler) ReassignPullRequest(c *gin.Context) {
 		Msg("successfully reassigned pull request reviewer")
 
 	c.JSON(http.StatusOK, map[string]interface{}{
-		"pr":          mapPullRequestToAPI(&result.PullRequest),
-		"replaced_by": result.ReplacedBy,
+		"pr":               mapPullRequestToAPI(&result.PullRequest),
+		"replaced_by":      result.ReplacedBy,
+		"replaced_by_team": result.ReplacedByTeam,
+		"dry_run":          req.DryRun,
 	})
 }
 
@@ -174,6 +211,7 @@ func (h *Handler) ReassignPullRequest(c *gin.Context) {
 func (h *Handler) ReassignInactiveReviewers(c *gin.Context) {
 	var req struct {
 		PullRequestID string `json:"pull_request_id" binding:"required"`
+		DryRun        bool   `json:"dry_run"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -196,10 +234,12 @@ func (h *Handler) ReassignInactiveReviewers(c *gin.Context) {
 		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
 		Str("layer", "handler").
 		Str("pull_request_id", req.PullRequestID).
+		Bool("dry_run", req.DryRun).
 		Msg("reassigning inactive reviewers")
 
 	input := &domain.ReassignInactiveInput{
 		PullRequestID: req.PullRequestID,
+		DryRun:        req.DryRun,
 	}
 
 	result, err := h.service.ReassignInactiveReviewers(c.Request.Context(), input)
@@ -208,15 +248,6 @@ func (h *Handler) ReassignInactiveReviewers(c *gin.Context) {
 		return
 	}
 
-	reassignments := make([]map[string]interface{}, len(result.ReassignmentDetails))
-	for i, detail := range result.ReassignmentDetails {
-		reassignments[i] = map[string]interface{}{
-			"old_reviewer_id": detail.OldReviewerID,
-			"new_reviewer_id": detail.NewReviewerID,
-			"was_removed":     detail.WasRemoved,
-		}
-	}
-
 	log.Info().
 		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
 		Str("layer", "handler").
@@ -224,8 +255,527 @@ func (h *Handler) ReassignInactiveReviewers(c *gin.Context) {
 		Int("reassigned_count", len(result.ReassignmentDetails)).
 		Msg("successfully reassigned inactive reviewers")
 
+	response := mapReassignInactiveResultToAPI(*result)
+	response["dry_run"] = req.DryRun
+	c.JSON(http.StatusOK, response)
+}
+
+// BulkReassignInactiveReviewers обрабатывает массовое переназначение неактивных ревьюверов
+func (h *Handler) BulkReassignInactiveReviewers(c *gin.Context) {
+	var req struct {
+		TeamName       string   `json:"team_name"`
+		PullRequestIDs []string `json:"pull_request_ids"`
+		DryRun         bool     `json:"dry_run"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("team_name", req.TeamName).
+		Int("requested_pr_count", len(req.PullRequestIDs)).
+		Bool("dry_run", req.DryRun).
+		Msg("bulk reassigning inactive reviewers")
+
+	input := &domain.BulkReassignInactiveInput{
+		TeamName:       req.TeamName,
+		PullRequestIDs: req.PullRequestIDs,
+		DryRun:         req.DryRun,
+	}
+
+	result, err := h.service.BulkReassignInactiveReviewers(c.Request.Context(), input)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	prList := make([]map[string]interface{}, len(result.PullRequests))
+	for i, pr := range result.PullRequests {
+		prList[i] = mapBulkReassignResultToAPI(pr)
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Int("pr_count", len(prList)).
+		Msg("successfully bulk reassigned inactive reviewers")
+
+	c.JSON(http.StatusOK, gin.H{
+		"dry_run":       result.DryRun,
+		"pull_requests": prList,
+	})
+}
+
+// SubmitReview обрабатывает решение назначенного ревьювера по PR
+func (h *Handler) SubmitReview(c *gin.Context) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+		ReviewerID    string `json:"reviewer_id" binding:"required"`
+		State         string `json:"state" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", req.PullRequestID).
+		Str("reviewer_id", req.ReviewerID).
+		Str("state", req.State).
+		Msg("submitting review")
+
+	pr, err := h.service.SubmitReview(c.Request.Context(), &domain.SubmitReviewInput{
+		PullRequestID: req.PullRequestID,
+		ReviewerID:    req.ReviewerID,
+		State:         domain.ReviewState(req.State),
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", pr.ID).
+		Str("reviewer_id", req.ReviewerID).
+		Str("state", req.State).
+		Msg("successfully submitted review")
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": mapPullRequestToAPI(pr),
+	})
+}
+
+// ClosePullRequest обрабатывает закрытие PR без merge
+func (h *Handler) ClosePullRequest(c *gin.Context) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", req.PullRequestID).
+		Msg("closing pull request")
+
+	pr, err := h.service.ClosePullRequest(c.Request.Context(), &domain.ClosePullRequestInput{
+		PullRequestID: req.PullRequestID,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", pr.ID).
+		Str("status", string(pr.Status)).
+		Msg("successfully closed pull request")
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": mapPullRequestToAPI(pr),
+	})
+}
+
+// ReopenPullRequest обрабатывает повторное открытие закрытого PR
+func (h *Handler) ReopenPullRequest(c *gin.Context) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", req.PullRequestID).
+		Msg("reopening pull request")
+
+	pr, err := h.service.ReopenPullRequest(c.Request.Context(), &domain.ReopenPullRequestInput{
+		PullRequestID: req.PullRequestID,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", pr.ID).
+		Str("status", string(pr.Status)).
+		Msg("successfully reopened pull request")
+
 	c.JSON(http.StatusOK, map[string]interface{}{
-		"pull_request_id":      result.PullRequestID,
-		"reassignment_details": reassignments,
+		"pr": mapPullRequestToAPI(pr),
 	})
 }
+
+// MarkPullRequestReady обрабатывает перевод черновика PR в OPEN с назначением ревьюверов
+func (h *Handler) MarkPullRequestReady(c *gin.Context) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", req.PullRequestID).
+		Msg("marking pull request ready")
+
+	pr, err := h.service.MarkPullRequestReady(c.Request.Context(), &domain.MarkReadyInput{
+		PullRequestID: req.PullRequestID,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", pr.ID).
+		Str("status", string(pr.Status)).
+		Msg("successfully marked pull request ready")
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": mapPullRequestToAPI(pr),
+	})
+}
+
+// GetPullRequestHistory обрабатывает получение истории событий PR
+func (h *Handler) GetPullRequestHistory(c *gin.Context) {
+	pullRequestID := c.Query("pull_request_id")
+	if pullRequestID == "" {
+		log.Warn().
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("missing pull_request_id parameter")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "pull_request_id parameter is required",
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", pullRequestID).
+		Msg("getting pull request history")
+
+	events, err := h.service.GetPullRequestHistory(c.Request.Context(), pullRequestID)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	eventList := make([]map[string]interface{}, len(events))
+	for i, event := range events {
+		eventList[i] = mapPullRequestEventToAPI(event)
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", pullRequestID).
+		Int("events_count", len(events)).
+		Msg("successfully retrieved pull request history")
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_request_id": pullRequestID,
+		"events":          eventList,
+	})
+}
+
+// ExplainPullRequest обрабатывает получение объяснений выбора ревьюверов PR
+func (h *Handler) ExplainPullRequest(c *gin.Context) {
+	pullRequestID := c.Query("pull_request_id")
+	if pullRequestID == "" {
+		log.Warn().
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("missing pull_request_id parameter")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "pull_request_id parameter is required",
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", pullRequestID).
+		Msg("explaining pull request reviewer selection")
+
+	explanations, err := h.service.ExplainPullRequest(c.Request.Context(), pullRequestID)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	explanationList := make([]map[string]interface{}, len(explanations))
+	for i, explanation := range explanations {
+		explanationList[i] = mapSelectionExplanationToAPI(explanation)
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", pullRequestID).
+		Int("explanations_count", len(explanations)).
+		Msg("successfully retrieved selection explanations")
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_request_id": pullRequestID,
+		"explanations":    explanationList,
+	})
+}
+
+// GetPullRequest обрабатывает получение PR с автором и ревьюверами
+func (h *Handler) GetPullRequest(c *gin.Context) {
+	pullRequestID := c.Query("pull_request_id")
+	if pullRequestID == "" {
+		log.Warn().
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("missing pull_request_id parameter")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "pull_request_id parameter is required",
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", pullRequestID).
+		Msg("getting pull request")
+
+	details, err := h.service.GetPullRequest(c.Request.Context(), pullRequestID)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("pull_request_id", details.ID).
+		Int("reviewers_count", len(details.Reviewers)).
+		Msg("successfully retrieved pull request")
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": mapPullRequestDetailsToAPI(details),
+	})
+}
+
+// ListPullRequests обрабатывает получение страницы PR по фильтрам
+func (h *Handler) ListPullRequests(c *gin.Context) {
+	input, err := parseListPullRequestsQuery(c)
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("invalid list query parameters")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Invalid query parameters: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Msg("listing pull requests")
+
+	page, err := h.service.ListPullRequests(c.Request.Context(), input)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	prList := make([]map[string]interface{}, len(page.PullRequests))
+	for i := range page.PullRequests {
+		prList[i] = mapPullRequestToAPI(&page.PullRequests[i])
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Int("pr_count", len(prList)).
+		Msg("successfully listed pull requests")
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_requests": prList,
+		"next_cursor":   nullableString(page.NextCursor),
+	})
+}
+
+// parseListPullRequestsQuery разбирает фильтры, сортировку и пагинацию списка PR из query
+func parseListPullRequestsQuery(c *gin.Context) (*domain.ListPullRequestsInput, error) {
+	input := &domain.ListPullRequestsInput{
+		PullRequestFilter: domain.PullRequestFilter{
+			AuthorID:   c.Query("author_id"),
+			ReviewerID: c.Query("reviewer_id"),
+			Statuses:   parseStatusQuery(c),
+			TeamName:   c.Query("team_name"),
+			Repository: c.Query("repository"),
+			SortBy:     domain.PullRequestSortField(c.Query("sort_by")),
+			Descending: true,
+		},
+		Cursor: c.Query("cursor"),
+	}
+
+	switch c.DefaultQuery("order", "desc") {
+	case "desc":
+	case "asc":
+		input.Descending = false
+	default:
+		return nil, errors.New("order must be asc or desc")
+	}
+
+	limit, err := parseLimitQuery(c)
+	if err != nil {
+		return nil, err
+	}
+	input.Limit = limit
+
+	for name, target := range map[string]**time.Time{
+		"created_from": &input.CreatedFrom,
+		"created_to":   &input.CreatedTo,
+		"merged_from":  &input.MergedFrom,
+		"merged_to":    &input.MergedTo,
+	} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, errors.New(name + " must be an RFC 3339 timestamp")
+		}
+		*target = &t
+	}
+
+	return input, nil
+}
+
+// parseStatusQuery читает фильтр по статусам: status можно передать несколько раз или через запятую
+func parseStatusQuery(c *gin.Context) []domain.PullRequestStatus {
+	var statuses []domain.PullRequestStatus
+	for _, value := range c.QueryArray("status") {
+		for _, status := range strings.Split(value, ",") {
+			if status != "" {
+				statuses = append(statuses, domain.PullRequestStatus(status))
+			}
+		}
+	}
+	return statuses
+}
+
+// parseLimitQuery читает размер страницы (0 - не задан, значение по умолчанию выберет сервис)
+func parseLimitQuery(c *gin.Context) (int, error) {
+	value := c.Query("limit")
+	if value == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New("limit must be an integer")
+	}
+	return limit, nil
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"avitoTechAutumn2025/internal/api"
+	"avitoTechAutumn2025/internal/api/middleware"
+	"avitoTechAutumn2025/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"net/http"
+)
+
+// SetRepository обрабатывает регистрацию репозитория и его команд-владельцев
+func (h *Handler) SetRepository(c *gin.Context) {
+	var req struct {
+		Repository string   `json:"repository" binding:"required"`
+		OwnerTeams []string `json:"owner_teams"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("repository", req.Repository).
+		Strs("owner_teams", req.OwnerTeams).
+		Msg("setting repository")
+
+	repository, err := h.service.SetRepository(c.Request.Context(), &domain.SetRepositoryInput{
+		Name:       req.Repository,
+		OwnerTeams: req.OwnerTeams,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"repository": mapRepositoryToAPI(repository),
+	})
+}
+
+// GetRepository обрабатывает получение репозитория
+func (h *Handler) GetRepository(c *gin.Context) {
+	name := c.Query("repository")
+	if name == "" {
+		log.Warn().
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("missing repository parameter")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "repository parameter is required",
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("repository", name).
+		Msg("getting repository")
+
+	repository, err := h.service.GetRepository(c.Request.Context(), name)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"repository": mapRepositoryToAPI(repository),
+	})
+}
+
+// ListRepositories обрабатывает получение списка репозиториев
+func (h *Handler) ListRepositories(c *gin.Context) {
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Msg("listing repositories")
+
+	repositories, err := h.service.ListRepositories(c.Request.Context())
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	repositoryList := make([]map[string]interface{}, len(repositories))
+	for i := range repositories {
+		repositoryList[i] = mapRepositoryToAPI(&repositories[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"repositories": repositoryList,
+	})
+}
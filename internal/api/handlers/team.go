@@ -16,6 +16,7 @@ func (h *Handler) AddTeam(c *gin.Context) {
 		Members  []struct {
 			UserID   string `json:"user_id" binding:"required"`
 			Username string `json:"username" binding:"required"`
+			Role     string `json:"role"`
 			IsActive bool   `json:"is_active"`
 		} `json:"members" binding:"required"`
 	}
@@ -47,6 +48,7 @@ func (h *Handler) AddTeam(c *gin.Context) {
 		members[i] = domain.TeamMember{
 			UserID:   m.UserID,
 			Username: m.Username,
+			Role:     domain.TeamRole(m.Role),
 			IsActive: m.IsActive,
 		}
 	}
@@ -114,7 +116,9 @@ func (h *Handler) GetTeam(c *gin.Context) {
 // DeactivateTeam обрабатывает массовую деактивацию всех участников команды
 func (h *Handler) DeactivateTeam(c *gin.Context) {
 	var req struct {
-		TeamName string `json:"team_name" binding:"required"`
+		TeamName            string `json:"team_name" binding:"required"`
+		ReassignOpenReviews bool   `json:"reassign_open_reviews"`
+		DryRun              bool   `json:"dry_run"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -137,10 +141,14 @@ func (h *Handler) DeactivateTeam(c *gin.Context) {
 		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
 		Str("layer", "handler").
 		Str("team_name", req.TeamName).
+		Bool("reassign_open_reviews", req.ReassignOpenReviews).
+		Bool("dry_run", req.DryRun).
 		Msg("deactivating team members")
 
 	input := &domain.DeactivateTeamInput{
-		TeamName: req.TeamName,
+		TeamName:            req.TeamName,
+		ReassignOpenReviews: req.ReassignOpenReviews,
+		DryRun:              req.DryRun,
 	}
 
 	result, err := h.service.DeactivateTeamMembers(c.Request.Context(), input)
@@ -156,8 +164,120 @@ func (h *Handler) DeactivateTeam(c *gin.Context) {
 		Int("deactivated_count", result.DeactivatedUserCount).
 		Msg("successfully deactivated team members")
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"team_name":              result.TeamName,
 		"deactivated_user_count": result.DeactivatedUserCount,
-	})
+		"dry_run":                req.DryRun,
+	}
+	if req.ReassignOpenReviews {
+		response["reassignments"] = mapReassignmentsToAPI(result.Reassignments)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTeamSettings обрабатывает получение настроек назначения ревьюверов команды
+func (h *Handler) GetTeamSettings(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		log.Warn().
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("missing team_name parameter")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "team_name parameter is required",
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("team_name", teamName).
+		Msg("getting team settings")
+
+	settings, err := h.service.GetTeamSettings(c.Request.Context(), teamName)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("team_name", settings.TeamName).
+		Msg("successfully retrieved team settings")
+
+	c.JSON(http.StatusOK, mapTeamSettingsToAPI(settings))
+}
+
+// SetTeamSettings обрабатывает изменение настроек назначения ревьюверов команды
+func (h *Handler) SetTeamSettings(c *gin.Context) {
+	var req struct {
+		TeamName          string   `json:"team_name" binding:"required"`
+		ReviewerCount     *int     `json:"reviewer_count"`
+		MinReviewers      *int     `json:"min_reviewers"`
+		SelectionStrategy *string  `json:"selection_strategy"`
+		FallbackTeams     []string `json:"fallback_teams"`
+		RequiredApprovals *int     `json:"required_approvals"`
+		MaxOpenReviews    *int     `json:"max_open_reviews"`
+		CapacityPolicy    *string  `json:"capacity_policy"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("team_name", req.TeamName).
+		Msg("setting team settings")
+
+	input := &domain.SetTeamSettingsInput{
+		TeamName:          req.TeamName,
+		ReviewerCount:     req.ReviewerCount,
+		MinReviewers:      req.MinReviewers,
+		FallbackTeams:     req.FallbackTeams,
+		RequiredApprovals: req.RequiredApprovals,
+		MaxOpenReviews:    req.MaxOpenReviews,
+	}
+	if req.SelectionStrategy != nil {
+		strategy := domain.SelectionStrategy(*req.SelectionStrategy)
+		input.SelectionStrategy = &strategy
+	}
+	if req.CapacityPolicy != nil {
+		policy := domain.CapacityPolicy(*req.CapacityPolicy)
+		input.CapacityPolicy = &policy
+	}
+
+	settings, err := h.service.SetTeamSettings(c.Request.Context(), input)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("team_name", settings.TeamName).
+		Msg("successfully updated team settings")
+
+	c.JSON(http.StatusOK, mapTeamSettingsToAPI(settings))
 }
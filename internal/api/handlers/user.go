@@ -3,16 +3,19 @@ package handlers
 import (
 	"avitoTechAutumn2025/internal/api"
 	"avitoTechAutumn2025/internal/api/middleware"
+	"avitoTechAutumn2025/internal/domain"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 	"net/http"
+	"time"
 )
 
 // SetIsActive обрабатывает изменение статуса активности пользователя
 func (h *Handler) SetIsActive(c *gin.Context) {
 	var req struct {
-		UserID   string `json:"user_id" binding:"required"`
-		IsActive bool   `json:"is_active"`
+		UserID              string `json:"user_id" binding:"required"`
+		IsActive            bool   `json:"is_active"`
+		ReassignOpenReviews bool   `json:"reassign_open_reviews"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -38,7 +41,11 @@ func (h *Handler) SetIsActive(c *gin.Context) {
 		Bool("is_active", req.IsActive).
 		Msg("setting user active status")
 
-	user, err := h.service.SetUserIsActive(c.Request.Context(), req.UserID, req.IsActive)
+	result, err := h.service.SetUserIsActive(c.Request.Context(), &domain.SetUserActiveInput{
+		UserID:              req.UserID,
+		IsActive:            req.IsActive,
+		ReassignOpenReviews: req.ReassignOpenReviews,
+	})
 	if err != nil {
 		handleDomainError(c, err)
 		return
@@ -48,10 +55,16 @@ func (h *Handler) SetIsActive(c *gin.Context) {
 		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
 		Str("layer", "handler").
 		Str("user_id", req.UserID).
-		Bool("is_active", user.IsActive).
+		Bool("is_active", result.User.IsActive).
+		Int("reassigned_pr_count", len(result.Reassignments)).
 		Msg("successfully updated user active status")
 
-	c.JSON(http.StatusOK, mapUserToAPI(user))
+	response := mapUserToAPI(&result.User)
+	if req.ReassignOpenReviews {
+		response["reassignments"] = mapReassignmentsToAPI(result.Reassignments)
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // GetReview обрабатывает получение списка pull request-ов, в которых пользователь является ревьювером
@@ -72,20 +85,48 @@ func (h *Handler) GetReview(c *gin.Context) {
 		return
 	}
 
+	limit, err := parseLimitQuery(c)
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("invalid review query parameters")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Invalid query parameters: " + err.Error(),
+			},
+		})
+		return
+	}
+
 	log.Info().
 		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
 		Str("layer", "handler").
 		Str("user_id", userId).
 		Msg("getting pull requests reviewed by user")
 
-	prs, err := h.service.GetReviewerAssignments(c.Request.Context(), userId)
+	page, err := h.service.GetReviewerAssignments(c.Request.Context(), &domain.ReviewerAssignmentsInput{
+		UserID:   userId,
+		Statuses: parseStatusQuery(c),
+		Limit:    limit,
+		Cursor:   c.Query("cursor"),
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	capacity, err := h.service.GetReviewCapacity(c.Request.Context(), userId)
 	if err != nil {
 		handleDomainError(c, err)
 		return
 	}
 
-	prList := make([]map[string]interface{}, len(prs))
-	for i, pr := range prs {
+	prList := make([]map[string]interface{}, len(page.PullRequests))
+	for i, pr := range page.PullRequests {
 		prList[i] = mapPullRequestShortToAPI(pr)
 	}
 
@@ -93,11 +134,214 @@ func (h *Handler) GetReview(c *gin.Context) {
 		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
 		Str("layer", "handler").
 		Str("user_id", userId).
-		Int("pr_count", len(prs)).
+		Int("pr_count", len(prList)).
 		Msg("successfully retrieved reviewed PRs")
 
 	c.JSON(http.StatusOK, gin.H{
-		"user_id":       userId,
-		"pull_requests": prList,
+		"user_id":          userId,
+		"pull_requests":    prList,
+		"next_cursor":      nullableString(page.NextCursor),
+		"open_reviews":     capacity.OpenReviews,
+		"max_open_reviews": mapReviewLimitToAPI(capacity.MaxOpenReviews),
+	})
+}
+
+// SetReviewLimit обрабатывает изменение личного лимита открытых ревью пользователя
+func (h *Handler) SetReviewLimit(c *gin.Context) {
+	var req struct {
+		UserID         string `json:"user_id" binding:"required"`
+		MaxOpenReviews int    `json:"max_open_reviews"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("user_id", req.UserID).
+		Int("max_open_reviews", req.MaxOpenReviews).
+		Msg("setting user review limit")
+
+	user, err := h.service.SetUserReviewLimit(c.Request.Context(), req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("user_id", user.UserID).
+		Int("max_open_reviews", user.MaxOpenReviews).
+		Msg("successfully updated user review limit")
+
+	c.JSON(http.StatusOK, mapUserToAPI(user))
+}
+
+// AddAbsence обрабатывает добавление периода отсутствия пользователя
+func (h *Handler) AddAbsence(c *gin.Context) {
+	var req struct {
+		UserID   string    `json:"user_id" binding:"required"`
+		StartsAt time.Time `json:"starts_at" binding:"required"`
+		EndsAt   time.Time `json:"ends_at" binding:"required"`
+		Reason   string    `json:"reason"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("user_id", req.UserID).
+		Msg("adding user absence")
+
+	absence, err := h.service.AddUserAbsence(c.Request.Context(), &domain.AddUserAbsenceInput{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("user_id", absence.UserID).
+		Int64("absence_id", absence.ID).
+		Msg("successfully added user absence")
+
+	c.JSON(http.StatusCreated, gin.H{
+		"absence": mapUserAbsenceToAPI(*absence),
+	})
+}
+
+// GetAbsences обрабатывает получение периодов отсутствия пользователя
+func (h *Handler) GetAbsences(c *gin.Context) {
+	userId := c.Query("user_id")
+	if userId == "" {
+		log.Warn().
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("missing user_id parameter")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "user_id parameter is required",
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("user_id", userId).
+		Msg("getting user absences")
+
+	absences, err := h.service.GetUserAbsences(c.Request.Context(), userId)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	absenceList := make([]map[string]interface{}, len(absences))
+	for i, absence := range absences {
+		absenceList[i] = mapUserAbsenceToAPI(absence)
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("user_id", userId).
+		Int("absence_count", len(absences)).
+		Msg("successfully retrieved user absences")
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":  userId,
+		"absences": absenceList,
+	})
+}
+
+// DeleteAbsence обрабатывает удаление периода отсутствия пользователя
+func (h *Handler) DeleteAbsence(c *gin.Context) {
+	var req struct {
+		UserID    string `json:"user_id" binding:"required"`
+		AbsenceID int64  `json:"absence_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("user_id", req.UserID).
+		Int64("absence_id", req.AbsenceID).
+		Msg("deleting user absence")
+
+	err := h.service.DeleteUserAbsence(c.Request.Context(), &domain.DeleteUserAbsenceInput{
+		UserID:    req.UserID,
+		AbsenceID: req.AbsenceID,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("user_id", req.UserID).
+		Int64("absence_id", req.AbsenceID).
+		Msg("successfully deleted user absence")
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":    req.UserID,
+		"absence_id": req.AbsenceID,
 	})
 }
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"avitoTechAutumn2025/internal/api"
+	"avitoTechAutumn2025/internal/api/middleware"
+	"avitoTechAutumn2025/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"strconv"
+)
+
+// CreateWebhook обрабатывает подписку внешней системы на события
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	var req struct {
+		URL        string   `json:"url" binding:"required"`
+		Secret     string   `json:"secret" binding:"required"`
+		EventTypes []string `json:"event_types"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Str("url", req.URL).
+		Strs("event_types", req.EventTypes).
+		Msg("creating webhook subscription")
+
+	eventTypes := make([]domain.WebhookEventType, len(req.EventTypes))
+	for i, eventType := range req.EventTypes {
+		eventTypes[i] = domain.WebhookEventType(eventType)
+	}
+
+	subscription, err := h.service.CreateWebhook(c.Request.Context(), &domain.CreateWebhookInput{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Int64("subscription_id", subscription.ID).
+		Msg("successfully created webhook subscription")
+
+	c.JSON(http.StatusCreated, gin.H{
+		"webhook": mapWebhookSubscriptionToAPI(*subscription),
+	})
+}
+
+// ListWebhooks обрабатывает получение всех подписок на события
+func (h *Handler) ListWebhooks(c *gin.Context) {
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Msg("listing webhook subscriptions")
+
+	subscriptions, err := h.service.ListWebhooks(c.Request.Context())
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	webhookList := make([]map[string]interface{}, len(subscriptions))
+	for i, subscription := range subscriptions {
+		webhookList[i] = mapWebhookSubscriptionToAPI(subscription)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"webhooks": webhookList,
+	})
+}
+
+// DeleteWebhook обрабатывает удаление подписки на события
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	var req struct {
+		SubscriptionID int64 `json:"subscription_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("failed to parse request")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "Failed to parse request: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Int64("subscription_id", req.SubscriptionID).
+		Msg("deleting webhook subscription")
+
+	if err := h.service.DeleteWebhook(c.Request.Context(), req.SubscriptionID); err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"subscription_id": req.SubscriptionID,
+	})
+}
+
+// GetWebhookDeliveries обрабатывает получение журнала доставок подписки
+func (h *Handler) GetWebhookDeliveries(c *gin.Context) {
+	subscriptionID, err := strconv.ParseInt(c.Query("subscription_id"), 10, 64)
+	if err != nil {
+		log.Warn().
+			Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+			Str("layer", "handler").
+			Msg("missing or invalid subscription_id parameter")
+
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: "subscription_id parameter is required and must be an integer",
+			},
+		})
+		return
+	}
+
+	limit, err := parseLimitQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: api.Error{
+				Code:    api.ErrCodeInvalidRequest,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Int64("subscription_id", subscriptionID).
+		Msg("getting webhook deliveries")
+
+	deliveries, err := h.service.GetWebhookDeliveries(c.Request.Context(), &domain.WebhookDeliveriesInput{
+		SubscriptionID: subscriptionID,
+		Status:         domain.WebhookDeliveryStatus(c.Query("status")),
+		Limit:          limit,
+	})
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	deliveryList := make([]map[string]interface{}, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryList[i] = mapWebhookDeliveryToAPI(delivery)
+	}
+
+	log.Info().
+		Str("request_id", c.MustGet(middleware.RequestIDKey).(string)).
+		Str("layer", "handler").
+		Int64("subscription_id", subscriptionID).
+		Int("deliveries_count", len(deliveries)).
+		Msg("successfully retrieved webhook deliveries")
+
+	c.JSON(http.StatusOK, gin.H{
+		"subscription_id": subscriptionID,
+		"deliveries":      deliveryList,
+	})
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"os"
@@ -23,16 +24,20 @@ func AuthMiddleware() gin.HandlerFunc {
 			adminToken := os.Getenv("ADMIN_TOKEN")
 			userToken := os.Getenv("USER_TOKEN")
 
+			var role string
 			switch token {
 			case adminToken:
-				c.Set("role", "admin")
+				role = "admin"
 			case userToken:
-				c.Set("role", "user")
+				role = "user"
 			default:
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
 				c.Abort()
 				return
 			}
+
+			c.Set("role", role)
+			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ActorKey, role))
 		}
 
 		c.Next()
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"os"
+)
+
+// GitHubSignatureHeader - подпись тела вебхука GitHub: "sha256=" + hex(HMAC-SHA256(secret, body))
+const GitHubSignatureHeader = "X-Hub-Signature-256"
+
+// maxGitHubPayloadSize - GitHub не присылает вебхуки больше 25 МБ
+const maxGitHubPayloadSize = 25 << 20
+
+// RequireGitHubSignature пропускает только запросы, подписанные секретом из GITHUB_WEBHOOK_SECRET.
+// Если секрет не задан, все запросы отклоняются. Инициатором действий становится "github".
+func RequireGitHubSignature() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := os.Getenv("GITHUB_WEBHOOK_SECRET")
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxGitHubPayloadSize))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			c.Abort()
+			return
+		}
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+		if secret == "" || !hmac.Equal([]byte(expected), []byte(c.GetHeader(GitHubSignatureHeader))) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid signature"})
+			c.Abort()
+			return
+		}
+
+		// Тело уже прочитано для проверки подписи - возвращаем его обработчику
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ActorKey, "github"))
+
+		c.Next()
+	}
+}
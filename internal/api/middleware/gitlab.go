@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"os"
+)
+
+// GitLabTokenHeader - секретный токен вебхука GitLab, передаётся как есть
+const GitLabTokenHeader = "X-Gitlab-Token"
+
+// RequireGitLabToken пропускает только запросы с токеном из GITLAB_WEBHOOK_TOKEN.
+// Если токен не задан, все запросы отклоняются. Инициатором действий становится "gitlab".
+func RequireGitLabToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := os.Getenv("GITLAB_WEBHOOK_TOKEN")
+
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(c.GetHeader(GitLabTokenHeader))) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ActorKey, "gitlab"))
+
+		c.Next()
+	}
+}
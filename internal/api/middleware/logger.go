@@ -13,6 +13,8 @@ type contextKey string
 
 const (
 	RequestIDKey contextKey = "request_id"
+	// ActorKey - роль из токена запроса, записывается в историю PR как инициатор действия
+	ActorKey contextKey = "actor"
 )
 
 func LoggerMiddleware() gin.HandlerFunc {
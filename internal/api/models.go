@@ -1,12 +1,18 @@
 package api
 
 const (
-	ErrCodeTeamExists        = "TEAM_EXISTS"
-	ErrCodePullRequestExists = "PR_EXISTS"
-	ErrCodePullRequestMerged = "PR_MERGED"
-	ErrCodeNotAssigned       = "NOT_ASSIGNED"
-	ErrCodeNoCandidate       = "NO_CANDIDATE"
-	ErrCodeNotFound          = "NOT_FOUND"
+	ErrCodeTeamExists         = "TEAM_EXISTS"
+	ErrCodePullRequestExists  = "PR_EXISTS"
+	ErrCodePullRequestMerged  = "PR_MERGED"
+	ErrCodeNotAssigned        = "NOT_ASSIGNED"
+	ErrCodeNoCandidate        = "NO_CANDIDATE"
+	ErrCodeNotEnoughReviewers = "NOT_ENOUGH_REVIEWERS"
+	ErrCodeAllAtCapacity      = "ALL_AT_CAPACITY"
+	ErrCodeNotApproved        = "NOT_APPROVED"
+	ErrCodePullRequestNotOpen = "PR_NOT_OPEN"
+	ErrCodeInvalidTransition  = "INVALID_TRANSITION"
+	ErrCodeNotFound           = "NOT_FOUND"
+	ErrCodeUnknownUser        = "UNKNOWN_USER"
 
 	ErrCodeInternalError  = "INTERNAL_ERROR"
 	ErrCodeInvalidRequest = "INVALID_REQUEST"
@@ -3,7 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Config struct {
@@ -11,7 +13,13 @@ type Config struct {
 	ProductionType string
 	LogPath        string
 
-	Database Database
+	Database   Database
+	Assignment Assignment
+	Absence    Absence
+	Webhook    Webhook
+	Outbox     Outbox
+	GitHub     GitHub
+	GitLab     GitLab
 }
 
 type Database struct {
@@ -23,7 +31,81 @@ type Database struct {
 	SSLMode  string
 }
 
-func NewEnvConfig() *Config {
+// Assignment - настройки выбора ревьюверов
+type Assignment struct {
+	Strategy       string            // глобальная стратегия (random, round_robin, least_loaded, weighted)
+	TeamStrategies map[string]string // стратегии отдельных команд: team_name -> strategy
+	UserWeights    map[string]int    // веса пользователей для стратегии weighted: user_id -> weight
+}
+
+// Absence - настройки фоновой обработки отсутствий пользователей
+type Absence struct {
+	CheckInterval time.Duration // как часто проверять начавшиеся отсутствия
+}
+
+// Webhook - настройки доставки событий подписчикам
+type Webhook struct {
+	DeliveryInterval time.Duration // как часто проверять доставки, время попытки которых наступило
+	Timeout          time.Duration // таймаут HTTP запроса к подписчику
+	MaxAttempts      int           // попыток доставки одного события
+	RetryBackoff     time.Duration // задержка перед первым повтором, далее удваивается
+}
+
+// Outbox - настройки публикации событий из outbox
+type Outbox struct {
+	RelayInterval time.Duration // как часто проверять неопубликованные события
+	RetryBackoff  time.Duration // задержка перед первой повторной публикацией, далее удваивается
+	Sinks         []string      // приёмники событий: webhook, log
+}
+
+// GitHub - настройки приёма вебхуков GitHub (секрет подписи читается из GITHUB_WEBHOOK_SECRET)
+type GitHub struct {
+	Logins map[string]string // логин GitHub -> user_id
+}
+
+// GitLab - настройки приёма вебхуков GitLab (токен читается из GITLAB_WEBHOOK_TOKEN)
+type GitLab struct {
+	Logins map[string]string // username GitLab -> user_id
+}
+
+// Значения по умолчанию для OUTBOX_* переменных
+const (
+	defaultOutboxRelayInterval = time.Second
+	defaultOutboxRetryBackoff  = 5 * time.Second
+	defaultOutboxSinks         = "webhook"
+)
+
+// Значения по умолчанию для WEBHOOK_* переменных
+const (
+	defaultWebhookDeliveryInterval = 5 * time.Second
+	defaultWebhookTimeout          = 10 * time.Second
+	defaultWebhookMaxAttempts      = 5
+	defaultWebhookRetryBackoff     = 10 * time.Second
+)
+
+// defaultAbsenceCheckInterval - интервал проверки отсутствий, если ABSENCE_CHECK_INTERVAL не задан
+const defaultAbsenceCheckInterval = time.Minute
+
+// NewEnvConfig читает конфигурацию из переменных окружения.
+// Некорректные пары в ASSIGNMENT_* и *_USER_MAPPING возвращаются ошибкой, чтобы сервис не стартовал с частью настроек.
+func NewEnvConfig() (*Config, error) {
+	teamStrategies, err := parsePairs(os.Getenv("ASSIGNMENT_TEAM_STRATEGIES"))
+	if err != nil {
+		return nil, fmt.Errorf("ASSIGNMENT_TEAM_STRATEGIES: %w", err)
+	}
+	userWeights, err := parseWeights(os.Getenv("ASSIGNMENT_USER_WEIGHTS"))
+	if err != nil {
+		return nil, fmt.Errorf("ASSIGNMENT_USER_WEIGHTS: %w", err)
+	}
+	githubLogins, err := parsePairs(os.Getenv("GITHUB_USER_MAPPING"))
+	if err != nil {
+		return nil, fmt.Errorf("GITHUB_USER_MAPPING: %w", err)
+	}
+	gitlabLogins, err := parsePairs(os.Getenv("GITLAB_USER_MAPPING"))
+	if err != nil {
+		return nil, fmt.Errorf("GITLAB_USER_MAPPING: %w", err)
+	}
+
 	return &Config{
 		Port:           os.Getenv("APP_PORT"),
 		ProductionType: os.Getenv("APP_PRODUCTION_TYPE"),
@@ -37,7 +119,112 @@ func NewEnvConfig() *Config {
 			Name:     os.Getenv("DB_NAME"),
 			SSLMode:  os.Getenv("DB_SSLMODE"),
 		},
+
+		Assignment: Assignment{
+			Strategy:       os.Getenv("ASSIGNMENT_STRATEGY"),
+			TeamStrategies: teamStrategies,
+			UserWeights:    userWeights,
+		},
+
+		Absence: Absence{
+			CheckInterval: parseDuration(os.Getenv("ABSENCE_CHECK_INTERVAL"), defaultAbsenceCheckInterval),
+		},
+
+		Webhook: Webhook{
+			DeliveryInterval: parseDuration(os.Getenv("WEBHOOK_DELIVERY_INTERVAL"), defaultWebhookDeliveryInterval),
+			Timeout:          parseDuration(os.Getenv("WEBHOOK_TIMEOUT"), defaultWebhookTimeout),
+			MaxAttempts:      parsePositiveInt(os.Getenv("WEBHOOK_MAX_ATTEMPTS"), defaultWebhookMaxAttempts),
+			RetryBackoff:     parseDuration(os.Getenv("WEBHOOK_RETRY_BACKOFF"), defaultWebhookRetryBackoff),
+		},
+
+		Outbox: Outbox{
+			RelayInterval: parseDuration(os.Getenv("OUTBOX_RELAY_INTERVAL"), defaultOutboxRelayInterval),
+			RetryBackoff:  parseDuration(os.Getenv("OUTBOX_RETRY_BACKOFF"), defaultOutboxRetryBackoff),
+			Sinks:         parseList(os.Getenv("OUTBOX_SINKS"), defaultOutboxSinks),
+		},
+
+		GitHub: GitHub{
+			Logins: githubLogins,
+		},
+
+		GitLab: GitLab{
+			Logins: gitlabLogins,
+		},
+	}, nil
+}
+
+// parseDuration разбирает длительность вида "30s", "5m"; пустое или некорректное значение заменяется на fallback
+func parseDuration(raw string, fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+// parsePositiveInt разбирает положительное число; пустое или некорректное значение заменяется на fallback
+func parsePositiveInt(raw string, fallback int) int {
+	n, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
+
+// parseList разбирает строку вида "a,b", пустые элементы пропускаются; пустое значение заменяется на fallback
+func parseList(raw, fallback string) []string {
+	if strings.TrimSpace(raw) == "" {
+		raw = fallback
+	}
+
+	items := []string{}
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parsePairs разбирает строку вида "key1:value1,key2:value2"; пустые элементы пропускаются,
+// а пара без ключа или значения и повтор ключа возвращаются ошибкой
+func parsePairs(raw string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(item, ":")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("malformed pair %q, expected key:value", item)
+		}
+		if _, exists := pairs[key]; exists {
+			return nil, fmt.Errorf("duplicate key %q", key)
+		}
+		pairs[key] = value
+	}
+	return pairs, nil
+}
+
+// parseWeights разбирает строку вида "user1:3,user2:1"; вес должен быть неотрицательным целым
+func parseWeights(raw string) (map[string]int, error) {
+	pairs, err := parsePairs(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[string]int, len(pairs))
+	for key, value := range pairs {
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q for %q, expected non-negative integer", value, key)
+		}
+		weights[key] = weight
 	}
+	return weights, nil
 }
 
 func (config *Config) PrintConfigWithHiddenSecrets() {
@@ -64,5 +251,30 @@ func (config *Config) PrintConfigWithHiddenSecrets() {
 	fmt.Printf("\tName: %s\n", config.Database.Name)
 	fmt.Printf("\tSSLMode: %s\n", config.Database.SSLMode)
 
+	fmt.Println("\nAssignment Configuration:")
+	fmt.Printf("\tStrategy: %s\n", config.Assignment.Strategy)
+	fmt.Printf("\tTeamStrategies: %v\n", config.Assignment.TeamStrategies)
+	fmt.Printf("\tUserWeights: %v\n", config.Assignment.UserWeights)
+
+	fmt.Println("\nAbsence Configuration:")
+	fmt.Printf("\tCheckInterval: %s\n", config.Absence.CheckInterval)
+
+	fmt.Println("\nWebhook Configuration:")
+	fmt.Printf("\tDeliveryInterval: %s\n", config.Webhook.DeliveryInterval)
+	fmt.Printf("\tTimeout: %s\n", config.Webhook.Timeout)
+	fmt.Printf("\tMaxAttempts: %d\n", config.Webhook.MaxAttempts)
+	fmt.Printf("\tRetryBackoff: %s\n", config.Webhook.RetryBackoff)
+
+	fmt.Println("\nOutbox Configuration:")
+	fmt.Printf("\tRelayInterval: %s\n", config.Outbox.RelayInterval)
+	fmt.Printf("\tRetryBackoff: %s\n", config.Outbox.RetryBackoff)
+	fmt.Printf("\tSinks: %v\n", config.Outbox.Sinks)
+
+	fmt.Println("\nGitHub Configuration:")
+	fmt.Printf("\tLogins: %v\n", config.GitHub.Logins)
+
+	fmt.Println("\nGitLab Configuration:")
+	fmt.Printf("\tLogins: %v\n", config.GitLab.Logins)
+
 	fmt.Println("\n===================================")
 }
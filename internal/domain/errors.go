@@ -10,14 +10,24 @@ import (
 type ErrorCode string
 
 const (
-	ErrorCodeTeamExists        ErrorCode = "TEAM_EXISTS"
-	ErrorCodePullRequestExists ErrorCode = "PR_EXISTS"
-	ErrorCodePullRequestMerged ErrorCode = "PR_MERGED"
-	ErrorCodeReviewerMissing   ErrorCode = "NOT_ASSIGNED"
-	ErrorCodeNoCandidate       ErrorCode = "NO_CANDIDATE"
-	ErrorCodeNotFound          ErrorCode = "NOT_FOUND"
-	ErrorCodeInternalError     ErrorCode = "INTERNAL_ERROR"
-	ErrorCodeInvalidInput      ErrorCode = "INVALID_INPUT"
+	ErrorCodeTeamExists         ErrorCode = "TEAM_EXISTS"
+	ErrorCodePullRequestExists  ErrorCode = "PR_EXISTS"
+	ErrorCodePullRequestMerged  ErrorCode = "PR_MERGED"
+	ErrorCodeReviewerMissing    ErrorCode = "NOT_ASSIGNED"
+	ErrorCodeNoCandidate        ErrorCode = "NO_CANDIDATE"
+	ErrorCodeNotEnoughReviewers ErrorCode = "NOT_ENOUGH_REVIEWERS"
+	ErrorCodeAllAtCapacity      ErrorCode = "ALL_AT_CAPACITY"
+	ErrorCodeNotApproved        ErrorCode = "NOT_APPROVED"
+	ErrorCodePullRequestNotOpen ErrorCode = "PR_NOT_OPEN"
+	ErrorCodeInvalidTransition  ErrorCode = "INVALID_TRANSITION"
+	ErrorCodeNotFound           ErrorCode = "NOT_FOUND"
+	ErrorCodeInternalError      ErrorCode = "INTERNAL_ERROR"
+	ErrorCodeInvalidInput       ErrorCode = "INVALID_INPUT"
+	ErrorCodeUnknownUser        ErrorCode = "UNKNOWN_USER"
+	ErrorCodeNotTeamMember      ErrorCode = "NOT_TEAM_MEMBER"
+	ErrorCodeAlreadyTeamMember  ErrorCode = "ALREADY_TEAM_MEMBER"
+	ErrorCodeLastTeam           ErrorCode = "LAST_TEAM"
+	ErrorCodeTeamHasOpenPRs     ErrorCode = "TEAM_HAS_OPEN_PRS"
 )
 
 // Error - доменная ошибка с HTTP статусом и кодом
@@ -93,6 +103,14 @@ var (
 		nil,
 	)
 
+	// ErrReviewOnMerged - попытка оставить решение по уже смерженному PR
+	ErrReviewOnMerged = NewError(
+		http.StatusConflict,
+		ErrorCodePullRequestMerged,
+		"cannot review merged PR",
+		nil,
+	)
+
 	// ErrReviewerMissing - указанный пользователь не является ревьювером этого PR
 	ErrReviewerMissing = NewError(
 		http.StatusConflict,
@@ -109,6 +127,86 @@ var (
 		nil,
 	)
 
+	// ErrNotEnoughReviewers - в команде меньше доступных кандидатов, чем требуют её настройки
+	ErrNotEnoughReviewers = NewError(
+		http.StatusConflict,
+		ErrorCodeNotEnoughReviewers,
+		"not enough active reviewers to satisfy team minimum",
+		nil,
+	)
+
+	// ErrAllAtCapacity - все кандидаты достигли лимита открытых ревью, а команда запретила превышение
+	ErrAllAtCapacity = NewError(
+		http.StatusConflict,
+		ErrorCodeAllAtCapacity,
+		"all reviewer candidates are at their open review limit",
+		nil,
+	)
+
+	// ErrNotApproved - PR не набрал нужного числа одобрений или у ревьювера остались запрошенные изменения
+	ErrNotApproved = NewError(
+		http.StatusConflict,
+		ErrorCodeNotApproved,
+		"pull request lacks required approvals or has requested changes",
+		nil,
+	)
+
+	// ErrPullRequestNotOpen - операция над ревьюверами черновика или закрытого PR
+	ErrPullRequestNotOpen = NewError(
+		http.StatusConflict,
+		ErrorCodePullRequestNotOpen,
+		"pull request is not open",
+		nil,
+	)
+
+	// ErrInvalidTransition - переход между статусами PR не разрешён
+	ErrInvalidTransition = NewError(
+		http.StatusConflict,
+		ErrorCodeInvalidTransition,
+		"pull request status does not allow this transition",
+		nil,
+	)
+
+	// ErrUnknownExternalUser - логин из внешней системы не сопоставлен ни одному пользователю
+	ErrUnknownExternalUser = NewError(
+		http.StatusUnprocessableEntity,
+		ErrorCodeUnknownUser,
+		"external login is not mapped to a user",
+		nil,
+	)
+
+	// ErrNotTeamMember - пользователь не состоит в указанной команде
+	ErrNotTeamMember = NewError(
+		http.StatusBadRequest,
+		ErrorCodeNotTeamMember,
+		"user is not a member of the team",
+		nil,
+	)
+
+	// ErrAlreadyTeamMember - пользователь уже состоит в команде, в которую его переводят
+	ErrAlreadyTeamMember = NewError(
+		http.StatusConflict,
+		ErrorCodeAlreadyTeamMember,
+		"user is already a member of the team",
+		nil,
+	)
+
+	// ErrLastTeam - пользователь остался бы без команды
+	ErrLastTeam = NewError(
+		http.StatusConflict,
+		ErrorCodeLastTeam,
+		"user must belong to at least one team",
+		nil,
+	)
+
+	// ErrTeamHasOpenPRs - у команды есть открытые или черновые PR, удалять её нельзя
+	ErrTeamHasOpenPRs = NewError(
+		http.StatusConflict,
+		ErrorCodeTeamHasOpenPRs,
+		"team has open pull requests",
+		nil,
+	)
+
 	// ErrInvalidInput - невалидные входные данные
 	ErrInvalidInput = NewError(
 		http.StatusBadRequest,
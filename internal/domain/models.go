@@ -6,8 +6,134 @@ import "time"
 type PullRequestStatus string
 
 const (
+	PullRequestStatusDraft  PullRequestStatus = "DRAFT"
 	PullRequestStatusOpen   PullRequestStatus = "OPEN"
 	PullRequestStatusMerged PullRequestStatus = "MERGED"
+	PullRequestStatusClosed PullRequestStatus = "CLOSED"
+)
+
+// ReviewState - решение ревьювера по PR
+type ReviewState string
+
+const (
+	ReviewStatePending          ReviewState = "PENDING"
+	ReviewStateApproved         ReviewState = "APPROVED"
+	ReviewStateChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewStateDismissed        ReviewState = "DISMISSED"
+)
+
+// SelectionStrategy - стратегия выбора ревьюверов из списка кандидатов
+type SelectionStrategy string
+
+const (
+	SelectionStrategyRandom      SelectionStrategy = "random"
+	SelectionStrategyRoundRobin  SelectionStrategy = "round_robin"
+	SelectionStrategyLeastLoaded SelectionStrategy = "least_loaded"
+	SelectionStrategyWeighted    SelectionStrategy = "weighted"
+)
+
+// CapacityPolicy - что делать, если все кандидаты достигли лимита открытых ревью
+type CapacityPolicy string
+
+const (
+	CapacityPolicyReject            CapacityPolicy = "reject"              // вернуть ошибку ALL_AT_CAPACITY
+	CapacityPolicyAssignLeastLoaded CapacityPolicy = "assign_least_loaded" // назначить наименее загруженных сверх лимита
+)
+
+// TeamRole - роль участника в команде
+type TeamRole string
+
+const (
+	TeamRoleMember     TeamRole = "MEMBER"
+	TeamRoleMaintainer TeamRole = "MAINTAINER"
+)
+
+// PullRequestSortField - поле сортировки списка PR
+type PullRequestSortField string
+
+const (
+	PullRequestSortCreatedAt PullRequestSortField = "created_at"
+	PullRequestSortMergedAt  PullRequestSortField = "merged_at" // в список попадают только смерженные PR
+)
+
+// Размер страницы списков по умолчанию и максимальный
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// PullRequestEventType - тип события в истории PR
+type PullRequestEventType string
+
+const (
+	PullRequestEventCreated          PullRequestEventType = "CREATED"
+	PullRequestEventReady            PullRequestEventType = "READY"
+	PullRequestEventReviewerAssigned PullRequestEventType = "REVIEWER_ASSIGNED"
+	PullRequestEventReviewerReplaced PullRequestEventType = "REVIEWER_REPLACED"
+	PullRequestEventReviewerRemoved  PullRequestEventType = "REVIEWER_REMOVED"
+	PullRequestEventReviewSubmitted  PullRequestEventType = "REVIEW_SUBMITTED"
+	PullRequestEventMerged           PullRequestEventType = "MERGED"
+	PullRequestEventClosed           PullRequestEventType = "CLOSED"
+	PullRequestEventReopened         PullRequestEventType = "REOPENED"
+)
+
+// Причины изменения состава ревьюверов (PullRequestEvent.Reason)
+const (
+	EventReasonManual   = "manual"   // переназначение через /pullRequest/reassign
+	EventReasonInactive = "inactive" // ревьювер деактивирован
+	EventReasonAbsence  = "absence"  // у ревьювера начался период отсутствия
+	EventReasonForced   = "forced"   // merge в обход проверки одобрений
+)
+
+// SelectionTrigger - операция, при которой выбирались ревьюверы
+type SelectionTrigger string
+
+const (
+	SelectionTriggerCreated  SelectionTrigger = "CREATED"  // создание PR
+	SelectionTriggerReady    SelectionTrigger = "READY"    // перевод черновика или закрытого PR в OPEN
+	SelectionTriggerManual   SelectionTrigger = "MANUAL"   // переназначение через /pullRequest/reassign
+	SelectionTriggerInactive SelectionTrigger = "INACTIVE" // замена деактивированного ревьювера
+	SelectionTriggerAbsence  SelectionTrigger = "ABSENCE"  // замена отсутствующего ревьювера
+)
+
+// ExclusionReason - причина, по которой пользователь не рассматривался при выборе ревьюверов
+type ExclusionReason string
+
+const (
+	ExclusionReasonAuthor          ExclusionReason = "AUTHOR"
+	ExclusionReasonInactive        ExclusionReason = "INACTIVE"
+	ExclusionReasonAlreadyAssigned ExclusionReason = "ALREADY_ASSIGNED"
+	ExclusionReasonAtCapacity      ExclusionReason = "AT_CAPACITY"
+	ExclusionReasonOutOfOffice     ExclusionReason = "OUT_OF_OFFICE"
+)
+
+// WebhookEventType - тип события, о котором уведомляются подписчики вебхуков
+type WebhookEventType string
+
+const (
+	WebhookEventPullRequestCreated WebhookEventType = "pull_request.created"
+	WebhookEventPullRequestMerged  WebhookEventType = "pull_request.merged"
+	WebhookEventReviewerReassigned WebhookEventType = "pull_request.reviewer_reassigned"
+	WebhookEventUserDeactivated    WebhookEventType = "user.deactivated"
+	WebhookEventTeamDeactivated    WebhookEventType = "team.deactivated"
+)
+
+// WebhookEventTypes - все типы событий вебхуков
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventPullRequestCreated,
+	WebhookEventPullRequestMerged,
+	WebhookEventReviewerReassigned,
+	WebhookEventUserDeactivated,
+	WebhookEventTeamDeactivated,
+}
+
+// WebhookDeliveryStatus - состояние доставки события подписчику
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"   // ждёт первой или повторной попытки
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "DELIVERED" // подписчик ответил 2xx
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"    // попытки исчерпаны
 )
 
 // PullRequest - domain модель pull request
@@ -17,16 +143,158 @@ type PullRequest struct {
 	AuthorID          string
 	Status            PullRequestStatus
 	AssignedReviewers []string
+	ReviewerTeams     map[string]string // reviewer_id -> команда, из которой выбран ревьювер (заполняется при назначении)
+	ReviewerStates    []ReviewerState   // решения ревьюверов (заполняется при чтении PR из хранилища)
 	CreatedAt         *time.Time
 	MergedAt          *time.Time
+	ClosedAt          *time.Time
+	MergeForced       bool     // смержен администратором в обход проверки одобрений
+	Repository        string   // репозиторий PR (пусто - не указан); команды-владельцы репозитория выбирают ревьюверов
+	TeamName          string   // команда, от имени которой создан PR (пусто - основная команда автора)
+	ChangedFiles      []string // пути изменённых файлов для выбора владельцев кода по CODEOWNERS
+	CodeOwners        string   // CODEOWNERS, переданный при создании; пусто - зарегистрированный для Repository
+}
+
+// PullRequestDetails - PR вместе с данными автора и назначенных ревьюверов
+type PullRequestDetails struct {
+	PullRequest
+	Author    User
+	Reviewers []User // в порядке AssignedReviewers
+}
+
+// ReviewerState - текущее решение одного ревьювера по PR
+type ReviewerState struct {
+	ReviewerID string
+	State      ReviewState
+	UpdatedAt  time.Time // когда ревьювер был назначен или изменил решение
 }
 
 // PullRequestShort - краткая информация о PR для списков
 type PullRequestShort struct {
-	ID       string
-	Name     string
-	AuthorID string
-	Status   PullRequestStatus
+	ID        string
+	Name      string
+	AuthorID  string
+	Status    PullRequestStatus
+	CreatedAt time.Time
+}
+
+// PullRequestEvent - запись в истории PR (только добавляется, не изменяется)
+type PullRequestEvent struct {
+	ID            int64
+	PullRequestID string
+	Type          PullRequestEventType
+	ActorID       string // кто выполнил действие: роль из токена, ревьювер или "system" для фоновых задач
+	OldReviewerID string
+	NewReviewerID string
+	Reason        string // причина или решение ревьювера (для REVIEW_SUBMITTED)
+	CreatedAt     time.Time
+}
+
+// SelectionExclusion - пользователь, исключённый из выбора ревьюверов, и причина
+type SelectionExclusion struct {
+	UserID string
+	Reason ExclusionReason
+}
+
+// TeamSelection - выбор ревьюверов из одной команды: своей или резервной
+type TeamSelection struct {
+	TeamName   string
+	Strategy   SelectionStrategy
+	Fallback   bool     // резервная команда
+	CodeOwners bool     // кандидаты - владельцы изменённых файлов по CODEOWNERS, стратегия команды автора
+	Candidates []string // кандидаты, из которых выбирала стратегия
+	Selected   []string
+}
+
+// SelectionExplanation - объяснение одного выбора ревьюверов: кого рассматривали, кого исключили и почему
+type SelectionExplanation struct {
+	ID                 int64
+	PullRequestID      string
+	Trigger            SelectionTrigger
+	ReplacedReviewerID string // заменяемый ревьювер (для замен)
+	RequestedCount     int
+	Teams              []TeamSelection // в порядке рассмотрения: основная команда, затем резервные
+	Excluded           []SelectionExclusion
+	OverCapacity       bool // все кандидаты на пределе, назначены наименее загруженные по политике команды
+	// Repository - репозиторий, команды-владельцы которого рассматривались вместо команды автора (пусто - команда автора)
+	Repository string
+	// CodeOwners - правила CODEOWNERS, совпавшие с изменёнными файлами PR
+	// (nil - CODEOWNERS не применялся, пустой - ни одно правило не совпало)
+	CodeOwners []CodeOwnersMatch
+	Selected   []string
+	CreatedAt  time.Time
+}
+
+// CodeOwnersMatch - правило CODEOWNERS и изменённые файлы, для которых оно оказалось последним совпавшим
+type CodeOwnersMatch struct {
+	Line    int // номер строки правила в CODEOWNERS
+	Pattern string
+	Owners  []string // владельцы так, как они записаны в правиле
+	Paths   []string
+}
+
+// Repository - зарегистрированный репозиторий и команды, которые им владеют.
+// Ревьюверы PR в репозиторий выбираются из первой команды-владельца по её настройкам,
+// остальные команды-владельцы добирают недостающих раньше резервных команд.
+type Repository struct {
+	Name       string
+	OwnerTeams []string // в порядке приоритета; пусто - ревьюверы из команды автора
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// RepositoryCodeOwners - CODEOWNERS, зарегистрированный для репозитория
+type RepositoryCodeOwners struct {
+	Repository string
+	Content    string
+	UpdatedAt  time.Time
+}
+
+// WebhookSubscription - подписка внешней системы на события сервиса
+type WebhookSubscription struct {
+	ID         int64
+	URL        string
+	Secret     string             // ключ HMAC-подписи тела запроса
+	EventTypes []WebhookEventType // пусто - все события
+	CreatedAt  time.Time
+}
+
+// WebhookDelivery - доставка одного события одному подписчику и её попытки
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventID        string // общий для всех доставок одного события
+	EventType      WebhookEventType
+	Payload        []byte // JSON тела запроса
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	LastStatusCode int    // 0 - ответа не было
+	LastError      string // пусто после успешной попытки
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// PullRequestFilter - фильтры и сортировка списка PR (пустые поля не фильтруют).
+// Нижние границы периодов включаются, верхние - нет.
+type PullRequestFilter struct {
+	Statuses    []PullRequestStatus
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string // автор состоит в команде
+	Repository  string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	SortBy      PullRequestSortField
+	Descending  bool
+}
+
+// PageCursor - позиция последнего элемента страницы для keyset-пагинации
+type PageCursor struct {
+	SortValue time.Time
+	ID        string
 }
 
 // Team - domain модель команды
@@ -39,15 +307,72 @@ type Team struct {
 type TeamMember struct {
 	UserID   string
 	Username string
-	IsActive bool
+	Role     TeamRole
+	IsActive bool // участник рассматривается в команде: активны и пользователь, и его членство в ней
+}
+
+// TeamMembership - членство пользователя в команде
+type TeamMembership struct {
+	TeamName string
+	UserID   string
+	Role     TeamRole
+	IsActive bool // флаг членства; активность самого пользователя - User.IsActive
+	JoinedAt time.Time
+}
+
+// DefaultReviewerCount - количество ревьюверов, назначаемых на PR, если команда не настроила своё
+const DefaultReviewerCount = 2
+
+// TeamSettings - настройки назначения ревьюверов команды
+type TeamSettings struct {
+	TeamName          string
+	ReviewerCount     int               // сколько ревьюверов назначать на PR
+	MinReviewers      int               // минимум ревьюверов, без которого PR не создаётся
+	SelectionStrategy SelectionStrategy // пустая строка - стратегия из конфигурации сервиса
+	FallbackTeams     []string          // резервные команды в порядке приоритета
+	RequiredApprovals int               // сколько одобрений нужно для merge (0 - не требуются)
+	MaxOpenReviews    int               // лимит открытых ревью участника по умолчанию (0 - без лимита)
+	CapacityPolicy    CapacityPolicy    // поведение, когда все кандидаты достигли лимита
+}
+
+// DefaultTeamSettings возвращает настройки команды по умолчанию
+func DefaultTeamSettings(teamName string) *TeamSettings {
+	return &TeamSettings{
+		TeamName:       teamName,
+		ReviewerCount:  DefaultReviewerCount,
+		MinReviewers:   0,
+		CapacityPolicy: CapacityPolicyAssignLeastLoaded,
+	}
 }
 
 // User - domain модель пользователя
 type User struct {
-	UserID   string
-	Username string
-	TeamName string
-	IsActive bool
+	UserID         string
+	Username       string
+	TeamName       string // основная команда; у кандидатов, выбранных из команды, - эта команда
+	IsActive       bool
+	MaxOpenReviews int // личный лимит открытых ревью (0 - лимит команды)
+}
+
+// ReviewCapacity - текущая нагрузка ревьювера относительно его лимита
+type ReviewCapacity struct {
+	UserID         string
+	OpenReviews    int
+	MaxOpenReviews int // действующий лимит: личный или командный (0 - без лимита)
+}
+
+// UserAbsence - период отсутствия пользователя (отпуск, больничный и т.п.)
+type UserAbsence struct {
+	ID           int64
+	UserID       string
+	StartsAt     time.Time
+	EndsAt       time.Time
+	Reason       string
+	ReassignedAt *time.Time // когда открытые ревью пользователя были переназначены (nil - ещё нет)
+
+	ReassignAttempts int        // неудачные попытки переназначения
+	LastError        string     // ошибка последней неудачной попытки
+	NextAttemptAt    *time.Time // не раньше этого времени период обрабатывается снова (nil - сразу)
 }
 
 // Input/Output DTOs для методов сервиса
@@ -57,39 +382,185 @@ type CreatePullRequestInput struct {
 	PullRequestID   string
 	PullRequestName string
 	AuthorID        string
+	Draft           bool // черновик создаётся без ревьюверов
+	DryRun          bool // выполнить создание и откатить транзакцию
+	Repository      string
+	TeamName        string   // команда автора, от имени которой создаётся PR; пусто - основная команда автора
+	ChangedFiles    []string // изменённые файлы: владельцы из CODEOWNERS назначаются в первую очередь
+	CodeOwners      string   // содержимое CODEOWNERS; пусто - зарегистрированный для Repository
+}
+
+// SetRepositoryInput - входные данные для регистрации репозитория
+type SetRepositoryInput struct {
+	Name       string
+	OwnerTeams []string // заменяет предыдущий список команд-владельцев
+}
+
+// SetCodeOwnersInput - входные данные для регистрации CODEOWNERS репозитория
+type SetCodeOwnersInput struct {
+	Repository string
+	Content    string
 }
 
 // MergePullRequestInput - входные данные для merge PR
 type MergePullRequestInput struct {
 	PullRequestID string
+	Force         bool // merge в обход проверки одобрений
+}
+
+// ClosePullRequestInput - входные данные для закрытия PR без merge
+type ClosePullRequestInput struct {
+	PullRequestID string
+}
+
+// ReopenPullRequestInput - входные данные для повторного открытия закрытого PR
+type ReopenPullRequestInput struct {
+	PullRequestID string
+}
+
+// MarkReadyInput - входные данные для перевода черновика в ревью
+type MarkReadyInput struct {
+	PullRequestID string
+}
+
+// SubmitReviewInput - входные данные для решения ревьювера по PR
+type SubmitReviewInput struct {
+	PullRequestID string
+	ReviewerID    string
+	State         ReviewState
+}
+
+// ListPullRequestsInput - входные данные для списка PR
+type ListPullRequestsInput struct {
+	PullRequestFilter
+	Limit  int    // 0 - DefaultPageLimit
+	Cursor string // next_cursor предыдущей страницы
+}
+
+// PullRequestPage - страница списка PR
+type PullRequestPage struct {
+	PullRequests []PullRequest
+	NextCursor   string // пусто - страниц больше нет
+}
+
+// ReviewerAssignmentsInput - входные данные для списка PR, назначенных на ревьювера
+type ReviewerAssignmentsInput struct {
+	UserID   string
+	Statuses []PullRequestStatus // пусто - все статусы
+	Limit    int                 // 0 - DefaultPageLimit
+	Cursor   string
+}
+
+// ReviewerAssignmentsPage - страница PR ревьювера, отсортированных по created_at
+type ReviewerAssignmentsPage struct {
+	PullRequests []PullRequestShort
+	NextCursor   string // пусто - страниц больше нет
 }
 
 // ReassignPullRequestInput - входные данные для переназначения ревьювера
 type ReassignPullRequestInput struct {
 	PullRequestID string
 	OldUserID     string
+	DryRun        bool // выполнить замену и откатить транзакцию
 }
 
 // ReassignPullRequestResult - результат переназначения ревьювера
 type ReassignPullRequestResult struct {
-	PullRequest PullRequest
-	ReplacedBy  string
+	PullRequest    PullRequest
+	ReplacedBy     string
+	ReplacedByTeam string // команда, из которой выбран новый ревьювер
 }
 
 // DeactivateTeamInput - входные данные для массовой деактивации команды
 type DeactivateTeamInput struct {
-	TeamName string
+	TeamName            string
+	ReassignOpenReviews bool // в той же транзакции переназначить открытые ревью деактивированных участников
+	DryRun              bool // выполнить деактивацию и откатить транзакцию
 }
 
 // DeactivateTeamResult - результат массовой деактивации команды
 type DeactivateTeamResult struct {
 	TeamName             string
 	DeactivatedUserCount int
+	Reassignments        []ReassignInactiveResult // по одному на каждый затронутый OPEN PR
+}
+
+// AddTeamMemberInput - входные данные для добавления участника в команду (или изменения его членства)
+type AddTeamMemberInput struct {
+	TeamName            string
+	UserID              string
+	Username            string   // обязателен для нового пользователя, у существующего не меняется
+	Role                TeamRole // пусто - MEMBER
+	IsActive            bool     // флаг членства; новый пользователь создаётся с этой активностью
+	ReassignOpenReviews bool     // при неактивном членстве переназначить открытые ревью участника на PR команды
+}
+
+// RemoveTeamMemberInput - входные данные для удаления участника из команды
+type RemoveTeamMemberInput struct {
+	TeamName            string
+	UserID              string
+	ReassignOpenReviews bool // переназначить открытые ревью участника на PR команды
+}
+
+// MoveTeamMemberInput - входные данные для перевода участника в другую команду
+type MoveTeamMemberInput struct {
+	FromTeam            string
+	ToTeam              string
+	UserID              string
+	ReassignOpenReviews bool // переназначить открытые ревью участника на PR прежней команды
+}
+
+// TeamMemberResult - результат изменения состава команды
+type TeamMemberResult struct {
+	TeamName      string
+	Membership    *TeamMembership          // членство после изменения (nil - участник удалён)
+	PrimaryTeam   string                   // основная команда пользователя после изменения
+	Reassignments []ReassignInactiveResult // по одному на каждый затронутый OPEN PR
+}
+
+// SetUserActiveInput - входные данные для изменения активности пользователя
+type SetUserActiveInput struct {
+	UserID              string
+	IsActive            bool
+	ReassignOpenReviews bool // при деактивации в той же транзакции переназначить его открытые ревью
+}
+
+// SetUserActiveResult - результат изменения активности пользователя
+type SetUserActiveResult struct {
+	User          User
+	Reassignments []ReassignInactiveResult // по одному на каждый затронутый OPEN PR
+}
+
+// SetTeamSettingsInput - входные данные для изменения настроек команды (nil - оставить как есть)
+type SetTeamSettingsInput struct {
+	TeamName          string
+	ReviewerCount     *int
+	MinReviewers      *int
+	SelectionStrategy *SelectionStrategy
+	FallbackTeams     []string // nil - оставить как есть, пустой список - убрать резервные команды
+	RequiredApprovals *int
+	MaxOpenReviews    *int
+	CapacityPolicy    *CapacityPolicy
+}
+
+// AddUserAbsenceInput - входные данные для добавления периода отсутствия
+type AddUserAbsenceInput struct {
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+}
+
+// DeleteUserAbsenceInput - входные данные для удаления периода отсутствия
+type DeleteUserAbsenceInput struct {
+	UserID    string
+	AbsenceID int64
 }
 
 // ReassignInactiveInput - входные данные для переназначения неактивных ревьюверов PR
 type ReassignInactiveInput struct {
 	PullRequestID string
+	DryRun        bool // выполнить переназначение и откатить транзакцию
 }
 
 // ReassignInactiveResult - результат переназначения неактивных ревьюверов
@@ -98,9 +569,127 @@ type ReassignInactiveResult struct {
 	ReassignmentDetails []ReviewerReassignment
 }
 
+// BulkReassignChunkSize - сколько PR массовое переназначение обрабатывает в одной транзакции
+const BulkReassignChunkSize = 50
+
+// BulkReassignInactiveInput - входные данные для массового переназначения неактивных ревьюверов.
+// Без фильтров обрабатываются все OPEN PR с неактивными ревьюверами.
+type BulkReassignInactiveInput struct {
+	TeamName       string   // только PR, где неактивный ревьювер из этой команды
+	PullRequestIDs []string // только перечисленные PR
+	DryRun         bool     // вычислить замены, ничего не сохраняя
+}
+
+// BulkReassignInactiveResult - результат массового переназначения
+type BulkReassignInactiveResult struct {
+	DryRun       bool
+	PullRequests []BulkReassignPullRequestResult
+}
+
+// BulkReassignPullRequestResult - итог массового переназначения по одному PR
+type BulkReassignPullRequestResult struct {
+	PullRequestID string
+	Replaced      []ReviewerReassignment
+	Removed       []string // ревьюверы, снятые без замены
+	Failed        []string // неактивные ревьюверы PR, которых не удалось обработать
+	Failure       *Error   // причина, если PR не обработан
+}
+
 // ReviewerReassignment - детали переназначения одного ревьювера
 type ReviewerReassignment struct {
-	OldReviewerID string
-	NewReviewerID string // пустая строка если не удалось найти замену
-	WasRemoved    bool   // true если ревьювер был просто удален без замены
+	OldReviewerID   string
+	NewReviewerID   string // пустая строка если не удалось найти замену
+	NewReviewerTeam string // команда, из которой выбран новый ревьювер
+	WasRemoved      bool   // true если ревьювер был просто удален без замены
+}
+
+// CreateWebhookInput - входные данные для подписки на события
+type CreateWebhookInput struct {
+	URL        string
+	Secret     string
+	EventTypes []WebhookEventType // пусто - все события
+}
+
+// WebhookDeliveriesInput - входные данные для журнала доставок подписки
+type WebhookDeliveriesInput struct {
+	SubscriptionID int64
+	Status         WebhookDeliveryStatus // пусто - все состояния
+	Limit          int                   // 0 - DefaultPageLimit
+}
+
+// OutboxEvent - событие сервиса, записанное в outbox в транзакции породившей его операции.
+// Relay публикует его в приёмники, пока все они не примут событие.
+type OutboxEvent struct {
+	ID            int64
+	EventID       string
+	EventType     WebhookEventType
+	Payload       []byte // конверт события: event_id, event_type, actor, occurred_at, data
+	Attempts      int    // неудачных попыток публикации
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	PublishedAt   *time.Time // nil - ещё не опубликовано
+}
+
+// IngestionSource - внешняя система, присылающая события своих PR
+type IngestionSource string
+
+const (
+	IngestionSourceGitHub IngestionSource = "github"
+	IngestionSourceGitLab IngestionSource = "gitlab"
+)
+
+// ExternalPullRequestAction - действие над PR во внешней системе, которое сервис повторяет у себя
+type ExternalPullRequestAction string
+
+const (
+	ExternalActionOpened         ExternalPullRequestAction = "opened"           // CreatePullRequest
+	ExternalActionMerged         ExternalPullRequestAction = "merged"           // MergePullRequest
+	ExternalActionClosed         ExternalPullRequestAction = "closed"           // ClosePullRequest
+	ExternalActionReopened       ExternalPullRequestAction = "reopened"         // ReopenPullRequest
+	ExternalActionReadyForReview ExternalPullRequestAction = "ready_for_review" // MarkPullRequestReady
+)
+
+// ExternalPullRequestEvent - событие PR из внешней системы, приведённое к общему виду
+type ExternalPullRequestEvent struct {
+	Source      IngestionSource
+	DeliveryID  string // идентификатор доставки во внешней системе, повтор доставки не применяется
+	Action      ExternalPullRequestAction
+	Repository  string // полное имя репозитория: owner/name (в GitLab - путь проекта с группами)
+	Number      int    // номер PR в репозитории (в GitLab - iid merge request)
+	Title       string
+	AuthorLogin string // логин автора во внешней системе
+	Draft       bool
+}
+
+// IngestedDeliveryStatus - состояние доставки события из внешней системы
+type IngestedDeliveryStatus string
+
+const (
+	IngestedDeliveryPending IngestedDeliveryStatus = "PENDING" // доставка занята и обрабатывается
+	IngestedDeliveryDone    IngestedDeliveryStatus = "DONE"    // действие применено
+)
+
+// IngestedDelivery - принятая доставка события из внешней системы
+type IngestedDelivery struct {
+	Source        IngestionSource
+	DeliveryID    string
+	Action        ExternalPullRequestAction
+	PullRequestID string
+	Status        IngestedDeliveryStatus
+	ReceivedAt    time.Time
+	ClaimedAt     time.Time // когда доставку заняли последний раз
+}
+
+// IngestionResult - итог обработки события из внешней системы
+type IngestionResult struct {
+	DeliveryID    string
+	PullRequestID string
+	Action        ExternalPullRequestAction
+	Duplicate     bool         // доставка уже была обработана, повтор ничего не изменил
+	PullRequest   *PullRequest // состояние PR после обработки (nil для повтора)
+	// ReviewerLogins - логины назначенных ревьюверов во внешней системе, чтобы проставить их в PR
+	ReviewerLogins []string
+	// UnmappedReviewers - назначенные ревьюверы, для которых не задан логин во внешней системе
+	UnmappedReviewers []string
 }
@@ -12,24 +12,115 @@ type AssignmentService interface {
 	// MergePullRequest выполняет merge pull request
 	MergePullRequest(ctx context.Context, input *MergePullRequestInput) (*PullRequest, error)
 
+	// SubmitReview сохраняет решение назначенного ревьювера по PR
+	SubmitReview(ctx context.Context, input *SubmitReviewInput) (*PullRequest, error)
+
+	// ClosePullRequest закрывает черновик или открытый PR без merge
+	ClosePullRequest(ctx context.Context, input *ClosePullRequestInput) (*PullRequest, error)
+
+	// ReopenPullRequest повторно открывает закрытый PR
+	ReopenPullRequest(ctx context.Context, input *ReopenPullRequestInput) (*PullRequest, error)
+
+	// MarkPullRequestReady переводит черновик в OPEN и назначает ревьюверов
+	MarkPullRequestReady(ctx context.Context, input *MarkReadyInput) (*PullRequest, error)
+
+	// GetPullRequest возвращает PR с данными автора и ревьюверов
+	GetPullRequest(ctx context.Context, pullRequestID string) (*PullRequestDetails, error)
+
+	// ListPullRequests возвращает страницу PR по фильтрам
+	ListPullRequests(ctx context.Context, input *ListPullRequestsInput) (*PullRequestPage, error)
+
+	// GetPullRequestHistory возвращает историю событий PR в порядке их возникновения
+	GetPullRequestHistory(ctx context.Context, pullRequestID string) ([]PullRequestEvent, error)
+
+	// ExplainPullRequest возвращает объяснения всех выборов ревьюверов PR в порядке их выполнения
+	ExplainPullRequest(ctx context.Context, pullRequestID string) ([]SelectionExplanation, error)
+
 	// ReassignPullRequest переназначает ревьювера на другого члена команды
 	ReassignPullRequest(ctx context.Context, input *ReassignPullRequestInput) (*ReassignPullRequestResult, error)
 
 	// ReassignInactiveReviewers переназначает всех неактивных ревьюверов на активных членов команды на определённом PR
 	ReassignInactiveReviewers(ctx context.Context, input *ReassignInactiveInput) (*ReassignInactiveResult, error)
 
+	// BulkReassignInactiveReviewers переназначает неактивных ревьюверов во всех подходящих OPEN PR порциями
+	BulkReassignInactiveReviewers(ctx context.Context, input *BulkReassignInactiveInput) (*BulkReassignInactiveResult, error)
+
 	// CreateTeam создаёт новую команду с участниками
 	CreateTeam(ctx context.Context, team *Team) (*Team, error)
 
 	// GetTeam возвращает информацию о команде по имени
 	GetTeam(ctx context.Context, teamName string) (*Team, error)
 
+	// GetTeamSettings возвращает настройки назначения ревьюверов команды
+	GetTeamSettings(ctx context.Context, teamName string) (*TeamSettings, error)
+
+	// SetTeamSettings изменяет настройки назначения ревьюверов команды
+	SetTeamSettings(ctx context.Context, input *SetTeamSettingsInput) (*TeamSettings, error)
+
+	// AddTeamMember добавляет пользователя в команду или изменяет его членство
+	AddTeamMember(ctx context.Context, input *AddTeamMemberInput) (*TeamMemberResult, error)
+
+	// RemoveTeamMember удаляет пользователя из команды
+	RemoveTeamMember(ctx context.Context, input *RemoveTeamMemberInput) (*TeamMemberResult, error)
+
+	// MoveTeamMember переводит пользователя из одной команды в другую
+	MoveTeamMember(ctx context.Context, input *MoveTeamMemberInput) (*TeamMemberResult, error)
+
+	// DeleteTeam удаляет команду без OPEN PR
+	DeleteTeam(ctx context.Context, teamName string) error
+
 	// DeactivateTeamMembers деактивирует всех пользователей в команде
 	DeactivateTeamMembers(ctx context.Context, input *DeactivateTeamInput) (*DeactivateTeamResult, error)
 
 	// SetUserIsActive изменяет статус активности пользователя
-	SetUserIsActive(ctx context.Context, userID string, isActive bool) (*User, error)
+	SetUserIsActive(ctx context.Context, input *SetUserActiveInput) (*SetUserActiveResult, error)
+
+	// SetUserReviewLimit задаёт личный лимит открытых ревью пользователя (0 - лимит команды)
+	SetUserReviewLimit(ctx context.Context, userID string, maxOpenReviews int) (*User, error)
+
+	// AddUserAbsence добавляет период отсутствия пользователя
+	AddUserAbsence(ctx context.Context, input *AddUserAbsenceInput) (*UserAbsence, error)
+
+	// GetUserAbsences возвращает периоды отсутствия пользователя
+	GetUserAbsences(ctx context.Context, userID string) ([]UserAbsence, error)
+
+	// DeleteUserAbsence удаляет период отсутствия пользователя
+	DeleteUserAbsence(ctx context.Context, input *DeleteUserAbsenceInput) error
+
+	// GetReviewerAssignments возвращает страницу PR, где пользователь назначен ревьювером
+	GetReviewerAssignments(ctx context.Context, input *ReviewerAssignmentsInput) (*ReviewerAssignmentsPage, error)
+
+	// GetReviewCapacity возвращает количество открытых ревью пользователя и его действующий лимит
+	GetReviewCapacity(ctx context.Context, userID string) (*ReviewCapacity, error)
+
+	// CreateWebhook подписывает внешнюю систему на события сервиса
+	CreateWebhook(ctx context.Context, input *CreateWebhookInput) (*WebhookSubscription, error)
+
+	// ListWebhooks возвращает все подписки на события
+	ListWebhooks(ctx context.Context) ([]WebhookSubscription, error)
+
+	// DeleteWebhook удаляет подписку вместе с журналом её доставок
+	DeleteWebhook(ctx context.Context, subscriptionID int64) error
+
+	// GetWebhookDeliveries возвращает последние доставки событий подписчику, новые первыми
+	GetWebhookDeliveries(ctx context.Context, input *WebhookDeliveriesInput) ([]WebhookDelivery, error)
+
+	// SetRepository регистрирует репозиторий или заменяет его команды-владельцы
+	SetRepository(ctx context.Context, input *SetRepositoryInput) (*Repository, error)
+
+	// GetRepository возвращает репозиторий с командами-владельцами
+	GetRepository(ctx context.Context, name string) (*Repository, error)
+
+	// ListRepositories возвращает зарегистрированные репозитории, отсортированные по имени
+	ListRepositories(ctx context.Context) ([]Repository, error)
+
+	// SetCodeOwners регистрирует CODEOWNERS репозитория, заменяя предыдущий
+	SetCodeOwners(ctx context.Context, input *SetCodeOwnersInput) (*RepositoryCodeOwners, error)
+
+	// GetCodeOwners возвращает CODEOWNERS, зарегистрированный для репозитория
+	GetCodeOwners(ctx context.Context, repository string) (*RepositoryCodeOwners, error)
 
-	// GetReviewerAssignments возвращает список PR, где пользователь назначен ревьювером
-	GetReviewerAssignments(ctx context.Context, userID string) ([]PullRequestShort, error)
+	// IngestPullRequestEvent повторяет у себя действие над PR из внешней системы.
+	// Повторная доставка с тем же DeliveryID ничего не меняет.
+	IngestPullRequestEvent(ctx context.Context, event *ExternalPullRequestEvent) (*IngestionResult, error)
 }
@@ -80,3 +80,11 @@ func GetRequestID(ctx context.Context) string {
 	}
 	return "unknown"
 }
+
+// GetActor возвращает инициатора запроса (роль из токена); для фоновых задач - "system"
+func GetActor(ctx context.Context) string {
+	if actor, ok := ctx.Value(middleware.ActorKey).(string); ok {
+		return actor
+	}
+	return "system"
+}
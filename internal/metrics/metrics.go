@@ -19,12 +19,24 @@ var (
 		Help: "Total number of pull requests merged",
 	})
 
+	// PRClosedTotal - количество PR, закрытых без merge
+	PRClosedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pr_closed_total",
+		Help: "Total number of pull requests closed without merge",
+	})
+
 	// PRReassignedTotal - количество переназначений ревьюверов
 	PRReassignedTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "pr_reassigned_total",
 		Help: "Total number of reviewer reassignments",
 	})
 
+	// PRReviewsSubmittedTotal - решения ревьюверов по состояниям
+	PRReviewsSubmittedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pr_reviews_submitted_total",
+		Help: "Total number of review decisions submitted by reviewers",
+	}, []string{"state"})
+
 	// PROpenCount - текущее количество открытых PR
 	PROpenCount = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "pr_open_count",
@@ -72,6 +84,18 @@ var (
 		Name: "team_active_members_count",
 		Help: "Number of active team members by team",
 	}, []string{"team_name"})
+
+	// TeamFallbackReviewersTotal - ревьюверы, взятые из резервных команд
+	TeamFallbackReviewersTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "team_fallback_reviewers_total",
+		Help: "Total number of reviewers selected from fallback teams",
+	}, []string{"team_name", "fallback_team_name"})
+
+	// CodeOwnerReviewersTotal - ревьюверы, выбранные среди владельцев изменённых файлов по CODEOWNERS
+	CodeOwnerReviewersTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "code_owner_reviewers_total",
+		Help: "Total number of reviewers selected from CODEOWNERS of changed files",
+	}, []string{"team_name"})
 )
 
 // User Metrics
@@ -88,11 +112,23 @@ var (
 		Help: "Number of review assignments per user",
 	}, []string{"user_id"})
 
+	// UserOpenReviewAssignmentsCount - количество назначенных review в открытых PR
+	UserOpenReviewAssignmentsCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "user_open_review_assignments_count",
+		Help: "Number of review assignments in OPEN pull requests per user",
+	}, []string{"user_id"})
+
 	// UserNoCandidatesErrors - ошибки "нет доступных кандидатов"
 	UserNoCandidatesErrors = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "user_no_candidates_errors_total",
 		Help: "Total number of no candidates errors during reassignment",
 	})
+
+	// AbsenceReassignFailures - неудачные попытки переназначения ревью по периоду отсутствия
+	AbsenceReassignFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "absence_reassign_failures_total",
+		Help: "Total number of failed attempts to reassign reviews of absent users",
+	})
 )
 
 // HTTP Metrics
@@ -191,3 +227,43 @@ var (
 		Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1},
 	})
 )
+
+// Webhook Metrics
+var (
+	// WebhookDeliveryAttemptsTotal - попытки доставки событий подписчикам по итогу
+	WebhookDeliveryAttemptsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "webhook_delivery_attempts_total",
+		Help: "Total number of webhook delivery attempts by event type and result",
+	}, []string{"event_type", "result"})
+
+	// WebhookDeliveryDuration - длительность HTTP запроса к подписчику
+	WebhookDeliveryDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "webhook_delivery_duration_seconds",
+		Help:    "Duration of webhook delivery HTTP requests",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+// Outbox Metrics
+var (
+	// OutboxEventsTotal - попытки публикации событий outbox по итогу (published, retry)
+	OutboxEventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_events_total",
+		Help: "Total number of outbox publish attempts by event type and result",
+	}, []string{"event_type", "result"})
+
+	// OutboxSinkErrorsTotal - ошибки приёмников событий outbox
+	OutboxSinkErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_sink_errors_total",
+		Help: "Total number of outbox sink publish errors",
+	}, []string{"sink"})
+)
+
+// Ingestion Metrics
+var (
+	// IngestedEventsTotal - события PR из внешних систем по итогу обработки (applied, duplicate)
+	IngestedEventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ingested_events_total",
+		Help: "Total number of pull request events received from external systems",
+	}, []string{"source", "action", "result"})
+)
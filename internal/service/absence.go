@@ -0,0 +1,307 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// absenceRetryBackoff - задержка перед повторной попыткой переназначения по периоду отсутствия
+	absenceRetryBackoff = time.Minute
+	// maxAbsenceBackoffShift ограничивает рост задержки между попытками: absenceRetryBackoff * 2^6
+	maxAbsenceBackoffShift = 6
+)
+
+// AddUserAbsence добавляет период отсутствия пользователя.
+// Открытые ревью пользователя переназначит фоновая задача, когда период начнётся.
+func (s *Service) AddUserAbsence(outerCtx context.Context, input *domain.AddUserAbsenceInput) (*domain.UserAbsence, error) {
+	const op = "service.AddUserAbsence"
+	requestID := logger.GetRequestID(outerCtx)
+	var absence *domain.UserAbsence
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("add_user_absence").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("user_id", input.UserID).
+		Time("starts_at", input.StartsAt).
+		Time("ends_at", input.EndsAt).
+		Msg("adding user absence")
+
+	if !input.EndsAt.After(input.StartsAt) {
+		return nil, domain.ErrInvalidInput
+	}
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		// Проверяем существование пользователя
+		if _, err := tx.UserRepo().GetByID(ctx, input.UserID); err != nil {
+			return err
+		}
+
+		a := &domain.UserAbsence{
+			UserID:   input.UserID,
+			StartsAt: input.StartsAt,
+			EndsAt:   input.EndsAt,
+			Reason:   input.Reason,
+		}
+		if err := tx.UserRepo().CreateAbsence(ctx, a); err != nil {
+			return err
+		}
+
+		absence = a
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("user_id", absence.UserID).
+		Int64("absence_id", absence.ID).
+		Msg("successfully added user absence")
+
+	return absence, nil
+}
+
+// GetUserAbsences возвращает периоды отсутствия пользователя
+func (s *Service) GetUserAbsences(outerCtx context.Context, userID string) ([]domain.UserAbsence, error) {
+	const op = "service.GetUserAbsences"
+	requestID := logger.GetRequestID(outerCtx)
+	var absences []domain.UserAbsence
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("get_user_absences").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("user_id", userID).
+		Msg("fetching user absences")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		if _, err := tx.UserRepo().GetByID(ctx, userID); err != nil {
+			return err
+		}
+
+		result, err := tx.UserRepo().GetAbsences(ctx, userID)
+		if err != nil {
+			return err
+		}
+		absences = result
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("user_id", userID).
+		Int("absence_count", len(absences)).
+		Msg("successfully fetched user absences")
+
+	return absences, nil
+}
+
+// DeleteUserAbsence удаляет период отсутствия пользователя.
+// Уже переназначенные ревью обратно не возвращаются.
+func (s *Service) DeleteUserAbsence(outerCtx context.Context, input *domain.DeleteUserAbsenceInput) error {
+	const op = "service.DeleteUserAbsence"
+	requestID := logger.GetRequestID(outerCtx)
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("delete_user_absence").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("user_id", input.UserID).
+		Int64("absence_id", input.AbsenceID).
+		Msg("deleting user absence")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		return tx.UserRepo().DeleteAbsence(ctx, input.UserID, input.AbsenceID)
+	})
+
+	if err != nil {
+		return s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("user_id", input.UserID).
+		Int64("absence_id", input.AbsenceID).
+		Msg("successfully deleted user absence")
+
+	return nil
+}
+
+// ReassignAbsentReviewers переназначает открытые ревью пользователей, чей период отсутствия начался.
+// Каждый период обрабатывается в отдельной транзакции и отмечается как обработанный, поэтому
+// повторный запуск (в том числе на другом экземпляре сервиса) не переназначает ревью дважды.
+// Период, переназначение по которому не удалось, откладывается с экспоненциальной задержкой
+// и не мешает обработке остальных. Возвращает количество обработанных периодов.
+func (s *Service) ReassignAbsentReviewers(outerCtx context.Context) (int, error) {
+	const op = "service.ReassignAbsentReviewers"
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("reassign_absent_reviewers").Observe(time.Since(start).Seconds())
+	}()
+
+	processed := 0
+	for {
+		var absence *domain.UserAbsence
+		reassigned := 0
+
+		err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+			a, err := tx.UserRepo().ClaimStartedAbsence(ctx, time.Now())
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			absence = a
+
+			reassigned, err = s.reassignAbsentUserReviews(ctx, tx, a)
+			if err != nil {
+				return err
+			}
+
+			return tx.UserRepo().MarkAbsenceReassigned(ctx, a.ID, time.Now())
+		})
+
+		// Ошибка до захвата периода или отмена контекста прерывают обработку целиком
+		if err != nil && (absence == nil || outerCtx.Err() != nil) {
+			return processed, s.formatError(outerCtx, op, err)
+		}
+
+		// Необработанных периодов больше нет
+		if absence == nil {
+			return processed, nil
+		}
+
+		// Транзакция периода откатилась: откладываем его и переходим к следующему
+		if err != nil {
+			if err := s.recordAbsenceFailure(outerCtx, absence, err); err != nil {
+				return processed, s.formatError(outerCtx, op, err)
+			}
+			continue
+		}
+
+		processed++
+		metrics.PRReassignedTotal.Add(float64(reassigned))
+
+		log.Info().
+			Str("layer", "service").
+			Str("user_id", absence.UserID).
+			Int64("absence_id", absence.ID).
+			Int("reassigned_count", reassigned).
+			Msg("reassigned reviews of absent user")
+	}
+}
+
+// recordAbsenceFailure откладывает следующую попытку переназначения по периоду отсутствия
+func (s *Service) recordAbsenceFailure(ctx context.Context, absence *domain.UserAbsence, reassignErr error) error {
+	nextAttemptAt := time.Now().Add(absenceRetryBackoff << min(absence.ReassignAttempts, maxAbsenceBackoffShift))
+
+	metrics.AbsenceReassignFailures.Inc()
+	log.Error().
+		Err(reassignErr).
+		Str("layer", "service").
+		Str("user_id", absence.UserID).
+		Int64("absence_id", absence.ID).
+		Int("attempts", absence.ReassignAttempts+1).
+		Time("next_attempt_at", nextAttemptAt).
+		Msg("failed to reassign reviews of absent user")
+
+	return s.txmgr.Do(ctx, func(ctx context.Context, tx storage.Tx) error {
+		return tx.UserRepo().RecordAbsenceFailure(ctx, absence.ID, reassignErr.Error(), nextAttemptAt)
+	})
+}
+
+// reassignAbsentUserReviews заменяет отсутствующего пользователя во всех открытых PR, где он ревьювер.
+// Если замены нет, ревьювер остаётся назначенным. Возвращает количество замен.
+func (s *Service) reassignAbsentUserReviews(ctx context.Context, tx storage.Tx, absence *domain.UserAbsence) (int, error) {
+	prs, err := tx.PullRequestRepo().GetPRsReviewedByUser(ctx, absence.UserID, []domain.PullRequestStatus{domain.PullRequestStatusOpen}, nil, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	reassigned := 0
+	for _, short := range prs {
+		pr, err := tx.PullRequestRepo().GetByID(ctx, short.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		// Если все кандидаты на пределе, ревьювер остаётся назначенным, как и при их отсутствии
+		replacement, err := s.findReplacement(ctx, tx, pr, absence.UserID, domain.SelectionTriggerAbsence)
+		if err != nil && !errors.Is(err, domain.ErrAllAtCapacity) {
+			return 0, err
+		}
+
+		if replacement == nil {
+			metrics.UserNoCandidatesErrors.Inc()
+			log.Warn().
+				Str("layer", "service").
+				Str("pull_request_id", pr.ID).
+				Str("user_id", absence.UserID).
+				Msg("no replacement for absent reviewer, keeping assignment")
+			continue
+		}
+
+		if err := replaceReviewer(ctx, tx, pr.ID, absence.UserID, replacement.UserID, domain.EventReasonAbsence); err != nil {
+			return 0, err
+		}
+
+		reassigned++
+	}
+
+	return reassigned, nil
+}
+
+// RunAbsenceWorker периодически переназначает ревью пользователей, чьё отсутствие началось.
+// Блокируется до отмены ctx.
+func (s *Service) RunAbsenceWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Info().
+		Dur("interval", interval).
+		Msg("absence worker started")
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.ReassignAbsentReviewers(ctx); err != nil {
+				log.Error().Err(err).Msg("failed to reassign reviews of absent users")
+			}
+		case <-ctx.Done():
+			log.Info().Msg("stopping absence worker")
+			return
+		}
+	}
+}
@@ -0,0 +1,665 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// teamSettings возвращает настройки команды или настройки по умолчанию, если команда их не задавала
+func (s *Service) teamSettings(ctx context.Context, tx storage.Tx, teamName string) (*domain.TeamSettings, error) {
+	settings, err := tx.TeamRepo().GetSettings(ctx, teamName)
+	if errors.Is(err, storage.ErrNotFound) {
+		return domain.DefaultTeamSettings(teamName), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// selectorFor возвращает стратегию и селектор, которые нужно использовать для команды.
+// Приоритет: настройки команды в БД, затем стратегия команды из конфигурации, затем глобальная.
+// Неизвестная стратегия заменяется на random.
+func (s *Service) selectorFor(ctx context.Context, settings *domain.TeamSettings) (domain.SelectionStrategy, ReviewerSelector) {
+	teamName := settings.TeamName
+
+	strategy := s.defaultStrategy
+	if teamStrategy, ok := s.teamStrategies[teamName]; ok {
+		strategy = teamStrategy
+	}
+	if settings.SelectionStrategy != "" {
+		strategy = settings.SelectionStrategy
+	}
+
+	if selector, ok := s.selectors[strategy]; ok {
+		return strategy, selector
+	}
+
+	log.Warn().
+		Str("request_id", logger.GetRequestID(ctx)).
+		Str("layer", "service").
+		Str("team_name", teamName).
+		Str("strategy", string(strategy)).
+		Msg("unknown selection strategy, falling back to random")
+
+	return domain.SelectionStrategyRandom, s.selectors[domain.SelectionStrategyRandom]
+}
+
+// teamSelection - результат выбора ревьюверов из кандидатов одной команды
+type teamSelection struct {
+	strategy    domain.SelectionStrategy
+	available   []domain.User // кандидаты, из которых выбирала стратегия
+	selected    []domain.User
+	overloaded  []domain.User  // кандидаты, достигшие лимита открытых ревью
+	openReviews map[string]int // нагрузка кандидатов (nil, если не понадобилась)
+}
+
+// effectiveReviewLimit возвращает лимит открытых ревью пользователя: личный, иначе командный (0 - без лимита)
+func effectiveReviewLimit(user domain.User, settings *domain.TeamSettings) int {
+	if user.MaxOpenReviews > 0 {
+		return user.MaxOpenReviews
+	}
+	return settings.MaxOpenReviews
+}
+
+// selectReviewers выбирает до count ревьюверов из кандидатов по стратегии команды.
+// Кандидаты, достигшие лимита открытых ревью, не выбираются и возвращаются в overloaded.
+func (s *Service) selectReviewers(ctx context.Context, tx storage.Tx, settings *domain.TeamSettings, candidates []domain.User, count int) (*teamSelection, error) {
+	strategy, selector := s.selectorFor(ctx, settings)
+
+	result := &teamSelection{strategy: strategy, available: []domain.User{}, selected: []domain.User{}}
+	if len(candidates) == 0 || count <= 0 {
+		return result, nil
+	}
+
+	limited := false
+	for _, candidate := range candidates {
+		if effectiveReviewLimit(candidate, settings) > 0 {
+			limited = true
+			break
+		}
+	}
+
+	// Нагрузку читаем только если она нужна селектору или для проверки лимитов
+	loadAware, ok := selector.(LoadAwareSelector)
+	if limited || (ok && loadAware.UsesReviewLoad()) {
+		openReviews, err := tx.PullRequestRepo().GetOpenReviewCounts(ctx, userIDs(candidates))
+		if err != nil {
+			return nil, err
+		}
+		result.openReviews = openReviews
+	}
+
+	available := candidates
+	if limited {
+		available = make([]domain.User, 0, len(candidates))
+		for _, candidate := range candidates {
+			limit := effectiveReviewLimit(candidate, settings)
+			if limit > 0 && result.openReviews[candidate.UserID] >= limit {
+				result.overloaded = append(result.overloaded, candidate)
+				continue
+			}
+			available = append(available, candidate)
+		}
+	}
+
+	result.available = available
+	if len(available) == 0 {
+		return result, nil
+	}
+
+	req := &SelectionRequest{
+		TeamName:    settings.TeamName,
+		Candidates:  available,
+		Count:       count,
+		OpenReviews: result.openReviews,
+	}
+
+	selectionStart := time.Now()
+	selected, err := selector.Select(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	metrics.RandomReviewerSelectionDuration.Observe(time.Since(selectionStart).Seconds())
+
+	log.Info().
+		Str("request_id", logger.GetRequestID(ctx)).
+		Str("layer", "service").
+		Str("team_name", settings.TeamName).
+		Str("strategy", string(strategy)).
+		Int("candidates_count", len(candidates)).
+		Int("overloaded_count", len(result.overloaded)).
+		Any("selected_reviewers", userIDs(selected)).
+		Any("open_reviews", req.OpenReviews).
+		Msg("selected reviewers")
+
+	result.selected = selected
+	return result, nil
+}
+
+// selectWithFallback выбирает до count ревьюверов из кандидатов команды, а недостающих
+// добирает из активных участников резервных команд settings.FallbackTeams в порядке приоритета.
+// Если передан owners, сначала выбираются владельцы изменённых файлов по стратегии команды,
+// а команда и резервные команды добирают недостающих.
+// Для участников резервной команды используется стратегия этой команды.
+// Если никого выбрать не удалось, потому что все кандидаты достигли лимита открытых ревью,
+// решение принимается по settings.CapacityPolicy.
+// exclude - пользователи, которых нельзя назначать (автор, текущие ревьюверы), с причиной.
+// Ход выбора возвращается в объяснении, в том числе вместе с ErrAllAtCapacity.
+func (s *Service) selectWithFallback(ctx context.Context, tx storage.Tx, settings *domain.TeamSettings, owners *codeOwnersPool, candidates []domain.User, count int, exclude map[string]domain.ExclusionReason) ([]domain.User, *domain.SelectionExplanation, error) {
+	explanation := newSelectionExplanation(count, exclude)
+
+	if err := s.explainUnavailable(ctx, tx, explanation, settings.TeamName); err != nil {
+		return nil, nil, err
+	}
+
+	selected := []domain.User{}
+	var overloaded []domain.User
+	openReviews := make(map[string]int)
+
+	if owners != nil {
+		explanation.CodeOwners = owners.matches
+
+		fromOwners, err := s.selectReviewers(ctx, tx, settings, excludeCandidates(owners.users, exclude), count)
+		if err != nil {
+			return nil, nil, err
+		}
+		explainTeamSelection(explanation, settings.TeamName, false, fromOwners)
+		explanation.Teams[len(explanation.Teams)-1].CodeOwners = true
+
+		if len(fromOwners.selected) > 0 {
+			metrics.CodeOwnerReviewersTotal.WithLabelValues(settings.TeamName).Add(float64(len(fromOwners.selected)))
+		}
+
+		selected = append(selected, fromOwners.selected...)
+		overloaded = appendNewUsers(overloaded, fromOwners.overloaded)
+		for userID, n := range fromOwners.openReviews {
+			openReviews[userID] = n
+		}
+	}
+
+	if owners == nil || len(selected) < count {
+		own, err := s.selectReviewers(ctx, tx, settings, excludeCandidates(candidates, takenBy(exclude, selected)), count-len(selected))
+		if err != nil {
+			return nil, nil, err
+		}
+		explainTeamSelection(explanation, settings.TeamName, false, own)
+
+		selected = append(selected, own.selected...)
+		overloaded = appendNewUsers(overloaded, own.overloaded)
+		for userID, n := range own.openReviews {
+			openReviews[userID] = n
+		}
+	}
+
+	for _, fallbackTeam := range settings.FallbackTeams {
+		if len(selected) >= count {
+			break
+		}
+
+		members, err := tx.UserRepo().GetActiveByTeam(ctx, fallbackTeam)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.explainUnavailable(ctx, tx, explanation, fallbackTeam); err != nil {
+			return nil, nil, err
+		}
+
+		// Исключаем уже выбранных, чтобы не назначить одного человека дважды
+		fallbackCandidates := excludeCandidates(members, takenBy(exclude, selected))
+		if len(fallbackCandidates) == 0 {
+			explanation.Teams = append(explanation.Teams, domain.TeamSelection{
+				TeamName:   fallbackTeam,
+				Fallback:   true,
+				Candidates: []string{},
+				Selected:   []string{},
+			})
+			continue
+		}
+
+		fallbackSettings, err := s.teamSettings(ctx, tx, fallbackTeam)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fallback, err := s.selectReviewers(ctx, tx, fallbackSettings, fallbackCandidates, count-len(selected))
+		if err != nil {
+			return nil, nil, err
+		}
+		explainTeamSelection(explanation, fallbackTeam, true, fallback)
+
+		if len(fallback.selected) > 0 {
+			metrics.TeamFallbackReviewersTotal.WithLabelValues(settings.TeamName, fallbackTeam).Add(float64(len(fallback.selected)))
+
+			log.Info().
+				Str("request_id", logger.GetRequestID(ctx)).
+				Str("layer", "service").
+				Str("team_name", settings.TeamName).
+				Str("fallback_team_name", fallbackTeam).
+				Any("selected_reviewers", userIDs(fallback.selected)).
+				Msg("selected reviewers from fallback team")
+		}
+
+		selected = append(selected, fallback.selected...)
+		overloaded = appendNewUsers(overloaded, fallback.overloaded)
+		for userID, n := range fallback.openReviews {
+			openReviews[userID] = n
+		}
+	}
+
+	if len(selected) == 0 && len(overloaded) > 0 {
+		var err error
+		selected, err = s.selectOverCapacity(ctx, settings, overloaded, count, openReviews)
+		if err != nil {
+			return nil, explanation, err
+		}
+		explanation.OverCapacity = true
+	}
+
+	explanation.Selected = userIDs(selected)
+	return selected, explanation, nil
+}
+
+// selectOverCapacity применяет политику команды, когда все кандидаты достигли лимита открытых ревью:
+// либо возвращает ErrAllAtCapacity, либо назначает наименее загруженных сверх лимита
+func (s *Service) selectOverCapacity(ctx context.Context, settings *domain.TeamSettings, overloaded []domain.User, count int, openReviews map[string]int) ([]domain.User, error) {
+	if settings.CapacityPolicy == domain.CapacityPolicyReject {
+		log.Warn().
+			Str("request_id", logger.GetRequestID(ctx)).
+			Str("layer", "service").
+			Str("team_name", settings.TeamName).
+			Any("overloaded_candidates", userIDs(overloaded)).
+			Msg("all candidates are at capacity")
+
+		return nil, domain.ErrAllAtCapacity
+	}
+
+	selected, err := NewLeastLoadedSelector().Select(ctx, &SelectionRequest{
+		TeamName:    settings.TeamName,
+		Candidates:  overloaded,
+		Count:       count,
+		OpenReviews: openReviews,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Warn().
+		Str("request_id", logger.GetRequestID(ctx)).
+		Str("layer", "service").
+		Str("team_name", settings.TeamName).
+		Any("selected_reviewers", userIDs(selected)).
+		Any("open_reviews", openReviews).
+		Msg("all candidates are at capacity, assigning least loaded over the limit")
+
+	return selected, nil
+}
+
+// selectInitialReviewers выбирает ревьюверов для PR по настройкам команды автора или первой команды-владельца
+// репозитория PR (с учётом резервных команд), в первую очередь - владельцев изменённых файлов по CODEOWNERS.
+// Возвращает ErrNotEnoughReviewers, если не набирается MinReviewers.
+// Объяснение выбора сохраняет вызывающий, когда PR уже существует.
+func (s *Service) selectInitialReviewers(ctx context.Context, tx storage.Tx, pr *domain.PullRequest) ([]domain.User, *domain.SelectionExplanation, error) {
+	authorID := pr.AuthorID
+
+	// Получаем автора, чтобы знать его команду и её настройки назначения
+	author, err := tx.UserRepo().GetByID(ctx, authorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Команда PR по умолчанию - основная команда автора
+	if pr.TeamName == "" {
+		pr.TeamName = author.TeamName
+	}
+
+	// Пул - команды-владельцы репозитория PR, если он зарегистрирован, иначе команда PR
+	settings, activeUsers, repository, err := s.reviewerPool(ctx, tx, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	owners, err := s.codeOwnerCandidates(ctx, tx, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Выбираем ревьюверов по стратегии и настройкам команды, недостающих - из резервных команд
+	exclude := map[string]domain.ExclusionReason{authorID: domain.ExclusionReasonAuthor}
+	selected, explanation, err := s.selectWithFallback(ctx, tx, settings, owners, activeUsers, settings.ReviewerCount, exclude)
+	if err != nil {
+		return nil, nil, err
+	}
+	explanation.Repository = repository
+	if len(selected) < settings.MinReviewers {
+		metrics.UserNoCandidatesErrors.Inc()
+
+		log.Warn().
+			Str("request_id", logger.GetRequestID(ctx)).
+			Str("layer", "service").
+			Str("author_id", authorID).
+			Int("min_reviewers", settings.MinReviewers).
+			Any("selected_reviewers", explanation.Selected).
+			Any("excluded", explanation.Excluded).
+			Msg("not enough reviewers")
+
+		return nil, nil, domain.ErrNotEnoughReviewers
+	}
+
+	metrics.PRReviewersAssigned.Observe(float64(len(selected)))
+	return selected, explanation, nil
+}
+
+// assignReviewers создаёт записи о назначении ревьюверов на PR и добавляет их в историю
+func assignReviewers(ctx context.Context, tx storage.Tx, prID string, reviewers []string) error {
+	for _, reviewerID := range reviewers {
+		if err := tx.PullRequestRepo().AssignReviewer(ctx, prID, reviewerID); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, domain.PullRequestEvent{
+			PullRequestID: prID,
+			Type:          domain.PullRequestEventReviewerAssigned,
+			NewReviewerID: reviewerID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceReviewer заменяет ревьювера PR и добавляет замену в историю
+func replaceReviewer(ctx context.Context, tx storage.Tx, prID, oldReviewerID, newReviewerID, reason string) error {
+	if err := tx.PullRequestRepo().UnassignReviewer(ctx, prID, oldReviewerID); err != nil {
+		return err
+	}
+	if err := tx.PullRequestRepo().AssignReviewer(ctx, prID, newReviewerID); err != nil {
+		return err
+	}
+
+	return recordEvent(ctx, tx, domain.PullRequestEvent{
+		PullRequestID: prID,
+		Type:          domain.PullRequestEventReviewerReplaced,
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
+		Reason:        reason,
+	})
+}
+
+// findReplacement выбирает замену ревьюверу oldReviewerID на PR по настройкам его команды
+// (с учётом резервных команд), исключая автора и текущих ревьюверов PR.
+// Объяснение выбора сохраняется с указанным trigger, даже если замены нет.
+// Возвращает nil, если подходящих кандидатов нет.
+func (s *Service) findReplacement(ctx context.Context, tx storage.Tx, pr *domain.PullRequest, oldReviewerID string, trigger domain.SelectionTrigger) (*domain.User, error) {
+	// Получаем заменяемого ревьювера, чтобы знать его команду и её настройки
+	oldReviewer, err := tx.UserRepo().GetByID(ctx, oldReviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamName, err := replacementTeam(ctx, tx, pr, oldReviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.teamSettings(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Получаем активных членов команды заменяемого ревьювера
+	activeUsers, err := tx.UserRepo().GetActiveByTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Исключаем автора PR и текущих ревьюверов (включая заменяемого)
+	exclude := make(map[string]domain.ExclusionReason, len(pr.AssignedReviewers)+1)
+	for _, r := range pr.AssignedReviewers {
+		exclude[r] = domain.ExclusionReasonAlreadyAssigned
+	}
+	exclude[pr.AuthorID] = domain.ExclusionReasonAuthor
+
+	selected, explanation, selectErr := s.selectWithFallback(ctx, tx, settings, nil, activeUsers, 1, exclude)
+	if explanation != nil {
+		explanation.ReplacedReviewerID = oldReviewerID
+		if err := recordExplanation(ctx, tx, pr.ID, trigger, explanation); err != nil {
+			return nil, err
+		}
+	}
+	if selectErr != nil {
+		return nil, selectErr
+	}
+	if len(selected) == 0 {
+		return nil, nil
+	}
+
+	return &selected[0], nil
+}
+
+// reassignInactiveOnPR заменяет неактивных ревьюверов OPEN PR по настройкам их команд.
+// Ревьювер, которому не нашлось замены, снимается с PR. pr.AssignedReviewers обновляется по ходу.
+func (s *Service) reassignInactiveOnPR(ctx context.Context, tx storage.Tx, pr *domain.PullRequest, inactiveReviewers []string) ([]domain.ReviewerReassignment, error) {
+	requestID := logger.GetRequestID(ctx)
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("pull_request_id", pr.ID).
+		Int("inactive_count", len(inactiveReviewers)).
+		Any("inactive_reviewers", inactiveReviewers).
+		Msg("found inactive reviewers")
+
+	reassignments := make([]domain.ReviewerReassignment, 0, len(inactiveReviewers))
+
+	// Для каждого неактивного ревьювера пытаемся найти замену
+	for _, oldReviewerID := range inactiveReviewers {
+		// Выбираем нового ревьювера по стратегии команды, при нехватке - из резервных команд
+		// Если все кандидаты на пределе, ревьювер снимается без замены, как и при их отсутствии
+		replacement, err := s.findReplacement(ctx, tx, pr, oldReviewerID, domain.SelectionTriggerInactive)
+		if err != nil && !errors.Is(err, domain.ErrAllAtCapacity) {
+			return nil, err
+		}
+
+		if replacement == nil {
+			// Нет кандидатов - просто удаляем ревьювера
+			if err := tx.PullRequestRepo().UnassignReviewer(ctx, pr.ID, oldReviewerID); err != nil {
+				return nil, err
+			}
+			if err := recordEvent(ctx, tx, domain.PullRequestEvent{
+				PullRequestID: pr.ID,
+				Type:          domain.PullRequestEventReviewerRemoved,
+				OldReviewerID: oldReviewerID,
+				Reason:        domain.EventReasonInactive,
+			}); err != nil {
+				return nil, err
+			}
+
+			reassignments = append(reassignments, domain.ReviewerReassignment{
+				OldReviewerID: oldReviewerID,
+				NewReviewerID: "",
+				WasRemoved:    true,
+			})
+
+			// Удаляем из списка ревьюверов в памяти
+			pr.AssignedReviewers = replaceReviewerID(pr.AssignedReviewers, oldReviewerID, "")
+
+			log.Info().
+				Str("request_id", requestID).
+				Str("pull_request_id", pr.ID).
+				Str("old_reviewer_id", oldReviewerID).
+				Msg("removed inactive reviewer (no candidates)")
+
+			continue
+		}
+
+		newReviewer := replacement.UserID
+
+		// Удаляем старого и назначаем нового
+		if err := replaceReviewer(ctx, tx, pr.ID, oldReviewerID, newReviewer, domain.EventReasonInactive); err != nil {
+			return nil, err
+		}
+
+		reassignments = append(reassignments, domain.ReviewerReassignment{
+			OldReviewerID:   oldReviewerID,
+			NewReviewerID:   newReviewer,
+			NewReviewerTeam: replacement.TeamName,
+			WasRemoved:      false,
+		})
+
+		// Обновляем список ревьюверов в памяти, чтобы следующие итерации его учитывали
+		pr.AssignedReviewers = replaceReviewerID(pr.AssignedReviewers, oldReviewerID, newReviewer)
+
+		log.Info().
+			Str("request_id", requestID).
+			Str("pull_request_id", pr.ID).
+			Str("old_reviewer_id", oldReviewerID).
+			Str("new_reviewer_id", newReviewer).
+			Msg("reassigned inactive reviewer")
+	}
+
+	return reassignments, nil
+}
+
+// replacementTeam возвращает команду, из которой ищется замена ревьюверу: команду PR, если ревьювер
+// в ней состоит, иначе его основную команду
+func replacementTeam(ctx context.Context, tx storage.Tx, pr *domain.PullRequest, oldReviewer *domain.User) (string, error) {
+	if pr.TeamName == "" || pr.TeamName == oldReviewer.TeamName {
+		return oldReviewer.TeamName, nil
+	}
+
+	if _, err := tx.TeamRepo().GetMembership(ctx, pr.TeamName, oldReviewer.UserID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return oldReviewer.TeamName, nil
+		}
+		return "", err
+	}
+
+	return pr.TeamName, nil
+}
+
+// reassignOpenReviews переназначает неактивных ревьюверов во всех OPEN PR, где назначен кто-то из reviewerIDs.
+// Возвращает результат по каждому PR, в котором были неактивные ревьюверы.
+func (s *Service) reassignOpenReviews(ctx context.Context, tx storage.Tx, reviewerIDs []string) ([]domain.ReassignInactiveResult, error) {
+	prIDs, err := tx.PullRequestRepo().GetOpenPRIDsByReviewers(ctx, reviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.ReassignInactiveResult, 0, len(prIDs))
+	for _, prID := range prIDs {
+		pr, err := tx.PullRequestRepo().GetByID(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+
+		inactiveReviewers, err := tx.PullRequestRepo().GetInactiveReviewers(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+		if len(inactiveReviewers) == 0 {
+			continue
+		}
+
+		reassignments, err := s.reassignInactiveOnPR(ctx, tx, pr, inactiveReviewers)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, domain.ReassignInactiveResult{
+			PullRequestID:       prID,
+			ReassignmentDetails: reassignments,
+		})
+	}
+
+	return results, nil
+}
+
+// observeReassignments обновляет метрики по результату переназначения неактивных ревьюверов
+func observeReassignments(result domain.ReassignInactiveResult) {
+	for _, detail := range result.ReassignmentDetails {
+		if detail.WasRemoved {
+			metrics.UserNoCandidatesErrors.Inc()
+		} else {
+			metrics.PRReassignedTotal.Inc()
+		}
+	}
+}
+
+// replaceReviewerID возвращает список ревьюверов, в котором oldID заменён на newID (пустой newID - удалён)
+func replaceReviewerID(reviewers []string, oldID, newID string) []string {
+	result := make([]string, 0, len(reviewers))
+	for _, r := range reviewers {
+		switch {
+		case r != oldID:
+			result = append(result, r)
+		case newID != "":
+			result = append(result, newID)
+		}
+	}
+	return result
+}
+
+// reviewerTeams возвращает команды выбранных ревьюверов
+func reviewerTeams(users []domain.User) map[string]string {
+	teams := make(map[string]string, len(users))
+	for _, user := range users {
+		teams[user.UserID] = user.TeamName
+	}
+	return teams
+}
+
+// takenBy дополняет exclude уже выбранными пользователями
+func takenBy(exclude map[string]domain.ExclusionReason, selected []domain.User) map[string]domain.ExclusionReason {
+	taken := make(map[string]domain.ExclusionReason, len(exclude)+len(selected))
+	for userID, reason := range exclude {
+		taken[userID] = reason
+	}
+	for _, user := range selected {
+		taken[user.UserID] = domain.ExclusionReasonAlreadyAssigned
+	}
+	return taken
+}
+
+// appendNewUsers добавляет в users тех пользователей из added, которых там ещё нет.
+// Один человек может оказаться в нескольких пулах (владелец файлов и участник команды, участник
+// резервной команды), а назначать его дважды нельзя.
+func appendNewUsers(users []domain.User, added []domain.User) []domain.User {
+	seen := make(map[string]struct{}, len(users)+len(added))
+	for _, user := range users {
+		seen[user.UserID] = struct{}{}
+	}
+	for _, user := range added {
+		if _, ok := seen[user.UserID]; ok {
+			continue
+		}
+		seen[user.UserID] = struct{}{}
+		users = append(users, user)
+	}
+	return users
+}
+
+// excludeCandidates возвращает пользователей, не попавших в exclude
+func excludeCandidates(users []domain.User, exclude map[string]domain.ExclusionReason) []domain.User {
+	candidates := make([]domain.User, 0, len(users))
+	for _, user := range users {
+		if _, excluded := exclude[user.UserID]; !excluded {
+			candidates = append(candidates, user)
+		}
+	}
+	return candidates
+}
+
+// userIDs возвращает идентификаторы пользователей
+func userIDs(users []domain.User) []string {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.UserID
+	}
+	return ids
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// BulkReassignInactiveReviewers переназначает неактивных ревьюверов во всех OPEN PR, подходящих под фильтр.
+// PR обрабатываются порциями по domain.BulkReassignChunkSize, каждая в своей транзакции. Если порция
+// не прошла, её PR повторяются по одному, чтобы ошибка в одном PR не откатывала остальные.
+// При отмене контекста возвращаются результаты уже закоммиченных порций вместе с ошибкой отмены.
+func (s *Service) BulkReassignInactiveReviewers(outerCtx context.Context, input *domain.BulkReassignInactiveInput) (*domain.BulkReassignInactiveResult, error) {
+	const op = "service.BulkReassignInactiveReviewers"
+	requestID := logger.GetRequestID(outerCtx)
+	var prIDs []string
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("bulk_reassign_inactive_reviewers").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("team_name", input.TeamName).
+		Int("requested_pr_count", len(input.PullRequestIDs)).
+		Bool("dry_run", input.DryRun).
+		Msg("bulk reassigning inactive reviewers")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		if input.TeamName != "" {
+			if _, err := tx.TeamRepo().GetByName(ctx, input.TeamName); err != nil {
+				return err
+			}
+		}
+
+		ids, err := tx.PullRequestRepo().GetOpenPRIDsWithInactiveReviewers(ctx, input.TeamName, input.PullRequestIDs)
+		if err != nil {
+			return err
+		}
+		prIDs = ids
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	result := &domain.BulkReassignInactiveResult{
+		DryRun:       input.DryRun,
+		PullRequests: make([]domain.BulkReassignPullRequestResult, 0, len(prIDs)),
+	}
+
+	var cancelErr error
+	for from := 0; from < len(prIDs); from += domain.BulkReassignChunkSize {
+		if err := outerCtx.Err(); err != nil {
+			cancelErr = err
+			break
+		}
+
+		chunk := prIDs[from:min(from+domain.BulkReassignChunkSize, len(prIDs))]
+
+		chunkResults, err := s.reassignInactiveChunk(outerCtx, chunk, input.DryRun)
+		if err != nil {
+			log.Warn().
+				Err(err).
+				Str("request_id", requestID).
+				Str("layer", "service").
+				Int("chunk_size", len(chunk)).
+				Msg("chunk failed, retrying pull requests one by one")
+
+			chunkResults = make([]domain.BulkReassignPullRequestResult, 0, len(chunk))
+			for _, prID := range chunk {
+				prResults, err := s.reassignInactiveChunk(outerCtx, []string{prID}, input.DryRun)
+				if err != nil {
+					chunkResults = append(chunkResults, s.failedBulkResult(outerCtx, op, prID, err))
+					continue
+				}
+				chunkResults = append(chunkResults, prResults...)
+			}
+		}
+
+		result.PullRequests = append(result.PullRequests, chunkResults...)
+	}
+
+	failed := 0
+	for _, pr := range result.PullRequests {
+		if pr.Failure != nil {
+			failed++
+			continue
+		}
+		if input.DryRun {
+			continue
+		}
+		metrics.PRReassignedTotal.Add(float64(len(pr.Replaced)))
+		metrics.UserNoCandidatesErrors.Add(float64(len(pr.Removed)))
+	}
+
+	if cancelErr != nil {
+		log.Warn().
+			Err(cancelErr).
+			Str("request_id", requestID).
+			Str("layer", "service").
+			Int("pr_count", len(result.PullRequests)).
+			Int("total_pr_count", len(prIDs)).
+			Bool("dry_run", input.DryRun).
+			Msg("bulk reassignment cancelled, returning processed pull requests")
+		return result, s.formatError(outerCtx, op, cancelErr)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Int("pr_count", len(result.PullRequests)).
+		Int("failed_pr_count", failed).
+		Bool("dry_run", input.DryRun).
+		Msg("successfully bulk reassigned inactive reviewers")
+
+	return result, nil
+}
+
+// reassignInactiveChunk переназначает неактивных ревьюверов перечисленных PR в одной транзакции.
+// PR, которые успели перестать быть OPEN или лишились неактивных ревьюверов, пропускаются.
+// При dryRun транзакция откатывается, а вычисленные замены возвращаются.
+func (s *Service) reassignInactiveChunk(outerCtx context.Context, prIDs []string, dryRun bool) ([]domain.BulkReassignPullRequestResult, error) {
+	var results []domain.BulkReassignPullRequestResult
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		results = make([]domain.BulkReassignPullRequestResult, 0, len(prIDs))
+
+		for _, prID := range prIDs {
+			pr, err := tx.PullRequestRepo().GetByID(ctx, prID)
+			if err != nil {
+				return err
+			}
+			if pr.Status != domain.PullRequestStatusOpen {
+				continue
+			}
+
+			inactiveReviewers, err := tx.PullRequestRepo().GetInactiveReviewers(ctx, prID)
+			if err != nil {
+				return err
+			}
+			if len(inactiveReviewers) == 0 {
+				continue
+			}
+
+			reassignments, err := s.reassignInactiveOnPR(ctx, tx, pr, inactiveReviewers)
+			if err != nil {
+				return err
+			}
+			if err := s.recordReassignments(ctx, tx, []domain.ReassignInactiveResult{{
+				PullRequestID:       prID,
+				ReassignmentDetails: reassignments,
+			}}); err != nil {
+				return err
+			}
+
+			prResult := domain.BulkReassignPullRequestResult{
+				PullRequestID: prID,
+				Replaced:      []domain.ReviewerReassignment{},
+				Removed:       []string{},
+				Failed:        []string{},
+			}
+			for _, reassignment := range reassignments {
+				if reassignment.WasRemoved {
+					prResult.Removed = append(prResult.Removed, reassignment.OldReviewerID)
+				} else {
+					prResult.Replaced = append(prResult.Replaced, reassignment)
+				}
+			}
+			results = append(results, prResult)
+		}
+
+		return finishTx(dryRun)
+	})
+
+	if err := dryRunRolledBack(err); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// failedBulkResult описывает PR, который не удалось обработать: все его неактивные ревьюверы попадают в Failed
+func (s *Service) failedBulkResult(outerCtx context.Context, op, prID string, cause error) domain.BulkReassignPullRequestResult {
+	result := domain.BulkReassignPullRequestResult{
+		PullRequestID: prID,
+		Replaced:      []domain.ReviewerReassignment{},
+		Removed:       []string{},
+		Failed:        []string{},
+	}
+
+	var domainErr *domain.Error
+	if errors.As(s.formatError(outerCtx, op, cause), &domainErr) {
+		result.Failure = domainErr
+	} else {
+		result.Failure = domain.ErrInternal
+	}
+
+	// Список неактивных ревьюверов читаем отдельно: транзакция с ошибкой уже откатилась
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		inactiveReviewers, err := tx.PullRequestRepo().GetInactiveReviewers(ctx, prID)
+		if err != nil {
+			return err
+		}
+		result.Failed = append(result.Failed, inactiveReviewers...)
+		return nil
+	})
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", logger.GetRequestID(outerCtx)).
+			Str("layer", "service").
+			Str("pull_request_id", prID).
+			Msg("failed to fetch inactive reviewers of failed pull request")
+	}
+
+	return result
+}
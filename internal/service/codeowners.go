@@ -0,0 +1,348 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// codeOwnersRule - правило CODEOWNERS: шаблон пути и владельцы подходящих файлов
+type codeOwnersRule struct {
+	line    int
+	pattern string
+	owners  []string
+	re      *regexp.Regexp
+}
+
+// codeOwnersPool - владельцы изменённых файлов PR, которых можно назначить, и совпавшие правила
+type codeOwnersPool struct {
+	users   []domain.User
+	matches []domain.CodeOwnersMatch
+}
+
+// parseCodeOwners разбирает CODEOWNERS в формате GitHub: "шаблон @user @org/team user@example.com".
+// Пустые строки, комментарии и заголовки секций GitLab ([Section]) пропускаются.
+// Правило без владельцев допустимо - оно снимает владельцев с совпавших файлов.
+func parseCodeOwners(content string) ([]codeOwnersRule, error) {
+	var rules []codeOwnersRule
+
+	for i, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pattern := strings.ReplaceAll(fields[0], `\#`, "#")
+
+		owners := make([]string, 0, len(fields)-1)
+		for _, owner := range fields[1:] {
+			// Комментарий до конца строки
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			if !strings.Contains(owner, "@") || owner == "@" {
+				return nil, fmt.Errorf("line %d: invalid owner %q", i+1, owner)
+			}
+			owners = append(owners, owner)
+		}
+
+		re, err := compileCodeOwnersPattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", i+1, pattern, err)
+		}
+
+		rules = append(rules, codeOwnersRule{line: i + 1, pattern: pattern, owners: owners, re: re})
+	}
+
+	return rules, nil
+}
+
+// compileCodeOwnersPattern переводит шаблон CODEOWNERS в регулярное выражение по правилам GitHub:
+// шаблон с "/" в начале или середине привязан к корню, иначе совпадает на любой глубине;
+// "*" не переходит через "/", "**" - переходит; совпавший каталог включает все вложенные файлы,
+// кроме шаблонов вида "docs/*", которые относятся только к файлам самого каталога.
+func compileCodeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(pattern, "/")
+	p := strings.TrimPrefix(pattern, "/")
+	directory := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	if p == "" {
+		return nil, errors.New("empty pattern")
+	}
+	if strings.Contains(p, "/") {
+		anchored = true
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 3
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i += 2
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+			i++
+		case p[i] == '?':
+			b.WriteString("[^/]")
+			i++
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+			i++
+		}
+	}
+
+	switch {
+	case directory:
+		b.WriteString("/.*$")
+	case strings.HasSuffix(p, "/*"):
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
+
+// matchCodeOwners находит для каждого файла последнее совпавшее правило (как GitHub)
+// и группирует файлы по правилам в порядке строк CODEOWNERS. Файлы без правила не попадают в результат.
+func matchCodeOwners(rules []codeOwnersRule, paths []string) []domain.CodeOwnersMatch {
+	byRule := make(map[int]*domain.CodeOwnersMatch)
+
+	for _, path := range paths {
+		path = strings.TrimPrefix(strings.TrimPrefix(path, "./"), "/")
+
+		matched := -1
+		for i, rule := range rules {
+			if rule.re.MatchString(path) {
+				matched = i
+			}
+		}
+		if matched < 0 {
+			continue
+		}
+
+		match, ok := byRule[matched]
+		if !ok {
+			rule := rules[matched]
+			match = &domain.CodeOwnersMatch{Line: rule.line, Pattern: rule.pattern, Owners: rule.owners, Paths: []string{}}
+			byRule[matched] = match
+		}
+		match.Paths = append(match.Paths, path)
+	}
+
+	matches := make([]domain.CodeOwnersMatch, 0, len(byRule))
+	for _, match := range byRule {
+		matches = append(matches, *match)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Line < matches[j].Line })
+	return matches
+}
+
+// codeOwnerUserID сопоставляет логин из CODEOWNERS пользователю: по логинам GitHub и GitLab,
+// иначе логин считается user_id
+func (s *Service) codeOwnerUserID(login string) string {
+	for _, source := range []domain.IngestionSource{domain.IngestionSourceGitHub, domain.IngestionSourceGitLab} {
+		if userID, ok := s.externalUsers[source][login]; ok {
+			return userID
+		}
+	}
+	return login
+}
+
+// codeOwnerCandidates возвращает владельцев изменённых файлов PR, которых можно назначить, и совпавшие правила.
+// CODEOWNERS берётся из PR, иначе зарегистрированный для его репозитория.
+// nil - PR без изменённых файлов или CODEOWNERS для него нет.
+// Владелец @org/team - активные участники команды team, @login - пользователь, адреса почты не сопоставляются.
+func (s *Service) codeOwnerCandidates(ctx context.Context, tx storage.Tx, pr *domain.PullRequest) (*codeOwnersPool, error) {
+	if len(pr.ChangedFiles) == 0 {
+		return nil, nil
+	}
+
+	content := pr.CodeOwners
+	if content == "" {
+		if pr.Repository == "" {
+			return nil, nil
+		}
+		registered, err := tx.CodeOwnersRepo().Get(ctx, pr.Repository)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		content = registered.Content
+	}
+
+	rules, err := parseCodeOwners(content)
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("request_id", logger.GetRequestID(ctx)).
+			Str("layer", "service").
+			Str("pull_request_id", pr.ID).
+			Msg("invalid CODEOWNERS")
+		return nil, domain.ErrInvalidInput
+	}
+
+	pool := &codeOwnersPool{matches: matchCodeOwners(rules, pr.ChangedFiles), users: []domain.User{}}
+
+	var ownerIDs []string
+	seen := make(map[string]bool)
+	add := func(users []domain.User) {
+		for _, user := range users {
+			if !seen[user.UserID] {
+				seen[user.UserID] = true
+				pool.users = append(pool.users, user)
+			}
+		}
+	}
+
+	for _, match := range pool.matches {
+		for _, owner := range match.Owners {
+			login, ok := strings.CutPrefix(owner, "@")
+			if !ok {
+				continue
+			}
+
+			if slash := strings.LastIndex(login, "/"); slash >= 0 {
+				members, err := tx.UserRepo().GetActiveByTeam(ctx, login[slash+1:])
+				if err != nil {
+					return nil, err
+				}
+				add(members)
+				continue
+			}
+			ownerIDs = append(ownerIDs, s.codeOwnerUserID(login))
+		}
+	}
+
+	if len(ownerIDs) > 0 {
+		users, err := tx.UserRepo().GetActiveByIDs(ctx, ownerIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		// Сохраняем порядок владельцев из CODEOWNERS
+		byID := make(map[string]domain.User, len(users))
+		for _, user := range users {
+			byID[user.UserID] = user
+		}
+		for _, userID := range ownerIDs {
+			if user, ok := byID[userID]; ok {
+				add([]domain.User{user})
+			}
+		}
+	}
+
+	log.Info().
+		Str("request_id", logger.GetRequestID(ctx)).
+		Str("layer", "service").
+		Str("pull_request_id", pr.ID).
+		Int("matched_rules", len(pool.matches)).
+		Any("code_owners", userIDs(pool.users)).
+		Msg("resolved code owners of changed files")
+
+	return pool, nil
+}
+
+// SetCodeOwners регистрирует CODEOWNERS репозитория. Некорректный CODEOWNERS не сохраняется.
+func (s *Service) SetCodeOwners(outerCtx context.Context, input *domain.SetCodeOwnersInput) (*domain.RepositoryCodeOwners, error) {
+	const op = "service.SetCodeOwners"
+	requestID := logger.GetRequestID(outerCtx)
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("set_code_owners").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("repository", input.Repository).
+		Msg("setting repository CODEOWNERS")
+
+	if input.Repository == "" {
+		return nil, s.formatError(outerCtx, op, domain.ErrInvalidInput)
+	}
+	if _, err := parseCodeOwners(input.Content); err != nil {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("layer", "service").
+			Str("repository", input.Repository).
+			Msg("invalid CODEOWNERS")
+		return nil, s.formatError(outerCtx, op, domain.ErrInvalidInput)
+	}
+
+	codeOwners := &domain.RepositoryCodeOwners{
+		Repository: input.Repository,
+		Content:    input.Content,
+		UpdatedAt:  time.Now(),
+	}
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		return tx.CodeOwnersRepo().Upsert(ctx, codeOwners)
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("repository", input.Repository).
+		Msg("successfully set repository CODEOWNERS")
+
+	return codeOwners, nil
+}
+
+// GetCodeOwners возвращает CODEOWNERS, зарегистрированный для репозитория
+func (s *Service) GetCodeOwners(outerCtx context.Context, repository string) (*domain.RepositoryCodeOwners, error) {
+	const op = "service.GetCodeOwners"
+	requestID := logger.GetRequestID(outerCtx)
+	var codeOwners *domain.RepositoryCodeOwners
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("get_code_owners").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("repository", repository).
+		Msg("getting repository CODEOWNERS")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		var err error
+		codeOwners, err = tx.CodeOwnersRepo().Get(ctx, repository)
+		return err
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	return codeOwners, nil
+}
@@ -0,0 +1,23 @@
+package service
+
+import "errors"
+
+// errDryRun откатывает транзакцию пробного запуска после того, как все изменения вычислены
+var errDryRun = errors.New("dry run")
+
+// finishTx завершает функцию транзакции: при пробном запуске возвращает errDryRun,
+// чтобы TxManager откатил изменения, иначе nil - транзакция коммитится
+func finishTx(dryRun bool) error {
+	if dryRun {
+		return errDryRun
+	}
+	return nil
+}
+
+// dryRunRolledBack отбрасывает errDryRun: для пробного запуска откат - ожидаемый итог транзакции
+func dryRunRolledBack(err error) error {
+	if errors.Is(err, errDryRun) {
+		return nil
+	}
+	return err
+}
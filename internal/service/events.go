@@ -0,0 +1,65 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// recordEvent добавляет событие в историю PR в рамках текущей транзакции.
+// Если инициатор не указан, используется инициатор запроса из контекста.
+func recordEvent(ctx context.Context, tx storage.Tx, event domain.PullRequestEvent) error {
+	if event.ActorID == "" {
+		event.ActorID = logger.GetActor(ctx)
+	}
+	event.CreatedAt = time.Now()
+
+	return tx.PullRequestRepo().AddEvent(ctx, &event)
+}
+
+// GetPullRequestHistory возвращает историю событий PR
+func (s *Service) GetPullRequestHistory(outerCtx context.Context, pullRequestID string) ([]domain.PullRequestEvent, error) {
+	const op = "service.GetPullRequestHistory"
+	requestID := logger.GetRequestID(outerCtx)
+	var events []domain.PullRequestEvent
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("get_pull_request_history").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", pullRequestID).
+		Msg("getting pull request history")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		// Проверяем существование PR, чтобы отличить неизвестный PR от пустой истории
+		if _, err := tx.PullRequestRepo().GetByID(ctx, pullRequestID); err != nil {
+			return err
+		}
+
+		var err error
+		events, err = tx.PullRequestRepo().GetEvents(ctx, pullRequestID)
+		return err
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", pullRequestID).
+		Int("events_count", len(events)).
+		Msg("successfully retrieved pull request history")
+
+	return events, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// newSelectionExplanation начинает объяснение выбора count ревьюверов с заранее исключёнными пользователями
+func newSelectionExplanation(count int, exclude map[string]domain.ExclusionReason) *domain.SelectionExplanation {
+	explanation := &domain.SelectionExplanation{
+		RequestedCount: count,
+		Teams:          []domain.TeamSelection{},
+		Excluded:       []domain.SelectionExclusion{},
+		Selected:       []string{},
+	}
+
+	// Сортируем, чтобы объяснение не зависело от порядка обхода map
+	excludedIDs := make([]string, 0, len(exclude))
+	for userID := range exclude {
+		excludedIDs = append(excludedIDs, userID)
+	}
+	sort.Strings(excludedIDs)
+
+	for _, userID := range excludedIDs {
+		addExclusion(explanation, userID, exclude[userID])
+	}
+	return explanation
+}
+
+// addExclusion добавляет исключённого пользователя, если он ещё не упомянут в объяснении
+func addExclusion(explanation *domain.SelectionExplanation, userID string, reason domain.ExclusionReason) {
+	for _, exclusion := range explanation.Excluded {
+		if exclusion.UserID == userID {
+			return
+		}
+	}
+	explanation.Excluded = append(explanation.Excluded, domain.SelectionExclusion{UserID: userID, Reason: reason})
+}
+
+// explainUnavailable добавляет в объяснение неактивных и отсутствующих участников рассмотренной команды
+func (s *Service) explainUnavailable(ctx context.Context, tx storage.Tx, explanation *domain.SelectionExplanation, teamName string) error {
+	unavailable, err := tx.UserRepo().GetUnavailableByTeam(ctx, teamName)
+	if err != nil {
+		return err
+	}
+
+	for _, exclusion := range unavailable {
+		addExclusion(explanation, exclusion.UserID, exclusion.Reason)
+	}
+	return nil
+}
+
+// explainTeamSelection добавляет в объяснение выбор из одной команды и её кандидатов на пределе
+func explainTeamSelection(explanation *domain.SelectionExplanation, teamName string, fallback bool, selection *teamSelection) {
+	explanation.Teams = append(explanation.Teams, domain.TeamSelection{
+		TeamName:   teamName,
+		Strategy:   selection.strategy,
+		Fallback:   fallback,
+		Candidates: userIDs(selection.available),
+		Selected:   userIDs(selection.selected),
+	})
+
+	for _, user := range selection.overloaded {
+		addExclusion(explanation, user.UserID, domain.ExclusionReasonAtCapacity)
+	}
+}
+
+// recordExplanation сохраняет объяснение выбора ревьюверов PR в рамках текущей транзакции
+func recordExplanation(ctx context.Context, tx storage.Tx, prID string, trigger domain.SelectionTrigger, explanation *domain.SelectionExplanation) error {
+	explanation.PullRequestID = prID
+	explanation.Trigger = trigger
+	explanation.CreatedAt = time.Now()
+
+	return tx.PullRequestRepo().AddSelectionExplanation(ctx, explanation)
+}
+
+// ExplainPullRequest возвращает объяснения всех выборов ревьюверов PR
+func (s *Service) ExplainPullRequest(outerCtx context.Context, pullRequestID string) ([]domain.SelectionExplanation, error) {
+	const op = "service.ExplainPullRequest"
+	requestID := logger.GetRequestID(outerCtx)
+	var explanations []domain.SelectionExplanation
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("explain_pull_request").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", pullRequestID).
+		Msg("explaining pull request reviewer selection")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		// Проверяем существование PR, чтобы отличить неизвестный PR от PR без назначений
+		if _, err := tx.PullRequestRepo().GetByID(ctx, pullRequestID); err != nil {
+			return err
+		}
+
+		var err error
+		explanations, err = tx.PullRequestRepo().GetSelectionExplanations(ctx, pullRequestID)
+		return err
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", pullRequestID).
+		Int("explanations_count", len(explanations)).
+		Msg("successfully retrieved selection explanations")
+
+	return explanations, nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// externalPullRequestID формирует идентификатор PR в принятой во внешней системе записи:
+// owner/name#number для GitHub и group/project!iid для GitLab
+func externalPullRequestID(source domain.IngestionSource, repository string, number int) string {
+	if source == domain.IngestionSourceGitLab {
+		return fmt.Sprintf("%s!%d", repository, number)
+	}
+	return fmt.Sprintf("%s#%d", repository, number)
+}
+
+// externalReviewers переводит назначенных ревьюверов в логины внешней системы.
+// Ревьюверы без сопоставления возвращаются отдельно, чтобы их можно было назначить вручную.
+func (s *Service) externalReviewers(source domain.IngestionSource, reviewerIDs []string) ([]string, []string) {
+	logins := make(map[string]string, len(s.externalUsers[source]))
+	for login, userID := range s.externalUsers[source] {
+		logins[userID] = login
+	}
+
+	mapped := make([]string, 0, len(reviewerIDs))
+	var unmapped []string
+	for _, reviewerID := range reviewerIDs {
+		if login, ok := logins[reviewerID]; ok {
+			mapped = append(mapped, login)
+			continue
+		}
+		unmapped = append(unmapped, reviewerID)
+	}
+	return mapped, unmapped
+}
+
+// deliveryClaimLease - срок, после которого занятую, но не обработанную доставку может занять повтор.
+// Он с запасом больше времени одного действия, поэтому живой обработчик доставку не теряет.
+const deliveryClaimLease = 5 * time.Minute
+
+// IngestPullRequestEvent повторяет действие над PR из внешней системы через обычные операции сервиса.
+// Доставка сначала занимается (PENDING), и только занявший её запрос применяет действие: повтор,
+// пришедший одновременно или после успеха, получает Duplicate. Неудачное действие снимает доставку,
+// поэтому её можно повторить; успешное отмечает её обработанной (DONE). Если обработчик упал,
+// не сняв и не завершив доставку, повтор занимает её заново по истечении deliveryClaimLease.
+func (s *Service) IngestPullRequestEvent(outerCtx context.Context, event *domain.ExternalPullRequestEvent) (*domain.IngestionResult, error) {
+	const op = "service.IngestPullRequestEvent"
+	requestID := logger.GetRequestID(outerCtx)
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("ingest_pull_request_event").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("source", string(event.Source)).
+		Str("delivery_id", event.DeliveryID).
+		Str("action", string(event.Action)).
+		Str("repository", event.Repository).
+		Int("number", event.Number).
+		Msg("ingesting external pull request event")
+
+	if event.DeliveryID == "" || event.Repository == "" || event.Number <= 0 {
+		return nil, s.formatError(outerCtx, op, domain.ErrInvalidInput)
+	}
+
+	result := &domain.IngestionResult{
+		DeliveryID:    event.DeliveryID,
+		PullRequestID: externalPullRequestID(event.Source, event.Repository, event.Number),
+		Action:        event.Action,
+	}
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		now := time.Now()
+		claimed, err := tx.IngestionRepo().ClaimDelivery(ctx, &domain.IngestedDelivery{
+			Source:        event.Source,
+			DeliveryID:    event.DeliveryID,
+			Action:        event.Action,
+			PullRequestID: result.PullRequestID,
+			ReceivedAt:    now,
+			ClaimedAt:     now,
+		}, now.Add(-deliveryClaimLease))
+		if err != nil {
+			return err
+		}
+
+		result.Duplicate = !claimed
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	if result.Duplicate {
+		metrics.IngestedEventsTotal.WithLabelValues(string(event.Source), string(event.Action), "duplicate").Inc()
+		log.Info().
+			Str("request_id", requestID).
+			Str("layer", "service").
+			Str("source", string(event.Source)).
+			Str("delivery_id", event.DeliveryID).
+			Msg("external delivery already claimed, skipping")
+		return result, nil
+	}
+
+	pr, err := s.applyExternalAction(outerCtx, event, result.PullRequestID)
+	if err != nil {
+		s.releaseDelivery(outerCtx, event)
+		return nil, s.formatError(outerCtx, op, err)
+	}
+	result.PullRequest = pr
+	result.ReviewerLogins, result.UnmappedReviewers = s.externalReviewers(event.Source, pr.AssignedReviewers)
+
+	// Действие уже применено: доставка, оставшаяся в PENDING, даёт Duplicate до истечения аренды
+	err = s.txmgr.Do(context.WithoutCancel(outerCtx), func(ctx context.Context, tx storage.Tx) error {
+		return tx.IngestionRepo().CompleteDelivery(ctx, event.Source, event.DeliveryID)
+	})
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", requestID).
+			Str("layer", "service").
+			Str("source", string(event.Source)).
+			Str("delivery_id", event.DeliveryID).
+			Msg("failed to complete external delivery")
+	}
+
+	metrics.IngestedEventsTotal.WithLabelValues(string(event.Source), string(event.Action), "applied").Inc()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("source", string(event.Source)).
+		Str("delivery_id", event.DeliveryID).
+		Str("pull_request_id", result.PullRequestID).
+		Str("status", string(pr.Status)).
+		Msg("successfully ingested external pull request event")
+
+	return result, nil
+}
+
+// releaseDelivery снимает доставку после неудачного действия. Снятие выполняется и для отменённого запроса,
+// иначе доставка осталась бы в PENDING и её повтор считался бы дубликатом.
+func (s *Service) releaseDelivery(outerCtx context.Context, event *domain.ExternalPullRequestEvent) {
+	err := s.txmgr.Do(context.WithoutCancel(outerCtx), func(ctx context.Context, tx storage.Tx) error {
+		return tx.IngestionRepo().ReleaseDelivery(ctx, event.Source, event.DeliveryID)
+	})
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", logger.GetRequestID(outerCtx)).
+			Str("layer", "service").
+			Str("source", string(event.Source)).
+			Str("delivery_id", event.DeliveryID).
+			Msg("failed to release external delivery")
+	}
+}
+
+// applyExternalAction выполняет операцию сервиса, соответствующую действию во внешней системе
+func (s *Service) applyExternalAction(ctx context.Context, event *domain.ExternalPullRequestEvent, prID string) (*domain.PullRequest, error) {
+	switch event.Action {
+	case domain.ExternalActionOpened:
+		authorID, ok := s.externalUsers[event.Source][event.AuthorLogin]
+		if !ok {
+			return nil, domain.ErrUnknownExternalUser
+		}
+		return s.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+			PullRequestID:   prID,
+			PullRequestName: event.Title,
+			AuthorID:        authorID,
+			Draft:           event.Draft,
+			Repository:      event.Repository,
+		})
+	case domain.ExternalActionMerged:
+		// PR уже смержен во внешней системе: одобрения сервиса его не остановят
+		return s.MergePullRequest(ctx, &domain.MergePullRequestInput{PullRequestID: prID, Force: true})
+	case domain.ExternalActionClosed:
+		return s.ClosePullRequest(ctx, &domain.ClosePullRequestInput{PullRequestID: prID})
+	case domain.ExternalActionReopened:
+		return s.ReopenPullRequest(ctx, &domain.ReopenPullRequestInput{PullRequestID: prID})
+	case domain.ExternalActionReadyForReview:
+		return s.MarkPullRequestReady(ctx, &domain.MarkReadyInput{PullRequestID: prID})
+	default:
+		return nil, domain.ErrInvalidInput
+	}
+}
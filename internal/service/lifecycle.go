@@ -0,0 +1,236 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Разрешённые переходы между статусами PR (кроме merge):
+//
+//	DRAFT  -> OPEN   (ready, назначаются ревьюверы)
+//	DRAFT  -> CLOSED (close)
+//	OPEN   -> CLOSED (close)
+//	CLOSED -> OPEN   (reopen, ревьюверы назначаются, если их нет)
+//
+// MERGED - конечный статус.
+
+// ClosePullRequest закрывает черновик или открытый PR без merge.
+// Ревьюверы остаются назначенными, но закрытый PR не учитывается в их нагрузке.
+func (s *Service) ClosePullRequest(outerCtx context.Context, input *domain.ClosePullRequestInput) (*domain.PullRequest, error) {
+	const op = "service.ClosePullRequest"
+	requestID := logger.GetRequestID(outerCtx)
+	var pr *domain.PullRequest
+	var previousStatus domain.PullRequestStatus
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("close_pull_request").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", input.PullRequestID).
+		Msg("closing pull request")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		existingPR, err := tx.PullRequestRepo().GetByID(ctx, input.PullRequestID)
+		if err != nil {
+			return err
+		}
+
+		if existingPR.Status != domain.PullRequestStatusOpen && existingPR.Status != domain.PullRequestStatusDraft {
+			return domain.ErrInvalidTransition
+		}
+		previousStatus = existingPR.Status
+
+		now := time.Now()
+		existingPR.Status = domain.PullRequestStatusClosed
+		existingPR.ClosedAt = &now
+
+		if err := tx.PullRequestRepo().Update(ctx, existingPR); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, domain.PullRequestEvent{
+			PullRequestID: existingPR.ID,
+			Type:          domain.PullRequestEventClosed,
+		}); err != nil {
+			return err
+		}
+
+		pr = existingPR
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	metrics.PRClosedTotal.Inc()
+	if previousStatus == domain.PullRequestStatusOpen {
+		metrics.PROpenCount.Dec()
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", pr.ID).
+		Str("previous_status", string(previousStatus)).
+		Msg("successfully closed pull request")
+
+	return pr, nil
+}
+
+// ReopenPullRequest повторно открывает закрытый PR.
+// Если у PR нет ревьюверов (например, закрыт черновик), они назначаются как при создании.
+func (s *Service) ReopenPullRequest(outerCtx context.Context, input *domain.ReopenPullRequestInput) (*domain.PullRequest, error) {
+	const op = "service.ReopenPullRequest"
+	requestID := logger.GetRequestID(outerCtx)
+	var pr *domain.PullRequest
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("reopen_pull_request").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", input.PullRequestID).
+		Msg("reopening pull request")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		existingPR, err := tx.PullRequestRepo().GetByID(ctx, input.PullRequestID)
+		if err != nil {
+			return err
+		}
+
+		if existingPR.Status != domain.PullRequestStatusClosed {
+			return domain.ErrInvalidTransition
+		}
+
+		existingPR.Status = domain.PullRequestStatusOpen
+		existingPR.ClosedAt = nil
+
+		if err := recordEvent(ctx, tx, domain.PullRequestEvent{
+			PullRequestID: existingPR.ID,
+			Type:          domain.PullRequestEventReopened,
+		}); err != nil {
+			return err
+		}
+
+		if len(existingPR.AssignedReviewers) == 0 {
+			if err := s.openWithReviewers(ctx, tx, existingPR); err != nil {
+				return err
+			}
+		} else if err := tx.PullRequestRepo().Update(ctx, existingPR); err != nil {
+			return err
+		}
+
+		pr = existingPR
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	metrics.PROpenCount.Inc()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", pr.ID).
+		Int("reviewers_count", len(pr.AssignedReviewers)).
+		Msg("successfully reopened pull request")
+
+	return pr, nil
+}
+
+// MarkPullRequestReady переводит черновик в OPEN и назначает ревьюверов по настройкам команды автора
+func (s *Service) MarkPullRequestReady(outerCtx context.Context, input *domain.MarkReadyInput) (*domain.PullRequest, error) {
+	const op = "service.MarkPullRequestReady"
+	requestID := logger.GetRequestID(outerCtx)
+	var pr *domain.PullRequest
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("mark_pull_request_ready").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", input.PullRequestID).
+		Msg("marking pull request ready for review")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		existingPR, err := tx.PullRequestRepo().GetByID(ctx, input.PullRequestID)
+		if err != nil {
+			return err
+		}
+
+		if existingPR.Status != domain.PullRequestStatusDraft {
+			return domain.ErrInvalidTransition
+		}
+
+		existingPR.Status = domain.PullRequestStatusOpen
+		if err := recordEvent(ctx, tx, domain.PullRequestEvent{
+			PullRequestID: existingPR.ID,
+			Type:          domain.PullRequestEventReady,
+		}); err != nil {
+			return err
+		}
+		if err := s.openWithReviewers(ctx, tx, existingPR); err != nil {
+			return err
+		}
+
+		pr = existingPR
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	metrics.PROpenCount.Inc()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", pr.ID).
+		Int("reviewers_count", len(pr.AssignedReviewers)).
+		Msg("successfully marked pull request ready")
+
+	return pr, nil
+}
+
+// openWithReviewers сохраняет новый статус PR и назначает ему ревьюверов как при создании
+func (s *Service) openWithReviewers(ctx context.Context, tx storage.Tx, pr *domain.PullRequest) error {
+	selected, explanation, err := s.selectInitialReviewers(ctx, tx, pr)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.PullRequestRepo().Update(ctx, pr); err != nil {
+		return err
+	}
+
+	reviewers := userIDs(selected)
+	if err := assignReviewers(ctx, tx, pr.ID, reviewers); err != nil {
+		return err
+	}
+	if err := recordExplanation(ctx, tx, pr.ID, domain.SelectionTriggerReady, explanation); err != nil {
+		return err
+	}
+
+	pr.AssignedReviewers = reviewers
+	pr.ReviewerTeams = reviewerTeams(selected)
+	return nil
+}
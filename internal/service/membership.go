@@ -0,0 +1,426 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AddTeamMember добавляет пользователя в команду или изменяет роль и флаг его членства.
+// Несуществующий пользователь создаётся с этой командой в качестве основной.
+func (s *Service) AddTeamMember(outerCtx context.Context, input *domain.AddTeamMemberInput) (*domain.TeamMemberResult, error) {
+	const op = "service.AddTeamMember"
+	requestID := logger.GetRequestID(outerCtx)
+	var result *domain.TeamMemberResult
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("add_team_member").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("team_name", input.TeamName).
+		Str("user_id", input.UserID).
+		Str("role", string(input.Role)).
+		Bool("is_active", input.IsActive).
+		Bool("reassign_open_reviews", input.ReassignOpenReviews).
+		Msg("adding team member")
+
+	role, ok := normalizeTeamRole(input.Role)
+	if !ok {
+		return nil, s.formatError(outerCtx, op, domain.ErrInvalidInput)
+	}
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		if _, err := tx.TeamRepo().GetByName(ctx, input.TeamName); err != nil {
+			return err
+		}
+
+		membership := &domain.TeamMembership{
+			TeamName: input.TeamName,
+			UserID:   input.UserID,
+			Role:     role,
+			IsActive: input.IsActive,
+		}
+
+		user, err := tx.UserRepo().GetByID(ctx, input.UserID)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			// Новый пользователь: флаг относится к самому пользователю, как и в /team/add
+			if input.Username == "" {
+				return domain.ErrInvalidInput
+			}
+			user = &domain.User{
+				UserID:   input.UserID,
+				Username: input.Username,
+				TeamName: input.TeamName,
+				IsActive: input.IsActive,
+			}
+			if err := tx.UserRepo().CreateBatch(ctx, []domain.User{*user}); err != nil {
+				return err
+			}
+			membership.IsActive = true
+		case err != nil:
+			return err
+		}
+
+		if err := tx.TeamRepo().UpsertMembership(ctx, membership); err != nil {
+			return err
+		}
+
+		result = &domain.TeamMemberResult{
+			TeamName:    input.TeamName,
+			Membership:  membership,
+			PrimaryTeam: user.TeamName,
+		}
+
+		if !input.ReassignOpenReviews || membership.IsActive {
+			return nil
+		}
+
+		reassignments, err := s.reassignTeamReviews(ctx, tx, input.TeamName, input.UserID)
+		if err != nil {
+			return err
+		}
+		result.Reassignments = reassignments
+		return s.recordReassignments(ctx, tx, result.Reassignments)
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	for _, reassignment := range result.Reassignments {
+		observeReassignments(reassignment)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("team_name", result.TeamName).
+		Str("user_id", input.UserID).
+		Int("reassigned_pr_count", len(result.Reassignments)).
+		Msg("successfully added team member")
+
+	return result, nil
+}
+
+// RemoveTeamMember удаляет пользователя из команды.
+// Если команда была основной, основной становится команда, в которую он вступил раньше остальных.
+func (s *Service) RemoveTeamMember(outerCtx context.Context, input *domain.RemoveTeamMemberInput) (*domain.TeamMemberResult, error) {
+	const op = "service.RemoveTeamMember"
+	requestID := logger.GetRequestID(outerCtx)
+	var result *domain.TeamMemberResult
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("remove_team_member").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("team_name", input.TeamName).
+		Str("user_id", input.UserID).
+		Bool("reassign_open_reviews", input.ReassignOpenReviews).
+		Msg("removing team member")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		user, memberships, err := loadMemberships(ctx, tx, input.TeamName, input.UserID)
+		if err != nil {
+			return err
+		}
+		if len(memberships) == 1 {
+			return domain.ErrLastTeam
+		}
+
+		result = &domain.TeamMemberResult{
+			TeamName:    input.TeamName,
+			PrimaryTeam: user.TeamName,
+		}
+
+		// Переназначаем до удаления членства, чтобы замена выбиралась из команды PR
+		if input.ReassignOpenReviews {
+			reassignments, err := s.reassignTeamReviews(ctx, tx, input.TeamName, input.UserID)
+			if err != nil {
+				return err
+			}
+			result.Reassignments = reassignments
+		}
+
+		if err := tx.TeamRepo().DeleteMembership(ctx, input.TeamName, input.UserID); err != nil {
+			return err
+		}
+
+		if user.TeamName == input.TeamName {
+			primaryTeam := nextPrimaryTeam(memberships, input.TeamName)
+			if err := tx.UserRepo().SetPrimaryTeam(ctx, input.UserID, primaryTeam); err != nil {
+				return err
+			}
+			result.PrimaryTeam = primaryTeam
+		}
+
+		return s.recordReassignments(ctx, tx, result.Reassignments)
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	for _, reassignment := range result.Reassignments {
+		observeReassignments(reassignment)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("team_name", result.TeamName).
+		Str("user_id", input.UserID).
+		Str("primary_team", result.PrimaryTeam).
+		Int("reassigned_pr_count", len(result.Reassignments)).
+		Msg("successfully removed team member")
+
+	return result, nil
+}
+
+// MoveTeamMember переводит пользователя из одной команды в другую с сохранением роли и флага членства.
+// Если прежняя команда была основной, основной становится новая.
+func (s *Service) MoveTeamMember(outerCtx context.Context, input *domain.MoveTeamMemberInput) (*domain.TeamMemberResult, error) {
+	const op = "service.MoveTeamMember"
+	requestID := logger.GetRequestID(outerCtx)
+	var result *domain.TeamMemberResult
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("move_team_member").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("from_team", input.FromTeam).
+		Str("to_team", input.ToTeam).
+		Str("user_id", input.UserID).
+		Bool("reassign_open_reviews", input.ReassignOpenReviews).
+		Msg("moving team member")
+
+	if input.FromTeam == input.ToTeam {
+		return nil, s.formatError(outerCtx, op, domain.ErrInvalidInput)
+	}
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		if _, err := tx.TeamRepo().GetByName(ctx, input.ToTeam); err != nil {
+			return err
+		}
+
+		user, memberships, err := loadMemberships(ctx, tx, input.FromTeam, input.UserID)
+		if err != nil {
+			return err
+		}
+
+		var source domain.TeamMembership
+		for _, membership := range memberships {
+			switch membership.TeamName {
+			case input.ToTeam:
+				return domain.ErrAlreadyTeamMember
+			case input.FromTeam:
+				source = membership
+			}
+		}
+
+		result = &domain.TeamMemberResult{
+			TeamName:    input.ToTeam,
+			PrimaryTeam: user.TeamName,
+		}
+
+		// Переназначаем до удаления членства, чтобы замена выбиралась из прежней команды
+		if input.ReassignOpenReviews {
+			reassignments, err := s.reassignTeamReviews(ctx, tx, input.FromTeam, input.UserID)
+			if err != nil {
+				return err
+			}
+			result.Reassignments = reassignments
+		}
+
+		if err := tx.TeamRepo().DeleteMembership(ctx, input.FromTeam, input.UserID); err != nil {
+			return err
+		}
+
+		membership := &domain.TeamMembership{
+			TeamName: input.ToTeam,
+			UserID:   input.UserID,
+			Role:     source.Role,
+			IsActive: source.IsActive,
+		}
+		if err := tx.TeamRepo().UpsertMembership(ctx, membership); err != nil {
+			return err
+		}
+		result.Membership = membership
+
+		if user.TeamName == input.FromTeam {
+			if err := tx.UserRepo().SetPrimaryTeam(ctx, input.UserID, input.ToTeam); err != nil {
+				return err
+			}
+			result.PrimaryTeam = input.ToTeam
+		}
+
+		return s.recordReassignments(ctx, tx, result.Reassignments)
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	for _, reassignment := range result.Reassignments {
+		observeReassignments(reassignment)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("from_team", input.FromTeam).
+		Str("to_team", result.TeamName).
+		Str("user_id", input.UserID).
+		Str("primary_team", result.PrimaryTeam).
+		Int("reassigned_pr_count", len(result.Reassignments)).
+		Msg("successfully moved team member")
+
+	return result, nil
+}
+
+// DeleteTeam удаляет команду, у которой нет OPEN PR.
+// Участникам, для которых команда была основной, назначается другая их команда.
+func (s *Service) DeleteTeam(outerCtx context.Context, teamName string) error {
+	const op = "service.DeleteTeam"
+	requestID := logger.GetRequestID(outerCtx)
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("delete_team").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("team_name", teamName).
+		Msg("deleting team")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		team, err := tx.TeamRepo().GetByName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		openCount, err := tx.PullRequestRepo().CountOpenByTeam(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		if openCount > 0 {
+			return domain.ErrTeamHasOpenPRs
+		}
+
+		// Пользователи удаляются вместе со своей основной командой, поэтому сначала переносим её
+		for _, member := range team.Members {
+			user, err := tx.UserRepo().GetByID(ctx, member.UserID)
+			if err != nil {
+				return err
+			}
+			if user.TeamName != teamName {
+				continue
+			}
+
+			memberships, err := tx.TeamRepo().GetMemberships(ctx, member.UserID)
+			if err != nil {
+				return err
+			}
+			primaryTeam := nextPrimaryTeam(memberships, teamName)
+			if primaryTeam == "" {
+				return domain.ErrLastTeam
+			}
+			if err := tx.UserRepo().SetPrimaryTeam(ctx, member.UserID, primaryTeam); err != nil {
+				return err
+			}
+		}
+
+		return tx.TeamRepo().Delete(ctx, teamName)
+	})
+
+	if err != nil {
+		return s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("team_name", teamName).
+		Msg("successfully deleted team")
+
+	return nil
+}
+
+// loadMemberships возвращает пользователя и все его членства, проверяя, что он состоит в команде
+func loadMemberships(ctx context.Context, tx storage.Tx, teamName, userID string) (*domain.User, []domain.TeamMembership, error) {
+	user, err := tx.UserRepo().GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	memberships, err := tx.TeamRepo().GetMemberships(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, membership := range memberships {
+		if membership.TeamName == teamName {
+			return user, memberships, nil
+		}
+	}
+
+	return nil, nil, domain.ErrNotTeamMember
+}
+
+// nextPrimaryTeam возвращает самую раннюю команду пользователя, кроме excluded ("" - других команд нет)
+func nextPrimaryTeam(memberships []domain.TeamMembership, excluded string) string {
+	for _, membership := range memberships {
+		if membership.TeamName != excluded {
+			return membership.TeamName
+		}
+	}
+	return ""
+}
+
+// reassignTeamReviews переназначает открытые ревью пользователя на PR команды
+func (s *Service) reassignTeamReviews(ctx context.Context, tx storage.Tx, teamName, userID string) ([]domain.ReassignInactiveResult, error) {
+	prIDs, err := tx.PullRequestRepo().GetOpenPRIDsByTeamReviewer(ctx, teamName, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.ReassignInactiveResult, 0, len(prIDs))
+	for _, prID := range prIDs {
+		pr, err := tx.PullRequestRepo().GetByID(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+
+		reassignments, err := s.reassignInactiveOnPR(ctx, tx, pr, []string{userID})
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, domain.ReassignInactiveResult{
+			PullRequestID:       prID,
+			ReassignmentDetails: reassignments,
+		})
+	}
+
+	return results, nil
+}
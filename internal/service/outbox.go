@@ -0,0 +1,255 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// maxOutboxBackoffShift ограничивает рост задержки между попытками публикации: backoff * 2^10
+const maxOutboxBackoffShift = 10
+
+// outboxSettings - приёмники событий outbox и задержка перед повторной публикацией
+type outboxSettings struct {
+	sinks        []EventSink
+	retryBackoff time.Duration
+}
+
+// eventEnvelope - конверт события: его получают все приёмники
+type eventEnvelope struct {
+	EventID    string                  `json:"event_id"`
+	EventType  domain.WebhookEventType `json:"event_type"`
+	Actor      string                  `json:"actor"`
+	OccurredAt time.Time               `json:"occurred_at"`
+	Data       interface{}             `json:"data"`
+}
+
+// eventPullRequest - PR в данных событий
+type eventPullRequest struct {
+	PullRequestID     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+	AuthorID          string     `json:"author_id"`
+	Status            string     `json:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	MergedAt          *time.Time `json:"merged_at,omitempty"`
+	MergeForced       bool       `json:"merge_forced"`
+}
+
+// eventReassignment - данные события pull_request.reviewer_reassigned
+type eventReassignment struct {
+	PullRequestID   string `json:"pull_request_id"`
+	OldReviewerID   string `json:"old_reviewer_id"`
+	NewReviewerID   string `json:"new_reviewer_id"` // пусто, если ревьювер снят без замены
+	NewReviewerTeam string `json:"new_reviewer_team,omitempty"`
+	Reason          string `json:"reason"`
+}
+
+// eventUserDeactivation - данные события user.deactivated
+type eventUserDeactivation struct {
+	UserID              string `json:"user_id"`
+	Username            string `json:"username"`
+	TeamName            string `json:"team_name"`
+	ReassignedPullCount int    `json:"reassigned_pr_count"`
+}
+
+// eventTeamDeactivation - данные события team.deactivated
+type eventTeamDeactivation struct {
+	TeamName             string `json:"team_name"`
+	DeactivatedUserCount int    `json:"deactivated_user_count"`
+	ReassignedPullCount  int    `json:"reassigned_pr_count"`
+}
+
+// newEventPullRequest готовит PR для данных события
+func newEventPullRequest(pr *domain.PullRequest) eventPullRequest {
+	reviewers := pr.AssignedReviewers
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+	return eventPullRequest{
+		PullRequestID:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorID:          pr.AuthorID,
+		Status:            string(pr.Status),
+		AssignedReviewers: reviewers,
+		MergedAt:          pr.MergedAt,
+		MergeForced:       pr.MergeForced,
+	}
+}
+
+// recordOutboxEvent записывает событие в outbox в рамках текущей транзакции: оно будет опубликовано,
+// только если транзакция закоммитится. Без WithOutbox ничего не делает.
+func (s *Service) recordOutboxEvent(ctx context.Context, tx storage.Tx, eventType domain.WebhookEventType, data interface{}) error {
+	if s.outbox == nil {
+		return nil
+	}
+
+	now := time.Now()
+	envelope := eventEnvelope{
+		EventID:    uuid.NewString(),
+		EventType:  eventType,
+		Actor:      logger.GetActor(ctx),
+		OccurredAt: now,
+		Data:       data,
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("encode %s event: %w", eventType, err)
+	}
+
+	return tx.OutboxRepo().Add(ctx, &domain.OutboxEvent{
+		EventID:       envelope.EventID,
+		EventType:     eventType,
+		Payload:       payload,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+}
+
+// recordReassignments записывает по событию на каждую замену или снятие неактивного ревьювера
+func (s *Service) recordReassignments(ctx context.Context, tx storage.Tx, results []domain.ReassignInactiveResult) error {
+	for _, result := range results {
+		for _, detail := range result.ReassignmentDetails {
+			if err := s.recordOutboxEvent(ctx, tx, domain.WebhookEventReviewerReassigned, eventReassignment{
+				PullRequestID:   result.PullRequestID,
+				OldReviewerID:   detail.OldReviewerID,
+				NewReviewerID:   detail.NewReviewerID,
+				NewReviewerTeam: detail.NewReviewerTeam,
+				Reason:          domain.EventReasonInactive,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PublishOutbox публикует в приёмники все события outbox, время попытки которых наступило.
+// Каждое событие обрабатывается в отдельной транзакции с блокировкой строки, поэтому несколько
+// экземпляров сервиса не публикуют одно событие одновременно. Если хотя бы один приёмник вернул ошибку,
+// событие позже публикуется во все приёмники заново (at-least-once).
+// Возвращает количество опубликованных событий. Без WithOutbox ничего не делает.
+func (s *Service) PublishOutbox(outerCtx context.Context) (int, error) {
+	const op = "service.PublishOutbox"
+
+	if s.outbox == nil {
+		return 0, nil
+	}
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("publish_outbox").Observe(time.Since(start).Seconds())
+	}()
+
+	published := 0
+	for {
+		var event *domain.OutboxEvent
+
+		err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+			e, err := tx.OutboxRepo().ClaimDue(ctx, time.Now())
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			s.recordPublishAttempt(e, s.publishToSinks(ctx, *e), time.Now())
+
+			if err := tx.OutboxRepo().Update(ctx, e); err != nil {
+				return err
+			}
+
+			event = e
+			return nil
+		})
+
+		if err != nil {
+			return published, s.formatError(outerCtx, op, err)
+		}
+
+		// Событий, готовых к публикации, больше нет
+		if event == nil {
+			return published, nil
+		}
+
+		if event.PublishedAt == nil {
+			metrics.OutboxEventsTotal.WithLabelValues(string(event.EventType), "retry").Inc()
+			log.Warn().
+				Str("layer", "service").
+				Int64("outbox_id", event.ID).
+				Str("event_id", event.EventID).
+				Str("event_type", string(event.EventType)).
+				Int("attempts", event.Attempts).
+				Str("error", event.LastError).
+				Time("next_attempt_at", event.NextAttemptAt).
+				Msg("outbox event publishing failed")
+			continue
+		}
+
+		published++
+		metrics.OutboxEventsTotal.WithLabelValues(string(event.EventType), "published").Inc()
+		log.Debug().
+			Str("layer", "service").
+			Int64("outbox_id", event.ID).
+			Str("event_id", event.EventID).
+			Str("event_type", string(event.EventType)).
+			Msg("outbox event published")
+	}
+}
+
+// publishToSinks передаёт событие всем приёмникам и возвращает ошибки тех, кто его не принял
+func (s *Service) publishToSinks(ctx context.Context, event domain.OutboxEvent) error {
+	var errs []error
+	for _, sink := range s.outbox.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			metrics.OutboxSinkErrorsTotal.WithLabelValues(sink.Name()).Inc()
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// recordPublishAttempt обновляет событие по итогу попытки: опубликовано или повтор с экспоненциальной задержкой
+func (s *Service) recordPublishAttempt(event *domain.OutboxEvent, publishErr error, now time.Time) {
+	if publishErr == nil {
+		event.LastError = ""
+		event.PublishedAt = &now
+		return
+	}
+
+	event.Attempts++
+	event.LastError = publishErr.Error()
+	event.NextAttemptAt = now.Add(s.outbox.retryBackoff << min(event.Attempts-1, maxOutboxBackoffShift))
+}
+
+// RunOutboxRelay периодически публикует события outbox в приёмники. Блокируется до отмены ctx.
+func (s *Service) RunOutboxRelay(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Info().
+		Dur("interval", interval).
+		Msg("outbox relay started")
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.PublishOutbox(ctx); err != nil {
+				log.Error().Err(err).Msg("failed to publish outbox events")
+			}
+		case <-ctx.Done():
+			log.Info().Msg("stopping outbox relay")
+			return
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// cursorPayload - содержимое курсора страницы (для клиента курсор непрозрачен)
+type cursorPayload struct {
+	SortValue time.Time `json:"v"`
+	ID        string    `json:"id"`
+}
+
+// encodeCursor кодирует позицию последнего элемента страницы
+func encodeCursor(sortValue time.Time, id string) string {
+	data, _ := json.Marshal(cursorPayload{SortValue: sortValue, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor разбирает курсор, полученный от клиента (пустой курсор - первая страница)
+func decodeCursor(cursor string) (*domain.PageCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidInput
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.ID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	return &domain.PageCursor{SortValue: payload.SortValue, ID: payload.ID}, nil
+}
+
+// pageLimit проверяет размер страницы и подставляет значение по умолчанию
+func pageLimit(limit int) (int, error) {
+	if limit == 0 {
+		return domain.DefaultPageLimit, nil
+	}
+	if limit < 0 || limit > domain.MaxPageLimit {
+		return 0, domain.ErrInvalidInput
+	}
+	return limit, nil
+}
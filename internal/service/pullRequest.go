@@ -41,6 +41,19 @@ func (s *Service) CreatePullRequest(outerCtx context.Context, input *domain.Crea
 		Str("author_id", input.AuthorID).
 		Msg("creating pull request with transaction")
 
+	// Переданный CODEOWNERS проверяем сразу, а не только когда дело дойдёт до назначения ревьюверов
+	if input.CodeOwners != "" {
+		if _, err := parseCodeOwners(input.CodeOwners); err != nil {
+			log.Warn().
+				Err(err).
+				Str("request_id", requestID).
+				Str("layer", "service").
+				Str("pull_request_id", input.PullRequestID).
+				Msg("invalid CODEOWNERS")
+			return nil, s.formatError(outerCtx, op, domain.ErrInvalidInput)
+		}
+	}
+
 	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
 		// Проверяем что PR с таким ID еще не существует
 		existingPR, err := tx.PullRequestRepo().GetByID(ctx, input.PullRequestID)
@@ -51,73 +64,104 @@ func (s *Service) CreatePullRequest(outerCtx context.Context, input *domain.Crea
 			return err
 		}
 
-		// Получаем список активных членов команды автора (исключая самого автора)
-		activeUsers, err := tx.UserRepo().GetActiveTeamMembers(ctx, input.AuthorID)
-		if err != nil {
-			return err
+		// PR создаётся от имени команды, в которой автор состоит
+		if input.TeamName != "" {
+			if _, err := tx.TeamRepo().GetMembership(ctx, input.TeamName, input.AuthorID); err != nil {
+				if errors.Is(err, storage.ErrNotFound) {
+					return domain.ErrNotTeamMember
+				}
+				return err
+			}
+		}
+
+		pr = &domain.PullRequest{
+			ID:           input.PullRequestID,
+			Name:         input.PullRequestName,
+			AuthorID:     input.AuthorID,
+			Repository:   input.Repository,
+			TeamName:     input.TeamName,
+			ChangedFiles: input.ChangedFiles,
+			CodeOwners:   input.CodeOwners,
 		}
 
-		// Выбираем до 2 случайных ревьюверов из доступных
-		reviewers := make([]string, 0, 2)
-		lenActive := len(activeUsers)
-		selectionStart := time.Now()
-		for i := 0; i < min(2, lenActive); i++ {
-			index, err := secureRandomInt(len(activeUsers))
+		// Черновик создаётся без ревьюверов - они назначаются при переводе в OPEN
+		status := domain.PullRequestStatusDraft
+		selected := []domain.User{}
+		var explanation *domain.SelectionExplanation
+		if input.Draft && pr.TeamName == "" {
+			// Команда черновика фиксируется сразу, как при выборе ревьюверов: по ней проверяется удаление команды
+			author, err := tx.UserRepo().GetByID(ctx, pr.AuthorID)
 			if err != nil {
 				return err
 			}
-			userID := activeUsers[index].UserID
-			activeUsers = append(activeUsers[:index], activeUsers[index+1:]...)
-			reviewers = append(reviewers, userID)
+			pr.TeamName = author.TeamName
 		}
-		metrics.RandomReviewerSelectionDuration.Observe(time.Since(selectionStart).Seconds())
-		metrics.PRReviewersAssigned.Observe(float64(len(reviewers)))
-
-		log.Info().
-			Str("request_id", requestID).
-			Str("layer", "service").
-			Str("pull_request_id", input.PullRequestID).
-			Any("selected_reviewers", reviewers).
-			Int("selected_reviewers_count", len(reviewers)).
-			Msg("selected reviewers randomly")
-
-		// Создаем pull request со статусом OPEN
-		pr = &domain.PullRequest{
-			ID:                input.PullRequestID,
-			Name:              input.PullRequestName,
-			AuthorID:          input.AuthorID,
-			Status:            domain.PullRequestStatusOpen,
-			AssignedReviewers: reviewers,
+		if !input.Draft {
+			status = domain.PullRequestStatusOpen
+			selected, explanation, err = s.selectInitialReviewers(ctx, tx, pr)
+			if err != nil {
+				return err
+			}
 		}
+		reviewers := userIDs(selected)
+
+		pr.Status = status
+		pr.AssignedReviewers = reviewers
+		pr.ReviewerTeams = reviewerTeams(selected)
 
 		if err := tx.PullRequestRepo().Create(ctx, pr); err != nil {
 			return err
 		}
+		if err := recordEvent(ctx, tx, domain.PullRequestEvent{
+			PullRequestID: pr.ID,
+			Type:          domain.PullRequestEventCreated,
+			Reason:        string(pr.Status),
+		}); err != nil {
+			return err
+		}
 
 		// Создаем записи связи PR с ревьюверами в таблице reviewers
-		for _, reviewerID := range reviewers {
-			if err := tx.PullRequestRepo().AssignReviewer(ctx, pr.ID, reviewerID); err != nil {
+		if err := assignReviewers(ctx, tx, pr.ID, reviewers); err != nil {
+			return err
+		}
+		if explanation != nil {
+			if err := recordExplanation(ctx, tx, pr.ID, domain.SelectionTriggerCreated, explanation); err != nil {
 				return err
 			}
 		}
+		if err := s.recordOutboxEvent(ctx, tx, domain.WebhookEventPullRequestCreated, newEventPullRequest(pr)); err != nil {
+			return err
+		}
 
 		log.Info().
 			Str("request_id", requestID).
 			Str("layer", "service").
 			Str("pull_request_id", pr.ID).
+			Str("status", string(pr.Status)).
 			Int("reviewers_count", len(reviewers)).
 			Msg("successfully created pull request with reviewers in transaction")
 
-		return nil
+		return finishTx(input.DryRun)
 	})
 
-	if err != nil {
+	if err := dryRunRolledBack(err); err != nil {
 		return nil, s.formatError(outerCtx, op, err)
 	}
 
+	if input.DryRun {
+		log.Info().
+			Str("request_id", requestID).
+			Str("layer", "service").
+			Str("pull_request_id", pr.ID).
+			Msg("dry run: pull request creation rolled back")
+		return pr, nil
+	}
+
 	// Увеличиваем счетчики метрик
 	metrics.PRCreatedTotal.Inc()
-	metrics.PROpenCount.Inc()
+	if pr.Status == domain.PullRequestStatusOpen {
+		metrics.PROpenCount.Inc()
+	}
 
 	return pr, nil
 }
@@ -157,8 +201,34 @@ func (s *Service) MergePullRequest(outerCtx context.Context, input *domain.Merge
 			return nil
 		}
 
+		// Смержить можно только открытый PR
+		if existingPR.Status != domain.PullRequestStatusOpen {
+			return domain.ErrInvalidTransition
+		}
+
+		// Проверяем одобрения ревьюверов; администратор может смержить в обход проверки
+		approved, err := s.isApproved(ctx, tx, existingPR)
+		if err != nil {
+			return err
+		}
+		if !approved {
+			if !input.Force {
+				return domain.ErrNotApproved
+			}
+
+			log.Warn().
+				Str("request_id", requestID).
+				Str("layer", "service").
+				Str("pull_request_id", input.PullRequestID).
+				Msg("force merging pull request without required approvals")
+		}
+
+		// Мерж считается принудительным, только если force действительно обошёл проверку одобрений
+		forced := !approved && input.Force
+
 		// Обновляем статус на MERGED
 		existingPR.Status = domain.PullRequestStatusMerged
+		existingPR.MergeForced = forced
 		now := time.Now()
 		existingPR.MergedAt = &now
 
@@ -166,6 +236,20 @@ func (s *Service) MergePullRequest(outerCtx context.Context, input *domain.Merge
 			return err
 		}
 
+		mergeEvent := domain.PullRequestEvent{
+			PullRequestID: existingPR.ID,
+			Type:          domain.PullRequestEventMerged,
+		}
+		if forced {
+			mergeEvent.Reason = domain.EventReasonForced
+		}
+		if err := recordEvent(ctx, tx, mergeEvent); err != nil {
+			return err
+		}
+		if err := s.recordOutboxEvent(ctx, tx, domain.WebhookEventPullRequestMerged, newEventPullRequest(existingPR)); err != nil {
+			return err
+		}
+
 		pr = existingPR
 		return nil
 	})
@@ -216,6 +300,9 @@ func (s *Service) ReassignPullRequest(outerCtx context.Context, input *domain.Re
 		if pr.Status == domain.PullRequestStatusMerged {
 			return domain.ErrReassignOnMerged
 		}
+		if pr.Status != domain.PullRequestStatusOpen {
+			return domain.ErrPullRequestNotOpen
+		}
 
 		// Проверяем что указанный пользователь действительно является ревьювером этого PR
 		isReviewer := false
@@ -229,79 +316,60 @@ func (s *Service) ReassignPullRequest(outerCtx context.Context, input *domain.Re
 			return domain.ErrReviewerMissing
 		}
 
-		// Получаем активных членов команды заменяемого ревьювера
-		activeUsers, err := tx.UserRepo().GetActiveTeamMembers(ctx, input.OldUserID)
+		// Выбираем нового ревьювера по стратегии команды заменяемого, при нехватке - из резервных команд
+		replacement, err := s.findReplacement(ctx, tx, pr, input.OldUserID, domain.SelectionTriggerManual)
 		if err != nil {
 			return err
 		}
-
-		// Формируем список кандидатов, исключая автора PR и текущих ревьюверов
-		excludeMap := make(map[string]bool)
-		excludeMap[pr.AuthorID] = true
-		for _, r := range pr.AssignedReviewers {
-			excludeMap[r] = true
-		}
-
-		candidates := make([]domain.User, 0)
-		for _, user := range activeUsers {
-			if !excludeMap[user.UserID] {
-				candidates = append(candidates, user)
-			}
-		}
-
-		if len(candidates) == 0 {
+		if replacement == nil {
 			metrics.UserNoCandidatesErrors.Inc()
 			return domain.ErrNoCandidate
 		}
-
-		// Случайно выбираем нового ревьювера из списка кандидатов
-		index, err := secureRandomInt(len(candidates))
-		if err != nil {
-			return err
-		}
-		newReviewer := candidates[index].UserID
+		newReviewer := replacement.UserID
 
 		log.Info().
 			Str("request_id", requestID).
 			Str("layer", "service").
 			Str("pull_request_id", input.PullRequestID).
 			Str("new_reviewer_id", newReviewer).
+			Str("new_reviewer_team", replacement.TeamName).
 			Msg("selected new reviewer")
 
 		// Удаляем старого ревьювера и добавляем нового
-		if err := tx.PullRequestRepo().UnassignReviewer(ctx, input.PullRequestID, input.OldUserID); err != nil {
-			return err
-		}
-
-		if err := tx.PullRequestRepo().AssignReviewer(ctx, input.PullRequestID, newReviewer); err != nil {
+		if err := replaceReviewer(ctx, tx, input.PullRequestID, input.OldUserID, newReviewer, domain.EventReasonManual); err != nil {
 			return err
 		}
 
 		// Обновляем список ревьюверов в памяти для ответа
-		newReviewers := make([]string, 0, len(pr.AssignedReviewers))
-		for _, r := range pr.AssignedReviewers {
-			if r == input.OldUserID {
-				newReviewers = append(newReviewers, newReviewer)
-			} else {
-				newReviewers = append(newReviewers, r)
-			}
-		}
-		pr.AssignedReviewers = newReviewers
+		pr.AssignedReviewers = replaceReviewerID(pr.AssignedReviewers, input.OldUserID, newReviewer)
 
 		result = &domain.ReassignPullRequestResult{
-			PullRequest: *pr,
-			ReplacedBy:  newReviewer,
+			PullRequest:    *pr,
+			ReplacedBy:     newReviewer,
+			ReplacedByTeam: replacement.TeamName,
 		}
 
-		return nil
+		if err := s.recordOutboxEvent(ctx, tx, domain.WebhookEventReviewerReassigned, eventReassignment{
+			PullRequestID:   pr.ID,
+			OldReviewerID:   input.OldUserID,
+			NewReviewerID:   newReviewer,
+			NewReviewerTeam: replacement.TeamName,
+			Reason:          domain.EventReasonManual,
+		}); err != nil {
+			return err
+		}
+
+		return finishTx(input.DryRun)
 	})
 
-	if err != nil {
+	if err := dryRunRolledBack(err); err != nil {
 		return nil, s.formatError(outerCtx, op, err)
 	}
 
 	// Увеличиваем счетчик переназначений
-	metrics.PRReassignedTotal.Inc()
+	if !input.DryRun {
+		metrics.PRReassignedTotal.Inc()
+	}
 
 	log.Info().
 		Str("request_id", requestID).
@@ -309,6 +377,7 @@ func (s *Service) ReassignPullRequest(outerCtx context.Context, input *domain.Re
 		Str("pull_request_id", result.PullRequest.ID).
 		Str("old_reviewer_id", input.OldUserID).
 		Str("new_reviewer_id", result.ReplacedBy).
+		Bool("dry_run", input.DryRun).
 		Msg("successfully reassigned reviewer")
 
 	return result, nil
@@ -342,6 +411,9 @@ func (s *Service) ReassignInactiveReviewers(outerCtx context.Context, input *dom
 		if pr.Status == domain.PullRequestStatusMerged {
 			return domain.ErrReassignOnMerged
 		}
+		if pr.Status != domain.PullRequestStatusOpen {
+			return domain.ErrPullRequestNotOpen
+		}
 
 		// Получаем список неактивных ревьюверов
 		inactiveReviewers, err := tx.PullRequestRepo().GetInactiveReviewers(ctx, input.PullRequestID)
@@ -355,117 +427,84 @@ func (s *Service) ReassignInactiveReviewers(outerCtx context.Context, input *dom
 				PullRequestID:       input.PullRequestID,
 				ReassignmentDetails: []domain.ReviewerReassignment{},
 			}
-			return nil
+			return finishTx(input.DryRun)
 		}
 
-		log.Info().
-			Str("request_id", requestID).
-			Str("pull_request_id", input.PullRequestID).
-			Int("inactive_count", len(inactiveReviewers)).
-			Any("inactive_reviewers", inactiveReviewers).
-			Msg("found inactive reviewers")
-
-		reassignments := make([]domain.ReviewerReassignment, 0, len(inactiveReviewers))
-
-		// Для каждого неактивного ревьювера пытаемся найти замену
-		for _, oldReviewerID := range inactiveReviewers {
-			// Получаем активных членов команды заменяемого ревьювера
-			activeUsers, err := tx.UserRepo().GetActiveTeamMembers(ctx, oldReviewerID)
-			if err != nil {
-				return err
-			}
-
-			// Формируем список кандидатов, исключая автора PR и текущих ревьюверов
-			excludeMap := make(map[string]bool)
-			excludeMap[pr.AuthorID] = true
-			for _, r := range pr.AssignedReviewers {
-				excludeMap[r] = true
-			}
+		reassignments, err := s.reassignInactiveOnPR(ctx, tx, pr, inactiveReviewers)
+		if err != nil {
+			return err
+		}
 
-			candidates := make([]domain.User, 0)
-			for _, user := range activeUsers {
-				if !excludeMap[user.UserID] {
-					candidates = append(candidates, user)
-				}
-			}
+		result = &domain.ReassignInactiveResult{
+			PullRequestID:       input.PullRequestID,
+			ReassignmentDetails: reassignments,
+		}
+		if err := s.recordReassignments(ctx, tx, []domain.ReassignInactiveResult{*result}); err != nil {
+			return err
+		}
 
-			if len(candidates) == 0 {
-				// Нет кандидатов - просто удаляем ревьювера
-				if err := tx.PullRequestRepo().UnassignReviewer(ctx, input.PullRequestID, oldReviewerID); err != nil {
-					return err
-				}
+		return finishTx(input.DryRun)
+	})
 
-				reassignments = append(reassignments, domain.ReviewerReassignment{
-					OldReviewerID: oldReviewerID,
-					NewReviewerID: "",
-					WasRemoved:    true,
-				})
-
-				// Удаляем из списка ревьюверов в памяти
-				newReviewers := make([]string, 0, len(pr.AssignedReviewers)-1)
-				for _, r := range pr.AssignedReviewers {
-					if r != oldReviewerID {
-						newReviewers = append(newReviewers, r)
-					}
-				}
-				pr.AssignedReviewers = newReviewers
+	if err := dryRunRolledBack(err); err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
 
-				log.Info().
-					Str("request_id", requestID).
-					Str("pull_request_id", input.PullRequestID).
-					Str("old_reviewer_id", oldReviewerID).
-					Msg("removed inactive reviewer (no candidates)")
+	if !input.DryRun {
+		observeReassignments(*result)
+	}
 
-				continue
-			}
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", result.PullRequestID).
+		Bool("dry_run", input.DryRun).
+		Int("reassigned_count", len(result.ReassignmentDetails)).
+		Msg("successfully reassigned all inactive reviewers")
 
-			// Случайно выбираем нового ревьювера
-			index, err := secureRandomInt(len(candidates))
-			if err != nil {
-				return err
-			}
-			newReviewer := candidates[index].UserID
+	return result, nil
+}
 
-			// Удаляем старого и назначаем нового
-			if err := tx.PullRequestRepo().UnassignReviewer(ctx, input.PullRequestID, oldReviewerID); err != nil {
-				return err
-			}
+// GetPullRequest возвращает PR с данными автора и назначенных ревьюверов
+func (s *Service) GetPullRequest(outerCtx context.Context, pullRequestID string) (*domain.PullRequestDetails, error) {
+	const op = "service.GetPullRequest"
+	requestID := logger.GetRequestID(outerCtx)
+	var details *domain.PullRequestDetails
 
-			if err := tx.PullRequestRepo().AssignReviewer(ctx, input.PullRequestID, newReviewer); err != nil {
-				return err
-			}
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("get_pull_request").Observe(time.Since(start).Seconds())
+	}()
 
-			reassignments = append(reassignments, domain.ReviewerReassignment{
-				OldReviewerID: oldReviewerID,
-				NewReviewerID: newReviewer,
-				WasRemoved:    false,
-			})
-
-			// Обновляем список ревьюверов в памяти
-			newReviewers := make([]string, 0, len(pr.AssignedReviewers))
-			for _, r := range pr.AssignedReviewers {
-				if r == oldReviewerID {
-					newReviewers = append(newReviewers, newReviewer)
-				} else {
-					newReviewers = append(newReviewers, r)
-				}
-			}
-			pr.AssignedReviewers = newReviewers
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", pullRequestID).
+		Msg("getting pull request")
 
-			// Добавляем нового ревьювера в excludeMap для следующих итераций
-			excludeMap[newReviewer] = true
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		pr, err := tx.PullRequestRepo().GetByID(ctx, pullRequestID)
+		if err != nil {
+			return err
+		}
 
-			log.Info().
-				Str("request_id", requestID).
-				Str("pull_request_id", input.PullRequestID).
-				Str("old_reviewer_id", oldReviewerID).
-				Str("new_reviewer_id", newReviewer).
-				Msg("reassigned inactive reviewer")
+		// Автора и ревьюверов загружаем одним запросом
+		users, err := tx.UserRepo().GetByIDs(ctx, append([]string{pr.AuthorID}, pr.AssignedReviewers...))
+		if err != nil {
+			return err
+		}
+		byID := make(map[string]domain.User, len(users))
+		for _, u := range users {
+			byID[u.UserID] = u
 		}
 
-		result = &domain.ReassignInactiveResult{
-			PullRequestID:       input.PullRequestID,
-			ReassignmentDetails: reassignments,
+		details = &domain.PullRequestDetails{
+			PullRequest: *pr,
+			Author:      userOrID(byID, pr.AuthorID),
+			Reviewers:   make([]domain.User, len(pr.AssignedReviewers)),
+		}
+		for i, reviewerID := range pr.AssignedReviewers {
+			details.Reviewers[i] = userOrID(byID, reviewerID)
 		}
 
 		return nil
@@ -475,21 +514,119 @@ func (s *Service) ReassignInactiveReviewers(outerCtx context.Context, input *dom
 		return nil, s.formatError(outerCtx, op, err)
 	}
 
-	// Обновляем метрики
-	for _, detail := range result.ReassignmentDetails {
-		if detail.WasRemoved {
-			metrics.UserNoCandidatesErrors.Inc()
-		} else {
-			metrics.PRReassignedTotal.Inc()
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", pullRequestID).
+		Str("status", string(details.Status)).
+		Msg("successfully retrieved pull request")
+
+	return details, nil
+}
+
+// userOrID возвращает пользователя из byID или заглушку только с ID, если пользователь не найден
+func userOrID(byID map[string]domain.User, userID string) domain.User {
+	if u, ok := byID[userID]; ok {
+		return u
+	}
+	return domain.User{UserID: userID}
+}
+
+// ListPullRequests возвращает страницу PR по фильтрам с сортировкой и курсорной пагинацией
+func (s *Service) ListPullRequests(outerCtx context.Context, input *domain.ListPullRequestsInput) (*domain.PullRequestPage, error) {
+	const op = "service.ListPullRequests"
+	requestID := logger.GetRequestID(outerCtx)
+	var page *domain.PullRequestPage
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("list_pull_requests").Observe(time.Since(start).Seconds())
+	}()
+
+	filter := input.PullRequestFilter
+	if filter.SortBy == "" {
+		filter.SortBy = domain.PullRequestSortCreatedAt
+	}
+	if err := validatePullRequestFilter(filter); err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	limit, err := pageLimit(input.Limit)
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	after, err := decodeCursor(input.Cursor)
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("sort_by", string(filter.SortBy)).
+		Bool("descending", filter.Descending).
+		Int("limit", limit).
+		Msg("listing pull requests")
+
+	err = s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		// Запрашиваем на один PR больше, чтобы узнать, есть ли следующая страница
+		prs, err := tx.PullRequestRepo().List(ctx, filter, after, limit+1)
+		if err != nil {
+			return err
 		}
+
+		page = &domain.PullRequestPage{PullRequests: prs}
+		if len(prs) > limit {
+			page.PullRequests = prs[:limit]
+			last := page.PullRequests[limit-1]
+			page.NextCursor = encodeCursor(pullRequestSortValue(last, filter.SortBy), last.ID)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
 	}
 
 	log.Info().
 		Str("request_id", requestID).
 		Str("layer", "service").
-		Str("pull_request_id", result.PullRequestID).
-		Int("reassigned_count", len(result.ReassignmentDetails)).
-		Msg("successfully reassigned all inactive reviewers")
+		Int("pr_count", len(page.PullRequests)).
+		Bool("has_more", page.NextCursor != "").
+		Msg("successfully listed pull requests")
 
-	return result, nil
+	return page, nil
+}
+
+// validatePullRequestFilter проверяет статусы и поле сортировки списка PR
+func validatePullRequestFilter(filter domain.PullRequestFilter) error {
+	for _, status := range filter.Statuses {
+		switch status {
+		case domain.PullRequestStatusDraft, domain.PullRequestStatusOpen,
+			domain.PullRequestStatusMerged, domain.PullRequestStatusClosed:
+		default:
+			return domain.ErrInvalidInput
+		}
+	}
+
+	switch filter.SortBy {
+	case domain.PullRequestSortCreatedAt, domain.PullRequestSortMergedAt:
+	default:
+		return domain.ErrInvalidInput
+	}
+
+	return nil
+}
+
+// pullRequestSortValue возвращает значение поля сортировки PR для курсора
+func pullRequestSortValue(pr domain.PullRequest, sortBy domain.PullRequestSortField) time.Time {
+	if sortBy == domain.PullRequestSortMergedAt && pr.MergedAt != nil {
+		return *pr.MergedAt
+	}
+	if pr.CreatedAt != nil {
+		return *pr.CreatedAt
+	}
+	return time.Time{}
 }
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// reviewerPool возвращает настройки и кандидатов, из которых выбираются ревьюверы PR.
+// Если репозиторий PR зарегистрирован с командами-владельцами, используются настройки и участники
+// первой из них, а остальные команды-владельцы добирают недостающих раньше её резервных команд.
+// Иначе - команда, от имени которой создан PR. Третье значение - репозиторий, команды которого использовались
+// (пусто - команда PR).
+func (s *Service) reviewerPool(ctx context.Context, tx storage.Tx, pr *domain.PullRequest) (*domain.TeamSettings, []domain.User, string, error) {
+	if pr.Repository != "" {
+		repository, err := tx.RepositoryRepo().Get(ctx, pr.Repository)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, "", err
+		}
+
+		if repository != nil && len(repository.OwnerTeams) > 0 {
+			ownerTeam := repository.OwnerTeams[0]
+
+			settings, err := s.teamSettings(ctx, tx, ownerTeam)
+			if err != nil {
+				return nil, nil, "", err
+			}
+			settings.FallbackTeams = ownerFallbackTeams(repository.OwnerTeams, settings.FallbackTeams)
+
+			members, err := tx.UserRepo().GetActiveByTeam(ctx, ownerTeam)
+			if err != nil {
+				return nil, nil, "", err
+			}
+
+			log.Info().
+				Str("request_id", logger.GetRequestID(ctx)).
+				Str("layer", "service").
+				Str("pull_request_id", pr.ID).
+				Str("repository", repository.Name).
+				Strs("owner_teams", repository.OwnerTeams).
+				Msg("selecting reviewers from repository owner teams")
+
+			return settings, members, repository.Name, nil
+		}
+	}
+
+	settings, err := s.teamSettings(ctx, tx, pr.TeamName)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	// Активные участники команды PR (исключая автора)
+	members, err := tx.UserRepo().GetActiveTeamMembers(ctx, pr.TeamName, pr.AuthorID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return settings, members, "", nil
+}
+
+// ownerFallbackTeams возвращает резервные команды для первой команды-владельца:
+// сначала остальные команды-владельцы, затем её собственные резервные команды без повторов
+func ownerFallbackTeams(ownerTeams, fallbackTeams []string) []string {
+	seen := map[string]bool{ownerTeams[0]: true}
+	result := make([]string, 0, len(ownerTeams)+len(fallbackTeams)-1)
+	for _, teams := range [][]string{ownerTeams[1:], fallbackTeams} {
+		for _, teamName := range teams {
+			if !seen[teamName] {
+				seen[teamName] = true
+				result = append(result, teamName)
+			}
+		}
+	}
+	return result
+}
+
+// SetRepository регистрирует репозиторий или заменяет список его команд-владельцев.
+// Команды-владельцы должны существовать и не повторяться.
+func (s *Service) SetRepository(outerCtx context.Context, input *domain.SetRepositoryInput) (*domain.Repository, error) {
+	const op = "service.SetRepository"
+	requestID := logger.GetRequestID(outerCtx)
+	var repository *domain.Repository
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("set_repository").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("repository", input.Name).
+		Strs("owner_teams", input.OwnerTeams).
+		Msg("setting repository")
+
+	if input.Name == "" {
+		return nil, s.formatError(outerCtx, op, domain.ErrInvalidInput)
+	}
+	seen := make(map[string]bool, len(input.OwnerTeams))
+	for _, teamName := range input.OwnerTeams {
+		if teamName == "" || seen[teamName] {
+			return nil, s.formatError(outerCtx, op, domain.ErrInvalidInput)
+		}
+		seen[teamName] = true
+	}
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		for _, teamName := range input.OwnerTeams {
+			if _, err := tx.TeamRepo().GetByName(ctx, teamName); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		repo := &domain.Repository{
+			Name:       input.Name,
+			OwnerTeams: append([]string{}, input.OwnerTeams...),
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+
+		// При обновлении сохраняем время регистрации
+		existing, err := tx.RepositoryRepo().Get(ctx, input.Name)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		if existing != nil {
+			repo.CreatedAt = existing.CreatedAt
+		}
+
+		if err := tx.RepositoryRepo().Upsert(ctx, repo); err != nil {
+			return err
+		}
+
+		repository = repo
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("repository", repository.Name).
+		Strs("owner_teams", repository.OwnerTeams).
+		Msg("successfully set repository")
+
+	return repository, nil
+}
+
+// GetRepository возвращает зарегистрированный репозиторий с командами-владельцами
+func (s *Service) GetRepository(outerCtx context.Context, name string) (*domain.Repository, error) {
+	const op = "service.GetRepository"
+	requestID := logger.GetRequestID(outerCtx)
+	var repository *domain.Repository
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("get_repository").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("repository", name).
+		Msg("getting repository")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		var err error
+		repository, err = tx.RepositoryRepo().Get(ctx, name)
+		return err
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	return repository, nil
+}
+
+// ListRepositories возвращает зарегистрированные репозитории, отсортированные по имени
+func (s *Service) ListRepositories(outerCtx context.Context) ([]domain.Repository, error) {
+	const op = "service.ListRepositories"
+	requestID := logger.GetRequestID(outerCtx)
+	var repositories []domain.Repository
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("list_repositories").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Msg("listing repositories")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		var err error
+		repositories, err = tx.RepositoryRepo().List(ctx)
+		return err
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	return repositories, nil
+}
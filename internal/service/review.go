@@ -0,0 +1,157 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SubmitReview сохраняет решение назначенного ревьювера по PR.
+// Повторное решение заменяет предыдущее; PENDING выставляется только при назначении.
+func (s *Service) SubmitReview(outerCtx context.Context, input *domain.SubmitReviewInput) (*domain.PullRequest, error) {
+	const op = "service.SubmitReview"
+	requestID := logger.GetRequestID(outerCtx)
+	var pr *domain.PullRequest
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("submit_review").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", input.PullRequestID).
+		Str("reviewer_id", input.ReviewerID).
+		Str("state", string(input.State)).
+		Msg("submitting review")
+
+	switch input.State {
+	case domain.ReviewStateApproved, domain.ReviewStateChangesRequested, domain.ReviewStateDismissed:
+	default:
+		return nil, domain.ErrInvalidInput
+	}
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		existingPR, err := tx.PullRequestRepo().GetByID(ctx, input.PullRequestID)
+		if err != nil {
+			return err
+		}
+
+		if existingPR.Status == domain.PullRequestStatusMerged {
+			return domain.ErrReviewOnMerged
+		}
+		if existingPR.Status != domain.PullRequestStatusOpen {
+			return domain.ErrPullRequestNotOpen
+		}
+
+		// Решение может оставить только назначенный ревьювер
+		if !containsReviewer(existingPR.AssignedReviewers, input.ReviewerID) {
+			return domain.ErrReviewerMissing
+		}
+
+		now := time.Now()
+		if err := tx.PullRequestRepo().SetReviewState(ctx, existingPR.ID, input.ReviewerID, input.State, now); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, domain.PullRequestEvent{
+			PullRequestID: existingPR.ID,
+			Type:          domain.PullRequestEventReviewSubmitted,
+			ActorID:       input.ReviewerID,
+			Reason:        string(input.State),
+		}); err != nil {
+			return err
+		}
+
+		existingPR.ReviewerStates = setReviewerState(existingPR.ReviewerStates, domain.ReviewerState{
+			ReviewerID: input.ReviewerID,
+			State:      input.State,
+			UpdatedAt:  now,
+		})
+
+		pr = existingPR
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	metrics.PRReviewsSubmittedTotal.WithLabelValues(string(input.State)).Inc()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("pull_request_id", pr.ID).
+		Str("reviewer_id", input.ReviewerID).
+		Str("state", string(input.State)).
+		Msg("successfully submitted review")
+
+	return pr, nil
+}
+
+// containsReviewer проверяет, назначен ли пользователь ревьювером
+func containsReviewer(reviewers []string, userID string) bool {
+	for _, r := range reviewers {
+		if r == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// setReviewerState возвращает состояния ревьюверов, в которых решение state.ReviewerID заменено на state
+func setReviewerState(states []domain.ReviewerState, state domain.ReviewerState) []domain.ReviewerState {
+	result := make([]domain.ReviewerState, 0, len(states)+1)
+	replaced := false
+	for _, current := range states {
+		if current.ReviewerID == state.ReviewerID {
+			current = state
+			replaced = true
+		}
+		result = append(result, current)
+	}
+	if !replaced {
+		result = append(result, state)
+	}
+	return result
+}
+
+// isApproved проверяет политику ревью команды PR: у PR есть не меньше RequiredApprovals
+// одобрений и ни один назначенный ревьювер не запросил изменения
+func (s *Service) isApproved(ctx context.Context, tx storage.Tx, pr *domain.PullRequest) (bool, error) {
+	approvals := 0
+	for _, state := range pr.ReviewerStates {
+		if !containsReviewer(pr.AssignedReviewers, state.ReviewerID) {
+			continue
+		}
+		switch state.State {
+		case domain.ReviewStateChangesRequested:
+			return false, nil
+		case domain.ReviewStateApproved:
+			approvals++
+		}
+	}
+
+	// Политика команды PR; у PR, созданных до появления команды PR, - основной команды автора
+	teamName := pr.TeamName
+	if teamName == "" {
+		author, err := tx.UserRepo().GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return false, err
+		}
+		teamName = author.TeamName
+	}
+
+	settings, err := s.teamSettings(ctx, tx, teamName)
+	if err != nil {
+		return false, err
+	}
+
+	return approvals >= settings.RequiredApprovals, nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+)
+
+// selectionTxKey - ключ контекста с изменениями состояния селекторов текущей транзакции
+type selectionTxKey struct{}
+
+// selectionTx накапливает изменения состояния селекторов в рамках одной транзакции.
+// Внутри транзакции селекторы видят свои изменения, а в общее состояние они попадают только после
+// коммита: пробный запуск и откатившаяся транзакция не сдвигают очередь выбора.
+type selectionTx struct {
+	cursors map[*RoundRobinSelector]map[string]string // селектор -> team_name -> последний выбранный
+}
+
+// selectionTxFrom возвращает изменения селекторов транзакции из контекста (nil - вне транзакции сервиса)
+func selectionTxFrom(ctx context.Context) *selectionTx {
+	stx, _ := ctx.Value(selectionTxKey{}).(*selectionTx)
+	return stx
+}
+
+// commit применяет накопленные изменения к селекторам
+func (t *selectionTx) commit() {
+	for selector, cursors := range t.cursors {
+		selector.advance(cursors)
+	}
+}
+
+// selectionTxManager оборачивает TxManager и применяет изменения селекторов только после коммита
+type selectionTxManager struct {
+	storage.TxManager
+}
+
+// Do выполняет fn в транзакции и после успешного коммита применяет изменения селекторов
+func (m selectionTxManager) Do(ctx context.Context, fn func(ctx context.Context, tx storage.Tx) error) error {
+	// Вложенный вызов: изменения применит внешняя транзакция
+	if selectionTxFrom(ctx) != nil {
+		return m.TxManager.Do(ctx, fn)
+	}
+
+	stx := &selectionTx{cursors: make(map[*RoundRobinSelector]map[string]string)}
+	if err := m.TxManager.Do(context.WithValue(ctx, selectionTxKey{}, stx), fn); err != nil {
+		return err
+	}
+
+	stx.commit()
+	return nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"context"
+	"sort"
+	"sync"
+)
+
+// ReviewerSelector выбирает ревьюверов из заранее отфильтрованного списка кандидатов
+type ReviewerSelector interface {
+	// Select возвращает не более req.Count кандидатов в порядке выбора
+	Select(ctx context.Context, req *SelectionRequest) ([]domain.User, error)
+}
+
+// LoadAwareSelector - селектор, которому для выбора нужна текущая нагрузка кандидатов.
+// Для таких селекторов сервис заполняет SelectionRequest.OpenReviews перед вызовом Select.
+type LoadAwareSelector interface {
+	ReviewerSelector
+	UsesReviewLoad() bool
+}
+
+// SelectionRequest - входные данные для выбора ревьюверов
+type SelectionRequest struct {
+	TeamName    string
+	Candidates  []domain.User
+	Count       int
+	OpenReviews map[string]int // количество открытых ревью по user_id кандидатов
+}
+
+// RandomSelector выбирает ревьюверов равновероятно
+type RandomSelector struct{}
+
+// NewRandomSelector создаёт селектор случайного выбора
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{}
+}
+
+// Select выбирает до req.Count случайных кандидатов без повторов
+func (s *RandomSelector) Select(_ context.Context, req *SelectionRequest) ([]domain.User, error) {
+	pool := append([]domain.User(nil), req.Candidates...)
+	selected := make([]domain.User, 0, min(req.Count, len(pool)))
+
+	for len(selected) < req.Count && len(pool) > 0 {
+		index, err := secureRandomInt(len(pool))
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, pool[index])
+		pool = append(pool[:index], pool[index+1:]...)
+	}
+
+	return selected, nil
+}
+
+// RoundRobinSelector выбирает ревьюверов по кругу отдельно для каждой команды.
+// Состояние хранится в памяти процесса: после рестарта очередь начинается заново.
+// В транзакции сервиса очередь сдвигается только после её коммита.
+type RoundRobinSelector struct {
+	mu   sync.Mutex
+	last map[string]string // team_name -> user_id последнего выбранного ревьювера
+}
+
+// NewRoundRobinSelector создаёт селектор с выбором по кругу
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{
+		last: make(map[string]string),
+	}
+}
+
+// Select выбирает req.Count кандидатов, следующих за последним выбранным в команде
+func (s *RoundRobinSelector) Select(ctx context.Context, req *SelectionRequest) ([]domain.User, error) {
+	if len(req.Candidates) == 0 || req.Count <= 0 {
+		return []domain.User{}, nil
+	}
+
+	// Сортируем по user_id, чтобы порядок обхода не зависел от порядка строк из БД
+	pool := append([]domain.User(nil), req.Candidates...)
+	sort.Slice(pool, func(i, j int) bool {
+		return pool[i].UserID < pool[j].UserID
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Начинаем с первого кандидата, идущего после последнего выбранного
+	start := 0
+	if last, ok := s.lastSelected(ctx, req.TeamName); ok {
+		start = sort.Search(len(pool), func(i int) bool {
+			return pool[i].UserID > last
+		}) % len(pool)
+	}
+
+	count := min(req.Count, len(pool))
+	selected := make([]domain.User, 0, count)
+	for i := 0; i < count; i++ {
+		selected = append(selected, pool[(start+i)%len(pool)])
+	}
+	s.setLastSelected(ctx, req.TeamName, selected[len(selected)-1].UserID)
+
+	return selected, nil
+}
+
+// lastSelected возвращает последнего выбранного в команде с учётом выбора текущей транзакции.
+// Вызывается под s.mu.
+func (s *RoundRobinSelector) lastSelected(ctx context.Context, teamName string) (string, bool) {
+	if stx := selectionTxFrom(ctx); stx != nil {
+		if last, ok := stx.cursors[s][teamName]; ok {
+			return last, true
+		}
+	}
+	last, ok := s.last[teamName]
+	return last, ok
+}
+
+// setLastSelected запоминает последнего выбранного: в транзакции сервиса - до её коммита.
+// Вызывается под s.mu.
+func (s *RoundRobinSelector) setLastSelected(ctx context.Context, teamName, userID string) {
+	stx := selectionTxFrom(ctx)
+	if stx == nil {
+		s.last[teamName] = userID
+		return
+	}
+
+	if stx.cursors[s] == nil {
+		stx.cursors[s] = make(map[string]string)
+	}
+	stx.cursors[s][teamName] = userID
+}
+
+// advance применяет закоммиченный выбор транзакции
+func (s *RoundRobinSelector) advance(cursors map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for teamName, userID := range cursors {
+		s.last[teamName] = userID
+	}
+}
+
+// LeastLoadedSelector выбирает кандидатов с наименьшим числом открытых ревью.
+// При равной нагрузке кандидаты выбираются случайно.
+type LeastLoadedSelector struct{}
+
+// NewLeastLoadedSelector создаёт селектор наименее загруженных ревьюверов
+func NewLeastLoadedSelector() *LeastLoadedSelector {
+	return &LeastLoadedSelector{}
+}
+
+// UsesReviewLoad сообщает сервису, что селектору нужна нагрузка кандидатов
+func (s *LeastLoadedSelector) UsesReviewLoad() bool {
+	return true
+}
+
+// Select выбирает до req.Count кандидатов с минимальной нагрузкой
+func (s *LeastLoadedSelector) Select(ctx context.Context, req *SelectionRequest) ([]domain.User, error) {
+	// Случайная перестановка + стабильная сортировка дают случайный tie-break
+	pool, err := NewRandomSelector().Select(ctx, &SelectionRequest{
+		Candidates: req.Candidates,
+		Count:      len(req.Candidates),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(pool, func(i, j int) bool {
+		return req.OpenReviews[pool[i].UserID] < req.OpenReviews[pool[j].UserID]
+	})
+
+	return pool[:min(req.Count, len(pool))], nil
+}
+
+// WeightedSelector выбирает ревьюверов случайно пропорционально их весу.
+// Пользователи без явно заданного веса получают вес 1, пользователи с весом 0 не выбираются.
+type WeightedSelector struct {
+	weights map[string]int
+}
+
+// NewWeightedSelector создаёт селектор взвешенного случайного выбора
+func NewWeightedSelector(weights map[string]int) *WeightedSelector {
+	return &WeightedSelector{
+		weights: weights,
+	}
+}
+
+// weight возвращает вес пользователя
+func (s *WeightedSelector) weight(userID string) int {
+	if w, ok := s.weights[userID]; ok {
+		return max(w, 0)
+	}
+	return 1
+}
+
+// Select выбирает до req.Count кандидатов без повторов с учётом весов
+func (s *WeightedSelector) Select(_ context.Context, req *SelectionRequest) ([]domain.User, error) {
+	pool := make([]domain.User, 0, len(req.Candidates))
+	total := 0
+	for _, candidate := range req.Candidates {
+		if w := s.weight(candidate.UserID); w > 0 {
+			pool = append(pool, candidate)
+			total += w
+		}
+	}
+
+	selected := make([]domain.User, 0, min(req.Count, len(pool)))
+	for len(selected) < req.Count && len(pool) > 0 {
+		point, err := secureRandomInt(total)
+		if err != nil {
+			return nil, err
+		}
+
+		// Находим кандидата, в чей отрезок попала случайная точка
+		index := 0
+		for ; index < len(pool)-1; index++ {
+			w := s.weight(pool[index].UserID)
+			if point < w {
+				break
+			}
+			point -= w
+		}
+
+		total -= s.weight(pool[index].UserID)
+		selected = append(selected, pool[index])
+		pool = append(pool[:index], pool[index+1:]...)
+	}
+
+	return selected, nil
+}
@@ -3,7 +3,11 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/rs/zerolog/log"
+	"net/http"
+	"sort"
+	"time"
 
 	"avitoTechAutumn2025/internal/domain"
 	"avitoTechAutumn2025/internal/storage"
@@ -12,16 +16,122 @@ import (
 // Service реализует domain.AssignmentService используя storage.TxManager
 type Service struct {
 	txmgr storage.TxManager
+
+	selectors       map[domain.SelectionStrategy]ReviewerSelector
+	defaultStrategy domain.SelectionStrategy
+	teamStrategies  map[string]domain.SelectionStrategy
+
+	webhooks *webhookSettings // nil - доставки подписчикам не выполняются
+	outbox   *outboxSettings  // nil - события в outbox не записываются
+
+	externalUsers map[domain.IngestionSource]map[string]string // логин во внешней системе -> user_id
 }
 
 // Проверка что Service реализует интерфейс domain.AssignmentService
 var _ domain.AssignmentService = (*Service)(nil)
 
+// Option настраивает Service при создании
+type Option func(*Service)
+
+// WithDefaultStrategy задаёт глобальную стратегию выбора ревьюверов
+func WithDefaultStrategy(strategy domain.SelectionStrategy) Option {
+	return func(s *Service) {
+		s.defaultStrategy = strategy
+	}
+}
+
+// WithTeamStrategies задаёт стратегии выбора ревьюверов для отдельных команд
+func WithTeamStrategies(strategies map[string]domain.SelectionStrategy) Option {
+	return func(s *Service) {
+		for team, strategy := range strategies {
+			s.teamStrategies[team] = strategy
+		}
+	}
+}
+
+// WithSelector регистрирует (или заменяет) реализацию стратегии выбора ревьюверов
+func WithSelector(strategy domain.SelectionStrategy, selector ReviewerSelector) Option {
+	return func(s *Service) {
+		s.selectors[strategy] = selector
+	}
+}
+
+// WithWebhooks включает доставку подписчикам событий, поставленных в очередь WebhookSink.
+// Неудачная доставка повторяется до maxAttempts раз, задержка перед повтором начинается с backoff
+// и удваивается с каждой попыткой.
+func WithWebhooks(client *http.Client, maxAttempts int, backoff time.Duration) Option {
+	return func(s *Service) {
+		s.webhooks = &webhookSettings{
+			client:       client,
+			maxAttempts:  maxAttempts,
+			retryBackoff: backoff,
+		}
+	}
+}
+
+// WithOutbox включает запись событий в outbox в транзакциях операций и их публикацию в sinks.
+// Если приёмник не принял событие, повторная публикация откладывается на backoff,
+// удваивающийся с каждой неудачной попыткой.
+func WithOutbox(backoff time.Duration, sinks ...EventSink) Option {
+	return func(s *Service) {
+		s.outbox = &outboxSettings{
+			sinks:        sinks,
+			retryBackoff: backoff,
+		}
+	}
+}
+
+// WithExternalUsers задаёт сопоставление логинов внешней системы пользователям сервиса
+func WithExternalUsers(source domain.IngestionSource, logins map[string]string) Option {
+	return func(s *Service) {
+		s.externalUsers[source] = logins
+	}
+}
+
 // New создаёт новый Service с TxManager
-func New(txmgr storage.TxManager) *Service {
-	return &Service{
-		txmgr: txmgr,
+func New(txmgr storage.TxManager, opts ...Option) *Service {
+	s := &Service{
+		// Состояние селекторов меняется только после коммита транзакции
+		txmgr: selectionTxManager{TxManager: txmgr},
+		selectors: map[domain.SelectionStrategy]ReviewerSelector{
+			domain.SelectionStrategyRandom:      NewRandomSelector(),
+			domain.SelectionStrategyRoundRobin:  NewRoundRobinSelector(),
+			domain.SelectionStrategyLeastLoaded: NewLeastLoadedSelector(),
+			domain.SelectionStrategyWeighted:    NewWeightedSelector(nil),
+		},
+		defaultStrategy: domain.SelectionStrategyRandom,
+		teamStrategies:  make(map[string]domain.SelectionStrategy),
+		externalUsers:   make(map[domain.IngestionSource]map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// ValidateStrategies проверяет, что глобальная стратегия и стратегии команд из конфигурации
+// есть среди зарегистрированных селекторов. Вызывается при старте, чтобы опечатка в настройках
+// не превращалась молча в выбор random.
+func (s *Service) ValidateStrategies() error {
+	if _, ok := s.selectors[s.defaultStrategy]; !ok {
+		return fmt.Errorf("unknown default selection strategy %q", s.defaultStrategy)
+	}
+
+	teams := make([]string, 0, len(s.teamStrategies))
+	for team := range s.teamStrategies {
+		teams = append(teams, team)
 	}
+	sort.Strings(teams)
+
+	for _, team := range teams {
+		if _, ok := s.selectors[s.teamStrategies[team]]; !ok {
+			return fmt.Errorf("unknown selection strategy %q for team %q", s.teamStrategies[team], team)
+		}
+	}
+
+	return nil
 }
 
 // formatError преобразует ошибки storage слоя в доменные ошибки с правильными HTTP кодами
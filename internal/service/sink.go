@@ -0,0 +1,132 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/storage"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// EventSink принимает события, опубликованные из outbox.
+// Доставка at-least-once: одно событие может прийти повторно, приёмник различает их по EventID.
+type EventSink interface {
+	// Name возвращает имя приёмника для логов и метрик
+	Name() string
+	// Publish принимает событие; ошибка означает, что событие нужно опубликовать позже ещё раз
+	Publish(ctx context.Context, event domain.OutboxEvent) error
+}
+
+// WebhookSink ставит событие в очередь доставки каждому подходящему подписчику.
+// Повторная публикация события новых доставок не создаёт.
+type WebhookSink struct {
+	txmgr storage.TxManager
+}
+
+// NewWebhookSink создаёт приёмник, наполняющий журнал доставок подписчикам
+func NewWebhookSink(txmgr storage.TxManager) *WebhookSink {
+	return &WebhookSink{txmgr: txmgr}
+}
+
+// Name возвращает имя приёмника
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+// Publish создаёт доставки события подписчикам в одной транзакции
+func (s *WebhookSink) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	return s.txmgr.Do(ctx, func(ctx context.Context, tx storage.Tx) error {
+		subscriptions, err := tx.WebhookRepo().GetSubscriptionsForEvent(ctx, event.EventType)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, subscription := range subscriptions {
+			if err := tx.WebhookRepo().CreateDelivery(ctx, &domain.WebhookDelivery{
+				SubscriptionID: subscription.ID,
+				EventID:        event.EventID,
+				EventType:      event.EventType,
+				Payload:        event.Payload,
+				Status:         domain.WebhookDeliveryPending,
+				NextAttemptAt:  now,
+				CreatedAt:      now,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// LogSink пишет события в лог приложения
+type LogSink struct{}
+
+// NewLogSink создаёт приёмник, логирующий события
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+// Name возвращает имя приёмника
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+// Publish логирует событие вместе с его содержимым
+func (s *LogSink) Publish(_ context.Context, event domain.OutboxEvent) error {
+	log.Info().
+		Str("layer", "outbox").
+		Str("event_id", event.EventID).
+		Str("event_type", string(event.EventType)).
+		RawJSON("payload", event.Payload).
+		Msg("event published")
+	return nil
+}
+
+// MemorySink хранит принятые события в памяти. Предназначен для тестов.
+type MemorySink struct {
+	mu     sync.Mutex
+	events []domain.OutboxEvent
+	err    error
+}
+
+// NewMemorySink создаёт пустой приёмник в памяти
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+// Name возвращает имя приёмника
+func (s *MemorySink) Name() string {
+	return "memory"
+}
+
+// Publish сохраняет событие или возвращает ошибку, заданную через FailWith
+func (s *MemorySink) Publish(_ context.Context, event domain.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+// FailWith заставляет Publish возвращать err (nil - снова принимать события)
+func (s *MemorySink) FailWith(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+// Events возвращает копию принятых событий в порядке публикации
+func (s *MemorySink) Events() []domain.OutboxEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]domain.OutboxEvent(nil), s.events...)
+}
@@ -28,19 +28,19 @@ func (s *Service) CreateTeam(outerCtx context.Context, team *domain.Team) (*doma
 		Int("members_count", len(team.Members)).
 		Msg("creating team")
 
-	// Преобразуем TeamMember в User для передачи в репозиторий
-	users := make([]domain.User, len(team.Members))
+	// Проверяем роли участников (по умолчанию - MEMBER)
+	members := make([]domain.TeamMember, len(team.Members))
 	for i, member := range team.Members {
-		users[i] = domain.User{
-			UserID:   member.UserID,
-			Username: member.Username,
-			TeamName: team.Name,
-			IsActive: member.IsActive,
+		role, ok := normalizeTeamRole(member.Role)
+		if !ok {
+			return nil, s.formatError(outerCtx, op, domain.ErrInvalidInput)
 		}
+		members[i] = member
+		members[i].Role = role
 	}
 
 	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
-		return tx.TeamRepo().Create(ctx, team, users)
+		return tx.TeamRepo().Create(ctx, team, members)
 	})
 
 	if err != nil {
@@ -99,3 +99,191 @@ func (s *Service) GetTeam(outerCtx context.Context, teamName string) (*domain.Te
 
 	return team, nil
 }
+
+// maxReviewerCount - верхняя граница количества ревьюверов в настройках команды
+const maxReviewerCount = 10
+
+// GetTeamSettings возвращает настройки назначения ревьюверов команды
+func (s *Service) GetTeamSettings(outerCtx context.Context, teamName string) (*domain.TeamSettings, error) {
+	const op = "service.GetTeamSettings"
+	requestID := logger.GetRequestID(outerCtx)
+	var settings *domain.TeamSettings
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("get_team_settings").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("team_name", teamName).
+		Msg("fetching team settings")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		// Проверяем существование команды, чтобы не отдавать настройки по умолчанию для несуществующей
+		if _, err := tx.TeamRepo().GetByName(ctx, teamName); err != nil {
+			return err
+		}
+
+		ts, err := s.teamSettings(ctx, tx, teamName)
+		if err != nil {
+			return err
+		}
+		settings = ts
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("team_name", teamName).
+		Msg("successfully fetched team settings")
+
+	return settings, nil
+}
+
+// SetTeamSettings изменяет настройки назначения ревьюверов команды.
+// Незаданные во входных данных поля сохраняют текущее значение.
+func (s *Service) SetTeamSettings(outerCtx context.Context, input *domain.SetTeamSettingsInput) (*domain.TeamSettings, error) {
+	const op = "service.SetTeamSettings"
+	requestID := logger.GetRequestID(outerCtx)
+	var settings *domain.TeamSettings
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("set_team_settings").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("team_name", input.TeamName).
+		Msg("updating team settings")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		if _, err := tx.TeamRepo().GetByName(ctx, input.TeamName); err != nil {
+			return err
+		}
+
+		ts, err := s.teamSettings(ctx, tx, input.TeamName)
+		if err != nil {
+			return err
+		}
+
+		if input.ReviewerCount != nil {
+			ts.ReviewerCount = *input.ReviewerCount
+		}
+		if input.MinReviewers != nil {
+			ts.MinReviewers = *input.MinReviewers
+		}
+		if input.SelectionStrategy != nil {
+			ts.SelectionStrategy = *input.SelectionStrategy
+		}
+		if input.FallbackTeams != nil {
+			ts.FallbackTeams = input.FallbackTeams
+		}
+		if input.RequiredApprovals != nil {
+			ts.RequiredApprovals = *input.RequiredApprovals
+		}
+		if input.MaxOpenReviews != nil {
+			ts.MaxOpenReviews = *input.MaxOpenReviews
+		}
+		if input.CapacityPolicy != nil {
+			ts.CapacityPolicy = *input.CapacityPolicy
+		}
+
+		if err := s.validateTeamSettings(ts); err != nil {
+			return err
+		}
+
+		// Новые резервные команды должны существовать
+		if input.FallbackTeams != nil {
+			for _, fallbackTeam := range input.FallbackTeams {
+				if _, err := tx.TeamRepo().GetByName(ctx, fallbackTeam); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.TeamRepo().UpsertSettings(ctx, ts); err != nil {
+			return err
+		}
+
+		settings = ts
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("team_name", settings.TeamName).
+		Int("reviewer_count", settings.ReviewerCount).
+		Int("min_reviewers", settings.MinReviewers).
+		Str("selection_strategy", string(settings.SelectionStrategy)).
+		Strs("fallback_teams", settings.FallbackTeams).
+		Int("required_approvals", settings.RequiredApprovals).
+		Int("max_open_reviews", settings.MaxOpenReviews).
+		Str("capacity_policy", string(settings.CapacityPolicy)).
+		Msg("successfully updated team settings")
+
+	return settings, nil
+}
+
+// validateTeamSettings проверяет согласованность настроек команды
+func (s *Service) validateTeamSettings(settings *domain.TeamSettings) error {
+	if settings.ReviewerCount < 0 || settings.ReviewerCount > maxReviewerCount {
+		return domain.ErrInvalidInput
+	}
+	if settings.MinReviewers < 0 || settings.MinReviewers > settings.ReviewerCount {
+		return domain.ErrInvalidInput
+	}
+	if settings.SelectionStrategy != "" {
+		if _, ok := s.selectors[settings.SelectionStrategy]; !ok {
+			return domain.ErrInvalidInput
+		}
+	}
+
+	// Одобрений не может требоваться больше, чем назначается ревьюверов
+	if settings.RequiredApprovals < 0 || settings.RequiredApprovals > settings.ReviewerCount {
+		return domain.ErrInvalidInput
+	}
+	if settings.MaxOpenReviews < 0 {
+		return domain.ErrInvalidInput
+	}
+	switch settings.CapacityPolicy {
+	case "", domain.CapacityPolicyReject, domain.CapacityPolicyAssignLeastLoaded:
+	default:
+		return domain.ErrInvalidInput
+	}
+
+	// Резервная команда не может ссылаться на саму себя или повторяться
+	seen := make(map[string]bool, len(settings.FallbackTeams))
+	for _, fallbackTeam := range settings.FallbackTeams {
+		if fallbackTeam == "" || fallbackTeam == settings.TeamName || seen[fallbackTeam] {
+			return domain.ErrInvalidInput
+		}
+		seen[fallbackTeam] = true
+	}
+	return nil
+}
+
+// normalizeTeamRole возвращает роль участника команды (пустая - MEMBER) и false, если роль неизвестна
+func normalizeTeamRole(role domain.TeamRole) (domain.TeamRole, bool) {
+	switch role {
+	case "":
+		return domain.TeamRoleMember, true
+	case domain.TeamRoleMember, domain.TeamRoleMaintainer:
+		return role, true
+	default:
+		return "", false
+	}
+}
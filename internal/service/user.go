@@ -11,11 +11,12 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// SetUserIsActive изменяет статус активности пользователя
-func (s *Service) SetUserIsActive(outerCtx context.Context, userID string, isActive bool) (*domain.User, error) {
+// SetUserIsActive изменяет статус активности пользователя.
+// При деактивации с ReassignOpenReviews его открытые ревью переназначаются в той же транзакции.
+func (s *Service) SetUserIsActive(outerCtx context.Context, input *domain.SetUserActiveInput) (*domain.SetUserActiveResult, error) {
 	const op = "service.SetUserIsActive"
 	requestID := logger.GetRequestID(outerCtx)
-	var user *domain.User
+	var result *domain.SetUserActiveResult
 
 	start := time.Now()
 	defer func() {
@@ -25,23 +26,45 @@ func (s *Service) SetUserIsActive(outerCtx context.Context, userID string, isAct
 	log.Info().
 		Str("request_id", requestID).
 		Str("layer", "service").
-		Str("user_id", userID).
-		Bool("is_active", isActive).
+		Str("user_id", input.UserID).
+		Bool("is_active", input.IsActive).
+		Bool("reassign_open_reviews", input.ReassignOpenReviews).
 		Msg("setting user active status")
 
 	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
-		u, err := tx.UserRepo().GetByID(ctx, userID)
+		u, err := tx.UserRepo().GetByID(ctx, input.UserID)
 		if err != nil {
 			return err
 		}
 
-		u.IsActive = isActive
+		u.IsActive = input.IsActive
 		if err := tx.UserRepo().Update(ctx, u); err != nil {
 			return err
 		}
 
-		user = u
-		return nil
+		result = &domain.SetUserActiveResult{User: *u}
+
+		if input.IsActive {
+			return nil
+		}
+
+		if input.ReassignOpenReviews {
+			reassignments, err := s.reassignOpenReviews(ctx, tx, []string{u.UserID})
+			if err != nil {
+				return err
+			}
+			result.Reassignments = reassignments
+		}
+
+		if err := s.recordOutboxEvent(ctx, tx, domain.WebhookEventUserDeactivated, eventUserDeactivation{
+			UserID:              u.UserID,
+			Username:            u.Username,
+			TeamName:            u.TeamName,
+			ReassignedPullCount: len(result.Reassignments),
+		}); err != nil {
+			return err
+		}
+		return s.recordReassignments(ctx, tx, result.Reassignments)
 	})
 
 	if err != nil {
@@ -50,44 +73,74 @@ func (s *Service) SetUserIsActive(outerCtx context.Context, userID string, isAct
 
 	// Обновляем метрики статуса пользователя
 	status := "inactive"
-	if user.IsActive {
+	if result.User.IsActive {
 		status = "active"
 	}
 	metrics.UserActiveStatusChanged.WithLabelValues(status).Inc()
+	for _, reassignment := range result.Reassignments {
+		observeReassignments(reassignment)
+	}
 
 	log.Info().
 		Str("request_id", requestID).
 		Str("layer", "service").
-		Str("user_id", user.UserID).
-		Bool("is_active", user.IsActive).
+		Str("user_id", result.User.UserID).
+		Bool("is_active", result.User.IsActive).
+		Int("reassigned_pr_count", len(result.Reassignments)).
 		Msg("successfully updated user active status")
 
-	return user, nil
+	return result, nil
 }
 
 // GetReviewerAssignments возвращает список PR, где пользователь назначен ревьювером
-func (s *Service) GetReviewerAssignments(outerCtx context.Context, userID string) ([]domain.PullRequestShort, error) {
+func (s *Service) GetReviewerAssignments(outerCtx context.Context, input *domain.ReviewerAssignmentsInput) (*domain.ReviewerAssignmentsPage, error) {
 	const op = "service.GetReviewerAssignments"
 	requestID := logger.GetRequestID(outerCtx)
-	var prs []domain.PullRequestShort
+	var page *domain.ReviewerAssignmentsPage
 
 	start := time.Now()
 	defer func() {
 		metrics.ServiceOperationDuration.WithLabelValues("get_reviewer_assignments").Observe(time.Since(start).Seconds())
 	}()
 
+	if err := validatePullRequestFilter(domain.PullRequestFilter{
+		Statuses: input.Statuses,
+		SortBy:   domain.PullRequestSortCreatedAt,
+	}); err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	limit, err := pageLimit(input.Limit)
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	after, err := decodeCursor(input.Cursor)
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
 	log.Info().
 		Str("request_id", requestID).
 		Str("layer", "service").
-		Str("user_id", userID).
+		Str("user_id", input.UserID).
+		Int("limit", limit).
 		Msg("fetching PRs reviewed by user")
 
-	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
-		result, err := tx.PullRequestRepo().GetPRsReviewedByUser(ctx, userID)
+	err = s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		// Запрашиваем на один PR больше, чтобы узнать, есть ли следующая страница
+		prs, err := tx.PullRequestRepo().GetPRsReviewedByUser(ctx, input.UserID, input.Statuses, after, limit+1)
 		if err != nil {
 			return err
 		}
-		prs = result
+
+		page = &domain.ReviewerAssignmentsPage{PullRequests: prs}
+		if len(prs) > limit {
+			page.PullRequests = prs[:limit]
+			last := page.PullRequests[limit-1]
+			page.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+		}
+
 		return nil
 	})
 
@@ -100,14 +153,123 @@ func (s *Service) GetReviewerAssignments(outerCtx context.Context, userID string
 	log.Info().
 		Str("request_id", requestID).
 		Str("layer", "service").
-		Str("user_id", userID).
-		Int("pr_count", len(prs)).
+		Str("user_id", input.UserID).
+		Int("pr_count", len(page.PullRequests)).
+		Bool("has_more", page.NextCursor != "").
 		Msg("successfully fetched reviewed PRs")
 
-	return prs, nil
+	return page, nil
+}
+
+// SetUserReviewLimit задаёт личный лимит открытых ревью пользователя (0 - действует лимит команды)
+func (s *Service) SetUserReviewLimit(outerCtx context.Context, userID string, maxOpenReviews int) (*domain.User, error) {
+	const op = "service.SetUserReviewLimit"
+	requestID := logger.GetRequestID(outerCtx)
+	var user *domain.User
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("set_user_review_limit").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("user_id", userID).
+		Int("max_open_reviews", maxOpenReviews).
+		Msg("setting user review limit")
+
+	if maxOpenReviews < 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		u, err := tx.UserRepo().GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		u.MaxOpenReviews = maxOpenReviews
+		if err := tx.UserRepo().Update(ctx, u); err != nil {
+			return err
+		}
+
+		user = u
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("user_id", user.UserID).
+		Int("max_open_reviews", user.MaxOpenReviews).
+		Msg("successfully updated user review limit")
+
+	return user, nil
+}
+
+// GetReviewCapacity возвращает количество открытых ревью пользователя и действующий для него лимит
+func (s *Service) GetReviewCapacity(outerCtx context.Context, userID string) (*domain.ReviewCapacity, error) {
+	const op = "service.GetReviewCapacity"
+	requestID := logger.GetRequestID(outerCtx)
+	var capacity *domain.ReviewCapacity
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("get_review_capacity").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("user_id", userID).
+		Msg("fetching user review capacity")
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		user, err := tx.UserRepo().GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		settings, err := s.teamSettings(ctx, tx, user.TeamName)
+		if err != nil {
+			return err
+		}
+
+		openReviews, err := tx.PullRequestRepo().GetOpenReviewCounts(ctx, []string{userID})
+		if err != nil {
+			return err
+		}
+
+		capacity = &domain.ReviewCapacity{
+			UserID:         userID,
+			OpenReviews:    openReviews[userID],
+			MaxOpenReviews: effectiveReviewLimit(*user, settings),
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("user_id", userID).
+		Int("open_reviews", capacity.OpenReviews).
+		Int("max_open_reviews", capacity.MaxOpenReviews).
+		Msg("successfully fetched user review capacity")
+
+	return capacity, nil
 }
 
-// DeactivateTeamMembers массово деактивирует всех участников команды
+// DeactivateTeamMembers массово деактивирует всех участников команды.
+// С ReassignOpenReviews их открытые ревью переназначаются в той же транзакции.
 func (s *Service) DeactivateTeamMembers(outerCtx context.Context, input *domain.DeactivateTeamInput) (*domain.DeactivateTeamResult, error) {
 	const op = "service.DeactivateTeamMembers"
 	requestID := logger.GetRequestID(outerCtx)
@@ -122,11 +284,12 @@ func (s *Service) DeactivateTeamMembers(outerCtx context.Context, input *domain.
 		Str("request_id", requestID).
 		Str("layer", "service").
 		Str("team_name", input.TeamName).
+		Bool("reassign_open_reviews", input.ReassignOpenReviews).
 		Msg("deactivating all team members")
 
 	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
 		// Проверяем существование команды
-		_, err := tx.TeamRepo().GetByName(ctx, input.TeamName)
+		team, err := tx.TeamRepo().GetByName(ctx, input.TeamName)
 		if err != nil {
 			return err
 		}
@@ -142,21 +305,52 @@ func (s *Service) DeactivateTeamMembers(outerCtx context.Context, input *domain.
 			DeactivatedUserCount: deactivatedCount,
 		}
 
-		return nil
+		if input.ReassignOpenReviews {
+			memberIDs := make([]string, len(team.Members))
+			for i, member := range team.Members {
+				memberIDs[i] = member.UserID
+			}
+
+			reassignments, err := s.reassignOpenReviews(ctx, tx, memberIDs)
+			if err != nil {
+				return err
+			}
+			result.Reassignments = reassignments
+		}
+
+		if err := s.recordOutboxEvent(ctx, tx, domain.WebhookEventTeamDeactivated, eventTeamDeactivation{
+			TeamName:             result.TeamName,
+			DeactivatedUserCount: result.DeactivatedUserCount,
+			ReassignedPullCount:  len(result.Reassignments),
+		}); err != nil {
+			return err
+		}
+		if err := s.recordReassignments(ctx, tx, result.Reassignments); err != nil {
+			return err
+		}
+
+		return finishTx(input.DryRun)
 	})
 
-	if err != nil {
+	if err := dryRunRolledBack(err); err != nil {
 		return nil, s.formatError(outerCtx, op, err)
 	}
 
 	// Обновляем метрики
-	metrics.UserActiveStatusChanged.WithLabelValues("inactive").Add(float64(result.DeactivatedUserCount))
+	if !input.DryRun {
+		metrics.UserActiveStatusChanged.WithLabelValues("inactive").Add(float64(result.DeactivatedUserCount))
+		for _, reassignment := range result.Reassignments {
+			observeReassignments(reassignment)
+		}
+	}
 
 	log.Info().
 		Str("request_id", requestID).
 		Str("layer", "service").
 		Str("team_name", result.TeamName).
 		Int("deactivated_count", result.DeactivatedUserCount).
+		Int("reassigned_pr_count", len(result.Reassignments)).
+		Bool("dry_run", input.DryRun).
 		Msg("successfully deactivated all team members")
 
 	return result, nil
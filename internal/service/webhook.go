@@ -0,0 +1,376 @@
+package service
+
+import (
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+	"avitoTechAutumn2025/internal/metrics"
+	"avitoTechAutumn2025/internal/storage"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxWebhookBackoffShift ограничивает рост задержки между попытками доставки: retryBackoff * 2^10
+const maxWebhookBackoffShift = 10
+
+// webhookSettings - параметры доставки событий подписчикам
+type webhookSettings struct {
+	client       *http.Client
+	maxAttempts  int
+	retryBackoff time.Duration
+}
+
+// Заголовки запроса доставки события
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookSignatureHeader = "X-Webhook-Signature-256" // "sha256=" + hex(HMAC-SHA256(secret, body))
+)
+
+// DeliverWebhooks выполняет все попытки доставки, время которых наступило.
+// Каждая попытка выполняется в отдельной транзакции с блокировкой доставки, поэтому
+// несколько экземпляров сервиса не отправляют одно событие одновременно.
+// Возвращает количество выполненных попыток. Без WithWebhooks ничего не делает.
+func (s *Service) DeliverWebhooks(outerCtx context.Context) (int, error) {
+	const op = "service.DeliverWebhooks"
+
+	if s.webhooks == nil {
+		return 0, nil
+	}
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("deliver_webhooks").Observe(time.Since(start).Seconds())
+	}()
+
+	attempted := 0
+	for {
+		var delivery *domain.WebhookDelivery
+
+		err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+			d, err := tx.WebhookRepo().ClaimDueDelivery(ctx, time.Now())
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			subscription, err := tx.WebhookRepo().GetSubscription(ctx, d.SubscriptionID)
+			if err != nil {
+				return err
+			}
+
+			statusCode, sendErr := s.sendWebhook(ctx, subscription, d)
+			s.recordDeliveryAttempt(d, statusCode, sendErr, time.Now())
+
+			if err := tx.WebhookRepo().UpdateDelivery(ctx, d); err != nil {
+				return err
+			}
+
+			delivery = d
+			return nil
+		})
+
+		if err != nil {
+			return attempted, s.formatError(outerCtx, op, err)
+		}
+
+		// Доставок, готовых к попытке, больше нет
+		if delivery == nil {
+			return attempted, nil
+		}
+
+		attempted++
+		metrics.WebhookDeliveryAttemptsTotal.WithLabelValues(string(delivery.EventType), string(delivery.Status)).Inc()
+
+		logEvent := log.Info()
+		if delivery.Status != domain.WebhookDeliveryDelivered {
+			logEvent = log.Warn()
+		}
+		logEvent.
+			Str("layer", "service").
+			Int64("delivery_id", delivery.ID).
+			Int64("subscription_id", delivery.SubscriptionID).
+			Str("event_type", string(delivery.EventType)).
+			Str("status", string(delivery.Status)).
+			Int("attempts", delivery.Attempts).
+			Int("status_code", delivery.LastStatusCode).
+			Str("error", delivery.LastError).
+			Msg("webhook delivery attempted")
+	}
+}
+
+// sendWebhook отправляет подписанное событие подписчику и возвращает код ответа (0 - ответа нет)
+func (s *Service) sendWebhook(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(delivery.EventType))
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookSignatureHeader, signWebhookPayload(subscription.Secret, delivery.Payload))
+
+	start := time.Now()
+	resp, err := s.webhooks.client.Do(req)
+	metrics.WebhookDeliveryDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Дочитываем тело, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// recordDeliveryAttempt обновляет доставку по итогу попытки: успех, повтор с экспоненциальной задержкой
+// или FAILED, если попытки исчерпаны
+func (s *Service) recordDeliveryAttempt(delivery *domain.WebhookDelivery, statusCode int, sendErr error, now time.Time) {
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+
+	if sendErr == nil {
+		delivery.Status = domain.WebhookDeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	delivery.LastError = sendErr.Error()
+	if delivery.Attempts >= s.webhooks.maxAttempts {
+		delivery.Status = domain.WebhookDeliveryFailed
+		return
+	}
+
+	delivery.NextAttemptAt = now.Add(s.webhooks.retryBackoff << min(delivery.Attempts-1, maxWebhookBackoffShift))
+}
+
+// signWebhookPayload вычисляет подпись тела запроса ключом подписки
+func signWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RunWebhookWorker периодически доставляет события подписчикам. Блокируется до отмены ctx.
+func (s *Service) RunWebhookWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Info().
+		Dur("interval", interval).
+		Msg("webhook worker started")
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.DeliverWebhooks(ctx); err != nil {
+				log.Error().Err(err).Msg("failed to deliver webhooks")
+			}
+		case <-ctx.Done():
+			log.Info().Msg("stopping webhook worker")
+			return
+		}
+	}
+}
+
+// CreateWebhook подписывает внешнюю систему на события сервиса
+func (s *Service) CreateWebhook(outerCtx context.Context, input *domain.CreateWebhookInput) (*domain.WebhookSubscription, error) {
+	const op = "service.CreateWebhook"
+	requestID := logger.GetRequestID(outerCtx)
+	var subscription *domain.WebhookSubscription
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("create_webhook").Observe(time.Since(start).Seconds())
+	}()
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Str("url", input.URL).
+		Msg("creating webhook subscription")
+
+	eventTypes, err := validateWebhookInput(input)
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	err = s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		sub := &domain.WebhookSubscription{
+			URL:        input.URL,
+			Secret:     input.Secret,
+			EventTypes: eventTypes,
+			CreatedAt:  time.Now(),
+		}
+		if err := tx.WebhookRepo().CreateSubscription(ctx, sub); err != nil {
+			return err
+		}
+
+		subscription = sub
+		return nil
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Int64("subscription_id", subscription.ID).
+		Msg("successfully created webhook subscription")
+
+	return subscription, nil
+}
+
+// validateWebhookInput проверяет адрес, ключ и фильтр подписки; возвращает фильтр без повторов
+func validateWebhookInput(input *domain.CreateWebhookInput) ([]domain.WebhookEventType, error) {
+	target, err := url.ParseRequestURI(input.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if input.Secret == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	known := make(map[domain.WebhookEventType]bool, len(domain.WebhookEventTypes))
+	for _, eventType := range domain.WebhookEventTypes {
+		known[eventType] = true
+	}
+
+	seen := make(map[domain.WebhookEventType]bool, len(input.EventTypes))
+	eventTypes := make([]domain.WebhookEventType, 0, len(input.EventTypes))
+	for _, eventType := range input.EventTypes {
+		if !known[eventType] {
+			return nil, domain.ErrInvalidInput
+		}
+		if seen[eventType] {
+			continue
+		}
+		seen[eventType] = true
+		eventTypes = append(eventTypes, eventType)
+	}
+	return eventTypes, nil
+}
+
+// ListWebhooks возвращает все подписки на события
+func (s *Service) ListWebhooks(outerCtx context.Context) ([]domain.WebhookSubscription, error) {
+	const op = "service.ListWebhooks"
+	requestID := logger.GetRequestID(outerCtx)
+	var subscriptions []domain.WebhookSubscription
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("list_webhooks").Observe(time.Since(start).Seconds())
+	}()
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		var err error
+		subscriptions, err = tx.WebhookRepo().ListSubscriptions(ctx)
+		return err
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Int("subscriptions_count", len(subscriptions)).
+		Msg("successfully listed webhook subscriptions")
+
+	return subscriptions, nil
+}
+
+// DeleteWebhook удаляет подписку вместе с журналом её доставок
+func (s *Service) DeleteWebhook(outerCtx context.Context, subscriptionID int64) error {
+	const op = "service.DeleteWebhook"
+	requestID := logger.GetRequestID(outerCtx)
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("delete_webhook").Observe(time.Since(start).Seconds())
+	}()
+
+	err := s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		return tx.WebhookRepo().DeleteSubscription(ctx, subscriptionID)
+	})
+
+	if err != nil {
+		return s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Int64("subscription_id", subscriptionID).
+		Msg("successfully deleted webhook subscription")
+
+	return nil
+}
+
+// GetWebhookDeliveries возвращает последние доставки событий подписчику
+func (s *Service) GetWebhookDeliveries(outerCtx context.Context, input *domain.WebhookDeliveriesInput) ([]domain.WebhookDelivery, error) {
+	const op = "service.GetWebhookDeliveries"
+	requestID := logger.GetRequestID(outerCtx)
+	var deliveries []domain.WebhookDelivery
+
+	start := time.Now()
+	defer func() {
+		metrics.ServiceOperationDuration.WithLabelValues("get_webhook_deliveries").Observe(time.Since(start).Seconds())
+	}()
+
+	switch input.Status {
+	case "", domain.WebhookDeliveryPending, domain.WebhookDeliveryDelivered, domain.WebhookDeliveryFailed:
+	default:
+		return nil, s.formatError(outerCtx, op, domain.ErrInvalidInput)
+	}
+
+	limit, err := pageLimit(input.Limit)
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	err = s.txmgr.Do(outerCtx, func(ctx context.Context, tx storage.Tx) error {
+		// Проверяем существование подписки, чтобы отличить её от подписки без доставок
+		if _, err := tx.WebhookRepo().GetSubscription(ctx, input.SubscriptionID); err != nil {
+			return err
+		}
+
+		var err error
+		deliveries, err = tx.WebhookRepo().GetDeliveries(ctx, input.SubscriptionID, input.Status, limit)
+		return err
+	})
+
+	if err != nil {
+		return nil, s.formatError(outerCtx, op, err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "service").
+		Int64("subscription_id", input.SubscriptionID).
+		Int("deliveries_count", len(deliveries)).
+		Msg("successfully retrieved webhook deliveries")
+
+	return deliveries, nil
+}
@@ -0,0 +1,54 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/storage"
+)
+
+type codeOwnersRepository struct {
+	db *gorm.DB
+}
+
+// NewCodeOwnersRepository создаёт новый репозиторий CODEOWNERS
+func NewCodeOwnersRepository(db *gorm.DB) storage.CodeOwnersRepository {
+	return &codeOwnersRepository{db: db}
+}
+
+// Get получает CODEOWNERS репозитория
+func (r *codeOwnersRepository) Get(ctx context.Context, repository string) (*domain.RepositoryCodeOwners, error) {
+	var dbCodeOwners RepositoryCodeOwners
+	result := r.db.WithContext(ctx).First(&dbCodeOwners, "repository = ?", repository)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &domain.RepositoryCodeOwners{
+		Repository: dbCodeOwners.Repository,
+		Content:    dbCodeOwners.Content,
+		UpdatedAt:  dbCodeOwners.UpdatedAt,
+	}, nil
+}
+
+// Upsert сохраняет CODEOWNERS репозитория, заменяя предыдущий
+func (r *codeOwnersRepository) Upsert(ctx context.Context, codeOwners *domain.RepositoryCodeOwners) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "repository"}},
+			DoUpdates: clause.AssignmentColumns([]string{"content", "updated_at"}),
+		}).
+		Create(&RepositoryCodeOwners{
+			Repository: codeOwners.Repository,
+			Content:    codeOwners.Content,
+			UpdatedAt:  codeOwners.UpdatedAt,
+		}).Error
+}
@@ -0,0 +1,198 @@
+package gorm
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/rs/zerolog/log"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/logger"
+)
+
+// selectionDetails - содержимое столбца details объяснения выбора ревьюверов
+type selectionDetails struct {
+	RequestedCount int                    `json:"requested_count"`
+	Teams          []teamSelectionDetails `json:"teams"`
+	Excluded       []exclusionDetails     `json:"excluded"`
+	OverCapacity   bool                   `json:"over_capacity"`
+	Repository     string                 `json:"repository,omitempty"`
+	CodeOwners     *[]codeOwnersDetails   `json:"code_owners,omitempty"` // nil - CODEOWNERS не применялся
+	Selected       []string               `json:"selected"`
+}
+
+type teamSelectionDetails struct {
+	TeamName   string   `json:"team_name"`
+	Strategy   string   `json:"strategy"`
+	Fallback   bool     `json:"fallback"`
+	CodeOwners bool     `json:"code_owners,omitempty"`
+	Candidates []string `json:"candidates"`
+	Selected   []string `json:"selected"`
+}
+
+type codeOwnersDetails struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+	Paths   []string `json:"paths"`
+}
+
+type exclusionDetails struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+// AddSelectionExplanation сохраняет объяснение выбора ревьюверов
+func (r *pullRequestRepository) AddSelectionExplanation(ctx context.Context, explanation *domain.SelectionExplanation) error {
+	requestID := logger.GetRequestID(ctx)
+
+	details, err := json.Marshal(mapSelectionDetailsToDB(explanation))
+	if err != nil {
+		return err
+	}
+
+	dbExplanation := &SelectionExplanation{
+		PullRequestID:      explanation.PullRequestID,
+		Trigger:            string(explanation.Trigger),
+		ReplacedReviewerID: nullableID(explanation.ReplacedReviewerID),
+		Details:            details,
+		CreatedAt:          explanation.CreatedAt,
+	}
+
+	if err := r.db.WithContext(ctx).Create(dbExplanation).Error; err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Str("pull_request_id", explanation.PullRequestID).
+			Str("trigger", string(explanation.Trigger)).
+			Msg("error adding selection explanation")
+		return err
+	}
+
+	explanation.ID = dbExplanation.ExplanationID
+	return nil
+}
+
+// GetSelectionExplanations получает объяснения выборов ревьюверов PR
+func (r *pullRequestRepository) GetSelectionExplanations(ctx context.Context, prID string) ([]domain.SelectionExplanation, error) {
+	requestID := logger.GetRequestID(ctx)
+
+	var dbExplanations []SelectionExplanation
+	result := r.db.WithContext(ctx).
+		Where("pull_request_id = ?", prID).
+		Order("explanation_id").
+		Find(&dbExplanations)
+
+	if result.Error != nil {
+		log.Error().
+			Err(result.Error).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Str("pull_request_id", prID).
+			Msg("error fetching selection explanations")
+		return nil, result.Error
+	}
+
+	explanations := make([]domain.SelectionExplanation, len(dbExplanations))
+	for i, dbExplanation := range dbExplanations {
+		explanation, err := mapSelectionExplanationToDomain(dbExplanation)
+		if err != nil {
+			return nil, err
+		}
+		explanations[i] = explanation
+	}
+
+	return explanations, nil
+}
+
+func mapSelectionDetailsToDB(explanation *domain.SelectionExplanation) selectionDetails {
+	details := selectionDetails{
+		RequestedCount: explanation.RequestedCount,
+		Teams:          make([]teamSelectionDetails, len(explanation.Teams)),
+		Excluded:       make([]exclusionDetails, len(explanation.Excluded)),
+		OverCapacity:   explanation.OverCapacity,
+		Repository:     explanation.Repository,
+		Selected:       explanation.Selected,
+	}
+	for i, team := range explanation.Teams {
+		details.Teams[i] = teamSelectionDetails{
+			TeamName:   team.TeamName,
+			Strategy:   string(team.Strategy),
+			Fallback:   team.Fallback,
+			CodeOwners: team.CodeOwners,
+			Candidates: team.Candidates,
+			Selected:   team.Selected,
+		}
+	}
+	if explanation.CodeOwners != nil {
+		matches := make([]codeOwnersDetails, len(explanation.CodeOwners))
+		for i, match := range explanation.CodeOwners {
+			matches[i] = codeOwnersDetails{
+				Line:    match.Line,
+				Pattern: match.Pattern,
+				Owners:  match.Owners,
+				Paths:   match.Paths,
+			}
+		}
+		details.CodeOwners = &matches
+	}
+	for i, exclusion := range explanation.Excluded {
+		details.Excluded[i] = exclusionDetails{
+			UserID: exclusion.UserID,
+			Reason: string(exclusion.Reason),
+		}
+	}
+	return details
+}
+
+func mapSelectionExplanationToDomain(dbExplanation SelectionExplanation) (domain.SelectionExplanation, error) {
+	var details selectionDetails
+	if err := json.Unmarshal(dbExplanation.Details, &details); err != nil {
+		return domain.SelectionExplanation{}, err
+	}
+
+	explanation := domain.SelectionExplanation{
+		ID:             dbExplanation.ExplanationID,
+		PullRequestID:  dbExplanation.PullRequestID,
+		Trigger:        domain.SelectionTrigger(dbExplanation.Trigger),
+		RequestedCount: details.RequestedCount,
+		Teams:          make([]domain.TeamSelection, len(details.Teams)),
+		Excluded:       make([]domain.SelectionExclusion, len(details.Excluded)),
+		OverCapacity:   details.OverCapacity,
+		Repository:     details.Repository,
+		Selected:       details.Selected,
+		CreatedAt:      dbExplanation.CreatedAt,
+	}
+	if dbExplanation.ReplacedReviewerID != nil {
+		explanation.ReplacedReviewerID = *dbExplanation.ReplacedReviewerID
+	}
+	for i, team := range details.Teams {
+		explanation.Teams[i] = domain.TeamSelection{
+			TeamName:   team.TeamName,
+			Strategy:   domain.SelectionStrategy(team.Strategy),
+			Fallback:   team.Fallback,
+			CodeOwners: team.CodeOwners,
+			Candidates: team.Candidates,
+			Selected:   team.Selected,
+		}
+	}
+	if details.CodeOwners != nil {
+		explanation.CodeOwners = make([]domain.CodeOwnersMatch, len(*details.CodeOwners))
+		for i, match := range *details.CodeOwners {
+			explanation.CodeOwners[i] = domain.CodeOwnersMatch{
+				Line:    match.Line,
+				Pattern: match.Pattern,
+				Owners:  match.Owners,
+				Paths:   match.Paths,
+			}
+		}
+	}
+	for i, exclusion := range details.Excluded {
+		explanation.Excluded[i] = domain.SelectionExclusion{
+			UserID: exclusion.UserID,
+			Reason: domain.ExclusionReason(exclusion.Reason),
+		}
+	}
+	return explanation, nil
+}
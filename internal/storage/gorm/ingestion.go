@@ -0,0 +1,77 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/storage"
+)
+
+type ingestionRepository struct {
+	db *gorm.DB
+}
+
+// NewIngestionRepository создаёт новый репозиторий доставок из внешних систем
+func NewIngestionRepository(db *gorm.DB) storage.IngestionRepository {
+	return &ingestionRepository{db: db}
+}
+
+// ClaimDelivery занимает доставку вставкой строки PENDING. При конфликте по ключу строка
+// перезанимается, только если она всё ещё PENDING и занята раньше staleBefore.
+func (r *ingestionRepository) ClaimDelivery(ctx context.Context, delivery *domain.IngestedDelivery, staleBefore time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "source"}, {Name: "delivery_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"action", "pull_request_id", "claimed_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{
+					SQL:  "ingested_deliveries.status = ? AND ingested_deliveries.claimed_at < ?",
+					Vars: []interface{}{string(domain.IngestedDeliveryPending), staleBefore},
+				},
+			}},
+		}).
+		Create(&IngestedDelivery{
+			Source:        string(delivery.Source),
+			DeliveryID:    delivery.DeliveryID,
+			Action:        string(delivery.Action),
+			PullRequestID: delivery.PullRequestID,
+			Status:        string(domain.IngestedDeliveryPending),
+			ReceivedAt:    delivery.ReceivedAt,
+			ClaimedAt:     delivery.ClaimedAt,
+		})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// CompleteDelivery отмечает доставку обработанной
+func (r *ingestionRepository) CompleteDelivery(ctx context.Context, source domain.IngestionSource, deliveryID string) error {
+	result := r.db.WithContext(ctx).
+		Model(&IngestedDelivery{}).
+		Where("source = ? AND delivery_id = ?", string(source), deliveryID).
+		Update("status", string(domain.IngestedDeliveryDone))
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// ReleaseDelivery удаляет занятую доставку; обработанная доставка не трогается
+func (r *ingestionRepository) ReleaseDelivery(ctx context.Context, source domain.IngestionSource, deliveryID string) error {
+	return r.db.WithContext(ctx).
+		Where("source = ? AND delivery_id = ? AND status = ?",
+			string(source), deliveryID, string(domain.IngestedDeliveryPending)).
+		Delete(&IngestedDelivery{}).Error
+}
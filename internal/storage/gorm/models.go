@@ -12,38 +12,241 @@ type PullRequest struct {
 	Status          string     `gorm:"column:status;not null;default:OPEN"`
 	CreatedAt       time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP"`
 	MergedAt        *time.Time `gorm:"column:merged_at"`
+	ClosedAt        *time.Time `gorm:"column:closed_at"`
+	MergeForced     bool       `gorm:"column:merge_forced;not null;default:false"`
+	Repository      *string    `gorm:"column:repository"`
+	ChangedFiles    []byte     `gorm:"column:changed_files;type:jsonb;not null;default:'[]'"`
+	CodeOwners      *string    `gorm:"column:codeowners"`
+	TeamName        *string    `gorm:"column:team_name"`
 }
 
 func (PullRequest) TableName() string {
 	return "pull_requests"
 }
 
+// PullRequestEvent - модель БД для события в истории PR
+type PullRequestEvent struct {
+	EventID       int64     `gorm:"column:event_id;primaryKey;autoIncrement"`
+	PullRequestID string    `gorm:"column:pull_request_id;not null"`
+	EventType     string    `gorm:"column:event_type;not null"`
+	Actor         string    `gorm:"column:actor;not null"`
+	OldReviewerID *string   `gorm:"column:old_reviewer_id"`
+	NewReviewerID *string   `gorm:"column:new_reviewer_id"`
+	Reason        string    `gorm:"column:reason;not null"`
+	CreatedAt     time.Time `gorm:"column:created_at;not null"`
+}
+
+func (PullRequestEvent) TableName() string {
+	return "pull_request_events"
+}
+
+// SelectionExplanation - модель БД для объяснения выбора ревьюверов (подробности - JSON в details)
+type SelectionExplanation struct {
+	ExplanationID      int64     `gorm:"column:explanation_id;primaryKey;autoIncrement"`
+	PullRequestID      string    `gorm:"column:pull_request_id;not null"`
+	Trigger            string    `gorm:"column:trigger;not null"`
+	ReplacedReviewerID *string   `gorm:"column:replaced_reviewer_id"`
+	Details            []byte    `gorm:"column:details;type:jsonb;not null"`
+	CreatedAt          time.Time `gorm:"column:created_at;not null"`
+}
+
+func (SelectionExplanation) TableName() string {
+	return "reviewer_selection_explanations"
+}
+
+// WebhookSubscription - модель БД для подписки на события
+type WebhookSubscription struct {
+	SubscriptionID int64     `gorm:"column:subscription_id;primaryKey;autoIncrement"`
+	URL            string    `gorm:"column:url;not null"`
+	Secret         string    `gorm:"column:secret;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookSubscriptionEvent - модель БД для типа события в фильтре подписки (нет строк - все события)
+type WebhookSubscriptionEvent struct {
+	SubscriptionID int64  `gorm:"column:subscription_id;primaryKey"`
+	EventType      string `gorm:"column:event_type;primaryKey"`
+}
+
+func (WebhookSubscriptionEvent) TableName() string {
+	return "webhook_subscription_events"
+}
+
+// WebhookDelivery - модель БД для доставки события подписчику
+type WebhookDelivery struct {
+	DeliveryID     int64      `gorm:"column:delivery_id;primaryKey;autoIncrement"`
+	SubscriptionID int64      `gorm:"column:subscription_id;not null"`
+	EventID        string     `gorm:"column:event_id;not null"`
+	EventType      string     `gorm:"column:event_type;not null"`
+	Payload        []byte     `gorm:"column:payload;type:jsonb;not null"`
+	Status         string     `gorm:"column:status;not null"`
+	Attempts       int        `gorm:"column:attempts;not null"`
+	LastStatusCode *int       `gorm:"column:last_status_code"`
+	LastError      string     `gorm:"column:last_error;not null"`
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at;not null"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// OutboxEvent - модель БД для события в outbox
+type OutboxEvent struct {
+	OutboxID      int64      `gorm:"column:outbox_id;primaryKey;autoIncrement"`
+	EventID       string     `gorm:"column:event_id;not null"`
+	EventType     string     `gorm:"column:event_type;not null"`
+	Payload       []byte     `gorm:"column:payload;type:jsonb;not null"`
+	Attempts      int        `gorm:"column:attempts;not null"`
+	LastError     string     `gorm:"column:last_error;not null"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null"`
+	CreatedAt     time.Time  `gorm:"column:created_at;not null"`
+	PublishedAt   *time.Time `gorm:"column:published_at"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox"
+}
+
+// IngestedDelivery - модель БД для принятой доставки события из внешней системы
+type IngestedDelivery struct {
+	Source        string    `gorm:"column:source;primaryKey"`
+	DeliveryID    string    `gorm:"column:delivery_id;primaryKey"`
+	Action        string    `gorm:"column:action;not null"`
+	PullRequestID string    `gorm:"column:pull_request_id;not null"`
+	Status        string    `gorm:"column:status;not null"`
+	ReceivedAt    time.Time `gorm:"column:received_at;not null"`
+	ClaimedAt     time.Time `gorm:"column:claimed_at;not null"`
+}
+
+func (IngestedDelivery) TableName() string {
+	return "ingested_deliveries"
+}
+
+// RepositoryCodeOwners - модель БД для CODEOWNERS репозитория
+type RepositoryCodeOwners struct {
+	Repository string    `gorm:"column:repository;primaryKey"`
+	Content    string    `gorm:"column:content;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (RepositoryCodeOwners) TableName() string {
+	return "repository_codeowners"
+}
+
+// Repository - модель БД для зарегистрированного репозитория
+type Repository struct {
+	Name      string    `gorm:"column:name;primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (Repository) TableName() string {
+	return "repositories"
+}
+
+// RepositoryOwnerTeam - модель БД для команды-владельца репозитория (меньше priority - раньше используется)
+type RepositoryOwnerTeam struct {
+	Repository string `gorm:"column:repository;primaryKey"`
+	TeamName   string `gorm:"column:team_name;primaryKey"`
+	Priority   int    `gorm:"column:priority;not null"`
+}
+
+func (RepositoryOwnerTeam) TableName() string {
+	return "repository_owner_teams"
+}
+
 // User - модель БД для пользователя
 type User struct {
 	UserID   string `gorm:"column:user_id;primaryKey"`
 	Username string `gorm:"column:username;not null"`
-	TeamName string `gorm:"column:team_name;not null"`
+	TeamName string `gorm:"column:team_name;not null"` // основная команда
 	IsActive bool   `gorm:"column:is_active;not null;default:true"`
+	// MaxOpenReviews - личный лимит открытых ревью (NULL - лимит команды)
+	MaxOpenReviews *int `gorm:"column:max_open_reviews"`
 }
 
 func (User) TableName() string {
 	return "users"
 }
 
+// UserAbsence - модель БД для периода отсутствия пользователя
+type UserAbsence struct {
+	AbsenceID    int64      `gorm:"column:absence_id;primaryKey;autoIncrement"`
+	UserID       string     `gorm:"column:user_id;not null"`
+	StartsAt     time.Time  `gorm:"column:starts_at;not null"`
+	EndsAt       time.Time  `gorm:"column:ends_at;not null"`
+	Reason       string     `gorm:"column:reason;not null"`
+	ReassignedAt *time.Time `gorm:"column:reassigned_at"`
+
+	ReassignAttempts int        `gorm:"column:reassign_attempts;not null"`
+	LastError        string     `gorm:"column:last_error;not null"`
+	NextAttemptAt    *time.Time `gorm:"column:next_attempt_at"`
+}
+
+func (UserAbsence) TableName() string {
+	return "user_absences"
+}
+
 // Team - модель БД для команды
 type Team struct {
 	TeamName string `gorm:"column:team_name;primaryKey"`
-	Members  []User `gorm:"foreignKey:TeamName;references:TeamName"`
 }
 
 func (Team) TableName() string {
 	return "teams"
 }
 
+// TeamMembership - модель БД для членства пользователя в команде
+type TeamMembership struct {
+	TeamName string    `gorm:"column:team_name;primaryKey"`
+	UserID   string    `gorm:"column:user_id;primaryKey"`
+	Role     string    `gorm:"column:role;not null;default:MEMBER"`
+	IsActive bool      `gorm:"column:is_active;not null"` // без default: gorm не вставляет нулевые значения полей с default
+	JoinedAt time.Time `gorm:"column:joined_at;not null;default:CURRENT_TIMESTAMP"`
+}
+
+func (TeamMembership) TableName() string {
+	return "team_memberships"
+}
+
+// TeamSettings - модель БД для настроек назначения ревьюверов команды
+type TeamSettings struct {
+	TeamName          string  `gorm:"column:team_name;primaryKey"`
+	ReviewerCount     int     `gorm:"column:reviewer_count;not null"`
+	MinReviewers      int     `gorm:"column:min_reviewers;not null"`
+	RequiredApprovals int     `gorm:"column:required_approvals;not null"`
+	SelectionStrategy *string `gorm:"column:selection_strategy"`
+	MaxOpenReviews    *int    `gorm:"column:max_open_reviews"`
+	CapacityPolicy    string  `gorm:"column:capacity_policy;not null"`
+}
+
+func (TeamSettings) TableName() string {
+	return "team_settings"
+}
+
+// TeamFallback - модель БД для резервной команды (меньше priority - раньше используется)
+type TeamFallback struct {
+	TeamName         string `gorm:"column:team_name;primaryKey"`
+	FallbackTeamName string `gorm:"column:fallback_team_name;primaryKey"`
+	Priority         int    `gorm:"column:priority;not null"`
+}
+
+func (TeamFallback) TableName() string {
+	return "team_fallbacks"
+}
+
 // Reviewer - модель БД для связи PR и ревьюверов
 type Reviewer struct {
-	PullRequestID string `gorm:"column:pull_request_id;primaryKey"`
-	ReviewerID    string `gorm:"column:reviewer_id;primaryKey"`
+	PullRequestID  string    `gorm:"column:pull_request_id;primaryKey"`
+	ReviewerID     string    `gorm:"column:reviewer_id;primaryKey"`
+	State          string    `gorm:"column:state;not null;default:PENDING"`
+	StateUpdatedAt time.Time `gorm:"column:state_updated_at;not null;default:CURRENT_TIMESTAMP"`
 }
 
 func (Reviewer) TableName() string {
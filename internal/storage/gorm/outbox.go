@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/storage"
+)
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository создаёт новый репозиторий outbox
+func NewOutboxRepository(db *gorm.DB) storage.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// Add записывает событие в outbox
+func (r *outboxRepository) Add(ctx context.Context, event *domain.OutboxEvent) error {
+	dbEvent := mapOutboxEventToDB(event)
+
+	if err := r.db.WithContext(ctx).Create(&dbEvent).Error; err != nil {
+		return err
+	}
+
+	event.ID = dbEvent.OutboxID
+	return nil
+}
+
+// ClaimDue блокирует самое старое неопубликованное событие, время попытки которого наступило
+func (r *outboxRepository) ClaimDue(ctx context.Context, now time.Time) (*domain.OutboxEvent, error) {
+	var dbEvent OutboxEvent
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("published_at IS NULL AND next_attempt_at <= ?", now).
+		Order("outbox_id").
+		First(&dbEvent)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, result.Error
+	}
+
+	event := mapOutboxEventToDomain(dbEvent)
+	return &event, nil
+}
+
+// Update сохраняет состояние события после попытки публикации
+func (r *outboxRepository) Update(ctx context.Context, event *domain.OutboxEvent) error {
+	result := r.db.WithContext(ctx).
+		Model(&OutboxEvent{}).
+		Where("outbox_id = ?", event.ID).
+		Updates(map[string]interface{}{
+			"attempts":        event.Attempts,
+			"last_error":      event.LastError,
+			"next_attempt_at": event.NextAttemptAt,
+			"published_at":    event.PublishedAt,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// mapOutboxEventToDB конвертирует domain модель события в модель БД
+func mapOutboxEventToDB(event *domain.OutboxEvent) OutboxEvent {
+	return OutboxEvent{
+		OutboxID:      event.ID,
+		EventID:       event.EventID,
+		EventType:     string(event.EventType),
+		Payload:       event.Payload,
+		Attempts:      event.Attempts,
+		LastError:     event.LastError,
+		NextAttemptAt: event.NextAttemptAt,
+		CreatedAt:     event.CreatedAt,
+		PublishedAt:   event.PublishedAt,
+	}
+}
+
+// mapOutboxEventToDomain конвертирует модель БД события в domain модель
+func mapOutboxEventToDomain(dbEvent OutboxEvent) domain.OutboxEvent {
+	return domain.OutboxEvent{
+		ID:            dbEvent.OutboxID,
+		EventID:       dbEvent.EventID,
+		EventType:     domain.WebhookEventType(dbEvent.EventType),
+		Payload:       dbEvent.Payload,
+		Attempts:      dbEvent.Attempts,
+		LastError:     dbEvent.LastError,
+		NextAttemptAt: dbEvent.NextAttemptAt,
+		CreatedAt:     dbEvent.CreatedAt,
+		PublishedAt:   dbEvent.PublishedAt,
+	}
+}
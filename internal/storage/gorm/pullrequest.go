@@ -2,6 +2,7 @@ package gorm
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -15,6 +16,15 @@ import (
 	"avitoTechAutumn2025/internal/storage"
 )
 
+// inactiveReviewerCondition отбирает ревьюверов, неактивных для PR: деактивированных пользователей
+// и участников с выключенным членством в команде, от имени которой создан PR.
+// Запрос должен присоединять users и pull_requests к pull_request_reviewers.
+const inactiveReviewerCondition = `(NOT users.is_active OR EXISTS (
+	SELECT 1 FROM team_memberships
+	WHERE team_memberships.team_name = pull_requests.team_name
+	AND team_memberships.user_id = pull_request_reviewers.reviewer_id
+	AND NOT team_memberships.is_active))`
+
 type pullRequestRepository struct {
 	db *gorm.DB
 }
@@ -34,11 +44,20 @@ func (r *pullRequestRepository) Create(ctx context.Context, pr *domain.PullReque
 		Str("pull_request_id", pr.ID).
 		Msg("creating pull request in database")
 
+	changedFiles, err := json.Marshal(nonNilStrings(pr.ChangedFiles))
+	if err != nil {
+		return err
+	}
+
 	dbPR := &PullRequest{
 		PullRequestID:   pr.ID,
 		PullRequestName: pr.Name,
 		AuthorID:        pr.AuthorID,
 		Status:          string(pr.Status),
+		Repository:      nullableID(pr.Repository),
+		ChangedFiles:    changedFiles,
+		CodeOwners:      nullableID(pr.CodeOwners),
+		TeamName:        nullableID(pr.TeamName),
 	}
 
 	result := r.db.WithContext(ctx).Clauses(clause.Returning{}).Create(dbPR)
@@ -112,9 +131,17 @@ func (r *pullRequestRepository) GetByID(ctx context.Context, pullRequestID strin
 		return nil, result.Error
 	}
 
-	// Получаем ревьюверов
-	reviewers, err := r.GetReviewers(ctx, pullRequestID)
-	if err != nil {
+	// Получаем ревьюверов вместе с их решениями
+	var dbReviewers []Reviewer
+	if err := r.db.WithContext(ctx).
+		Where("pull_request_id = ?", pullRequestID).
+		Find(&dbReviewers).Error; err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Str("pull_request_id", pullRequestID).
+			Msg("error fetching reviewers")
 		return nil, err
 	}
 
@@ -124,18 +151,130 @@ func (r *pullRequestRepository) GetByID(ctx context.Context, pullRequestID strin
 		Str("pull_request_id", pullRequestID).
 		Msg("successfully fetched pull request")
 
-	return &domain.PullRequest{
-		ID:                dbPR.PullRequestID,
-		Name:              dbPR.PullRequestName,
-		AuthorID:          dbPR.AuthorID,
-		Status:            domain.PullRequestStatus(dbPR.Status),
-		AssignedReviewers: reviewers,
-		CreatedAt:         &dbPR.CreatedAt,
-		MergedAt:          dbPR.MergedAt,
-	}, nil
+	pr := mapPullRequestToDomain(dbPR, dbReviewers)
+	return &pr, nil
 }
 
-// Update обновляет pull request (используется для merge)
+// List получает страницу PR по фильтру с keyset-пагинацией по (поле сортировки, pull_request_id)
+func (r *pullRequestRepository) List(ctx context.Context, filter domain.PullRequestFilter, after *domain.PageCursor, limit int) ([]domain.PullRequest, error) {
+	requestID := logger.GetRequestID(ctx)
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Int("limit", limit).
+		Msg("listing pull requests")
+
+	sortColumn := "pull_requests.created_at"
+	if filter.SortBy == domain.PullRequestSortMergedAt {
+		sortColumn = "pull_requests.merged_at"
+	}
+	direction, cmp := "ASC", ">"
+	if filter.Descending {
+		direction, cmp = "DESC", "<"
+	}
+
+	query := r.db.WithContext(ctx).Model(&PullRequest{})
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		query = query.Where("pull_requests.status IN ?", statuses)
+	}
+	if filter.AuthorID != "" {
+		query = query.Where("pull_requests.author_id = ?", filter.AuthorID)
+	}
+	if filter.ReviewerID != "" {
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM pull_request_reviewers
+			WHERE pull_request_reviewers.pull_request_id = pull_requests.pull_request_id
+			  AND pull_request_reviewers.reviewer_id = ?
+		)`, filter.ReviewerID)
+	}
+	if filter.TeamName != "" {
+		query = query.Where("pull_requests.author_id IN (SELECT user_id FROM team_memberships WHERE team_name = ?)", filter.TeamName)
+	}
+	if filter.Repository != "" {
+		query = query.Where("pull_requests.repository = ?", filter.Repository)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("pull_requests.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("pull_requests.created_at < ?", *filter.CreatedTo)
+	}
+	if filter.MergedFrom != nil {
+		query = query.Where("pull_requests.merged_at >= ?", *filter.MergedFrom)
+	}
+	if filter.MergedTo != nil {
+		query = query.Where("pull_requests.merged_at < ?", *filter.MergedTo)
+	}
+	if filter.SortBy == domain.PullRequestSortMergedAt {
+		query = query.Where("pull_requests.merged_at IS NOT NULL")
+	}
+	if after != nil {
+		query = query.Where("("+sortColumn+", pull_requests.pull_request_id) "+cmp+" (?, ?)", after.SortValue, after.ID)
+	}
+
+	var dbPRs []PullRequest
+	result := query.
+		Order(sortColumn + " " + direction).
+		Order("pull_requests.pull_request_id " + direction).
+		Limit(limit).
+		Find(&dbPRs)
+
+	if result.Error != nil {
+		log.Error().
+			Err(result.Error).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Msg("error listing pull requests")
+		return nil, result.Error
+	}
+
+	if len(dbPRs) == 0 {
+		return []domain.PullRequest{}, nil
+	}
+
+	// Ревьюверов всей страницы получаем одним запросом
+	prIDs := make([]string, len(dbPRs))
+	for i, dbPR := range dbPRs {
+		prIDs[i] = dbPR.PullRequestID
+	}
+
+	var dbReviewers []Reviewer
+	if err := r.db.WithContext(ctx).
+		Where("pull_request_id IN ?", prIDs).
+		Find(&dbReviewers).Error; err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Msg("error fetching reviewers for pull request list")
+		return nil, err
+	}
+
+	reviewersByPR := make(map[string][]Reviewer, len(dbPRs))
+	for _, dbReviewer := range dbReviewers {
+		reviewersByPR[dbReviewer.PullRequestID] = append(reviewersByPR[dbReviewer.PullRequestID], dbReviewer)
+	}
+
+	prs := make([]domain.PullRequest, len(dbPRs))
+	for i, dbPR := range dbPRs {
+		prs[i] = mapPullRequestToDomain(dbPR, reviewersByPR[dbPR.PullRequestID])
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Int("count", len(prs)).
+		Msg("successfully listed pull requests")
+
+	return prs, nil
+}
+
+// Update обновляет статус pull request и связанные с ним поля (merge, закрытие, переход из черновика)
 func (r *pullRequestRepository) Update(ctx context.Context, pr *domain.PullRequest) error {
 	requestID := logger.GetRequestID(ctx)
 
@@ -175,6 +314,9 @@ func (r *pullRequestRepository) Update(ctx context.Context, pr *domain.PullReque
 	} else {
 		existingPR.MergedAt = pr.MergedAt
 	}
+	existingPR.ClosedAt = pr.ClosedAt
+	existingPR.MergeForced = pr.MergeForced
+	existingPR.TeamName = nullableID(pr.TeamName)
 
 	result = r.db.WithContext(ctx).Save(&existingPR)
 	if result.Error != nil {
@@ -278,6 +420,7 @@ func (r *pullRequestRepository) AssignReviewer(ctx context.Context, prID, review
 	reviewer := &Reviewer{
 		PullRequestID: prID,
 		ReviewerID:    reviewerID,
+		State:         string(domain.ReviewStatePending),
 	}
 
 	result := r.db.WithContext(ctx).Create(reviewer)
@@ -462,8 +605,116 @@ func (r *pullRequestRepository) GetReviewers(ctx context.Context, prID string) (
 	return reviewerIDs, nil
 }
 
+// SetReviewState сохраняет решение ревьювера по PR
+func (r *pullRequestRepository) SetReviewState(ctx context.Context, prID, reviewerID string, state domain.ReviewState, at time.Time) error {
+	requestID := logger.GetRequestID(ctx)
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Str("pull_request_id", prID).
+		Str("reviewer_id", reviewerID).
+		Str("state", string(state)).
+		Msg("setting review state")
+
+	result := r.db.WithContext(ctx).
+		Model(&Reviewer{}).
+		Where("pull_request_id = ? AND reviewer_id = ?", prID, reviewerID).
+		Updates(map[string]interface{}{
+			"state":            string(state),
+			"state_updated_at": at,
+		})
+
+	if result.Error != nil {
+		log.Error().
+			Err(result.Error).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Str("pull_request_id", prID).
+			Str("reviewer_id", reviewerID).
+			Msg("error setting review state")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Str("pull_request_id", prID).
+			Str("reviewer_id", reviewerID).
+			Msg("reviewer not assigned to pull request")
+		return storage.ErrNotFound
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Str("pull_request_id", prID).
+		Str("reviewer_id", reviewerID).
+		Msg("successfully set review state")
+
+	return nil
+}
+
+// AddEvent добавляет событие в историю PR
+func (r *pullRequestRepository) AddEvent(ctx context.Context, event *domain.PullRequestEvent) error {
+	requestID := logger.GetRequestID(ctx)
+
+	dbEvent := &PullRequestEvent{
+		PullRequestID: event.PullRequestID,
+		EventType:     string(event.Type),
+		Actor:         event.ActorID,
+		OldReviewerID: nullableID(event.OldReviewerID),
+		NewReviewerID: nullableID(event.NewReviewerID),
+		Reason:        event.Reason,
+		CreatedAt:     event.CreatedAt,
+	}
+
+	if err := r.db.WithContext(ctx).Create(dbEvent).Error; err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Str("pull_request_id", event.PullRequestID).
+			Str("event_type", string(event.Type)).
+			Msg("error adding pull request event")
+		return err
+	}
+
+	event.ID = dbEvent.EventID
+	return nil
+}
+
+// GetEvents получает историю событий PR
+func (r *pullRequestRepository) GetEvents(ctx context.Context, prID string) ([]domain.PullRequestEvent, error) {
+	requestID := logger.GetRequestID(ctx)
+
+	var dbEvents []PullRequestEvent
+	result := r.db.WithContext(ctx).
+		Where("pull_request_id = ?", prID).
+		Order("event_id").
+		Find(&dbEvents)
+
+	if result.Error != nil {
+		log.Error().
+			Err(result.Error).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Str("pull_request_id", prID).
+			Msg("error fetching pull request events")
+		return nil, result.Error
+	}
+
+	events := make([]domain.PullRequestEvent, len(dbEvents))
+	for i, dbEvent := range dbEvents {
+		events[i] = mapEventToDomain(dbEvent)
+	}
+
+	return events, nil
+}
+
 // GetPRsReviewedByUser получает список PR, где пользователь является ревьювером
-func (r *pullRequestRepository) GetPRsReviewedByUser(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+func (r *pullRequestRepository) GetPRsReviewedByUser(ctx context.Context, userID string, statuses []domain.PullRequestStatus, after *domain.PageCursor, limit int) ([]domain.PullRequestShort, error) {
 	requestID := logger.GetRequestID(ctx)
 
 	log.Info().
@@ -474,9 +725,26 @@ func (r *pullRequestRepository) GetPRsReviewedByUser(ctx context.Context, userID
 
 	var dbPRs []PullRequest
 
-	result := r.db.WithContext(ctx).
+	query := r.db.WithContext(ctx).
 		Joins("JOIN pull_request_reviewers ON pull_request_reviewers.pull_request_id = pull_requests.pull_request_id").
-		Where("pull_request_reviewers.reviewer_id = ?", userID).
+		Where("pull_request_reviewers.reviewer_id = ?", userID)
+	if len(statuses) > 0 {
+		statusValues := make([]string, len(statuses))
+		for i, status := range statuses {
+			statusValues[i] = string(status)
+		}
+		query = query.Where("pull_requests.status IN ?", statusValues)
+	}
+	if after != nil {
+		query = query.Where("(pull_requests.created_at, pull_requests.pull_request_id) > (?, ?)", after.SortValue, after.ID)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	result := query.
+		Order("pull_requests.created_at").
+		Order("pull_requests.pull_request_id").
 		Find(&dbPRs)
 
 	if result.Error != nil {
@@ -492,10 +760,11 @@ func (r *pullRequestRepository) GetPRsReviewedByUser(ctx context.Context, userID
 	prs := make([]domain.PullRequestShort, len(dbPRs))
 	for i, dbPR := range dbPRs {
 		prs[i] = domain.PullRequestShort{
-			ID:       dbPR.PullRequestID,
-			Name:     dbPR.PullRequestName,
-			AuthorID: dbPR.AuthorID,
-			Status:   domain.PullRequestStatus(dbPR.Status),
+			ID:        dbPR.PullRequestID,
+			Name:      dbPR.PullRequestName,
+			AuthorID:  dbPR.AuthorID,
+			Status:    domain.PullRequestStatus(dbPR.Status),
+			CreatedAt: dbPR.CreatedAt,
 		}
 	}
 
@@ -521,12 +790,14 @@ func (r *pullRequestRepository) GetInactiveReviewers(ctx context.Context, prID s
 
 	var reviewerIDs []string
 
-	// JOIN с таблицей users чтобы найти неактивных ревьюверов
+	// JOIN с таблицами users и pull_requests чтобы найти неактивных ревьюверов
 	result := r.db.WithContext(ctx).
 		Table("pull_request_reviewers").
 		Select("pull_request_reviewers.reviewer_id").
 		Joins("JOIN users ON users.user_id = pull_request_reviewers.reviewer_id").
-		Where("pull_request_reviewers.pull_request_id = ? AND users.is_active = ?", prID, false).
+		Joins("JOIN pull_requests ON pull_requests.pull_request_id = pull_request_reviewers.pull_request_id").
+		Where("pull_request_reviewers.pull_request_id = ?", prID).
+		Where(inactiveReviewerCondition).
 		Pluck("pull_request_reviewers.reviewer_id", &reviewerIDs)
 
 	if result.Error != nil {
@@ -548,3 +819,299 @@ func (r *pullRequestRepository) GetInactiveReviewers(ctx context.Context, prID s
 
 	return reviewerIDs, nil
 }
+
+// GetOpenPRIDsByReviewers возвращает идентификаторы OPEN PR, где ревьювером назначен кто-то из пользователей
+func (r *pullRequestRepository) GetOpenPRIDsByReviewers(ctx context.Context, reviewerIDs []string) ([]string, error) {
+	requestID := logger.GetRequestID(ctx)
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Int("reviewer_count", len(reviewerIDs)).
+		Msg("fetching open pull requests by reviewers")
+
+	var prIDs []string
+	if len(reviewerIDs) == 0 {
+		return prIDs, nil
+	}
+
+	result := r.db.WithContext(ctx).
+		Table("pull_request_reviewers").
+		Distinct("pull_request_reviewers.pull_request_id").
+		Joins("JOIN pull_requests ON pull_requests.pull_request_id = pull_request_reviewers.pull_request_id").
+		Where("pull_request_reviewers.reviewer_id IN ? AND pull_requests.status = ?", reviewerIDs, string(domain.PullRequestStatusOpen)).
+		Order("pull_request_reviewers.pull_request_id").
+		Pluck("pull_request_reviewers.pull_request_id", &prIDs)
+
+	if result.Error != nil {
+		log.Error().
+			Err(result.Error).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Msg("error fetching open pull requests by reviewers")
+		return nil, result.Error
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Int("pr_count", len(prIDs)).
+		Msg("successfully fetched open pull requests by reviewers")
+
+	return prIDs, nil
+}
+
+// GetOpenPRIDsByTeamReviewer возвращает идентификаторы OPEN PR команды, где ревьювером назначен пользователь
+func (r *pullRequestRepository) GetOpenPRIDsByTeamReviewer(ctx context.Context, teamName, reviewerID string) ([]string, error) {
+	requestID := logger.GetRequestID(ctx)
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Str("team_name", teamName).
+		Str("reviewer_id", reviewerID).
+		Msg("fetching open team pull requests by reviewer")
+
+	var prIDs []string
+	result := r.db.WithContext(ctx).
+		Table("pull_request_reviewers").
+		Joins("JOIN pull_requests ON pull_requests.pull_request_id = pull_request_reviewers.pull_request_id").
+		Where("pull_request_reviewers.reviewer_id = ? AND pull_requests.team_name = ? AND pull_requests.status = ?",
+			reviewerID, teamName, string(domain.PullRequestStatusOpen)).
+		Order("pull_request_reviewers.pull_request_id").
+		Pluck("pull_request_reviewers.pull_request_id", &prIDs)
+
+	if result.Error != nil {
+		log.Error().
+			Err(result.Error).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Msg("error fetching open team pull requests by reviewer")
+		return nil, result.Error
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Int("pr_count", len(prIDs)).
+		Msg("successfully fetched open team pull requests by reviewer")
+
+	return prIDs, nil
+}
+
+// CountOpenByTeam возвращает число незавершённых (OPEN и DRAFT) PR, созданных от имени команды
+// или отправленных в репозитории, которыми команда владеет
+func (r *pullRequestRepository) CountOpenByTeam(ctx context.Context, teamName string) (int, error) {
+	requestID := logger.GetRequestID(ctx)
+
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&PullRequest{}).
+		Where("status IN ?", []string{
+			string(domain.PullRequestStatusOpen),
+			string(domain.PullRequestStatusDraft),
+		}).
+		Where("team_name = ? OR repository IN (?)", teamName,
+			r.db.Table("repository_owner_teams").Select("repository").Where("team_name = ?", teamName)).
+		Count(&count)
+
+	if result.Error != nil {
+		log.Error().
+			Err(result.Error).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Str("team_name", teamName).
+			Msg("error counting open team pull requests")
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+// GetOpenPRIDsWithInactiveReviewers возвращает идентификаторы OPEN PR с неактивными ревьюверами
+func (r *pullRequestRepository) GetOpenPRIDsWithInactiveReviewers(ctx context.Context, teamName string, prIDs []string) ([]string, error) {
+	requestID := logger.GetRequestID(ctx)
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Str("team_name", teamName).
+		Int("requested_pr_count", len(prIDs)).
+		Msg("fetching open pull requests with inactive reviewers")
+
+	query := r.db.WithContext(ctx).
+		Table("pull_request_reviewers").
+		Distinct("pull_request_reviewers.pull_request_id").
+		Joins("JOIN pull_requests ON pull_requests.pull_request_id = pull_request_reviewers.pull_request_id").
+		Joins("JOIN users ON users.user_id = pull_request_reviewers.reviewer_id").
+		Where("pull_requests.status = ?", string(domain.PullRequestStatusOpen)).
+		Where(inactiveReviewerCondition)
+	if teamName != "" {
+		query = query.Where("users.user_id IN (SELECT user_id FROM team_memberships WHERE team_name = ?)", teamName)
+	}
+	if len(prIDs) > 0 {
+		query = query.Where("pull_request_reviewers.pull_request_id IN ?", prIDs)
+	}
+
+	var result []string
+	if err := query.Order("pull_request_reviewers.pull_request_id").
+		Pluck("pull_request_reviewers.pull_request_id", &result).Error; err != nil {
+		log.Error().
+			Err(err).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Msg("error fetching open pull requests with inactive reviewers")
+		return nil, err
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Int("pr_count", len(result)).
+		Msg("successfully fetched open pull requests with inactive reviewers")
+
+	return result, nil
+}
+
+// GetOpenReviewCounts возвращает количество OPEN PR, назначенных на каждого из пользователей.
+// Пользователи без открытых ревью присутствуют в результате с нулевым значением.
+func (r *pullRequestRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	requestID := logger.GetRequestID(ctx)
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Int("users_count", len(userIDs)).
+		Msg("fetching open review counts")
+
+	counts := make(map[string]int, len(userIDs))
+	for _, userID := range userIDs {
+		counts[userID] = 0
+	}
+
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []openReviewCount
+	result := openReviewCountsQuery(r.db.WithContext(ctx)).
+		Where("pull_request_reviewers.reviewer_id IN ?", userIDs).
+		Scan(&rows)
+
+	if result.Error != nil {
+		log.Error().
+			Err(result.Error).
+			Str("request_id", requestID).
+			Str("layer", "storage").
+			Msg("error fetching open review counts")
+		return nil, result.Error
+	}
+
+	for _, row := range rows {
+		counts[row.ReviewerID] = row.Count
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("layer", "storage").
+		Int("users_count", len(userIDs)).
+		Msg("successfully fetched open review counts")
+
+	return counts, nil
+}
+
+// openReviewCount - количество открытых ревью одного ревьювера
+type openReviewCount struct {
+	ReviewerID string
+	Count      int
+}
+
+// openReviewCountsQuery строит запрос количества OPEN PR по ревьюверам.
+// Используется и при выборе ревьюверов, и reconcile-горутиной метрик, чтобы нагрузка считалась одинаково.
+func openReviewCountsQuery(db *gorm.DB) *gorm.DB {
+	return db.
+		Table("pull_request_reviewers").
+		Select("pull_request_reviewers.reviewer_id, COUNT(*) AS count").
+		Joins("JOIN pull_requests ON pull_requests.pull_request_id = pull_request_reviewers.pull_request_id").
+		Where("pull_requests.status = ?", domain.PullRequestStatusOpen).
+		Group("pull_request_reviewers.reviewer_id")
+}
+
+// mapEventToDomain конвертирует модель события БД в domain модель
+func mapEventToDomain(dbEvent PullRequestEvent) domain.PullRequestEvent {
+	event := domain.PullRequestEvent{
+		ID:            dbEvent.EventID,
+		PullRequestID: dbEvent.PullRequestID,
+		Type:          domain.PullRequestEventType(dbEvent.EventType),
+		ActorID:       dbEvent.Actor,
+		Reason:        dbEvent.Reason,
+		CreatedAt:     dbEvent.CreatedAt,
+	}
+	if dbEvent.OldReviewerID != nil {
+		event.OldReviewerID = *dbEvent.OldReviewerID
+	}
+	if dbEvent.NewReviewerID != nil {
+		event.NewReviewerID = *dbEvent.NewReviewerID
+	}
+	return event
+}
+
+// nullableID переводит пустой идентификатор в NULL
+func nullableID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+// derefString переводит NULL в пустую строку
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// nonNilStrings заменяет nil на пустой список, чтобы в JSONB был [], а не null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// mapPullRequestToDomain конвертирует модель PR БД и его ревьюверов в domain модель
+func mapPullRequestToDomain(dbPR PullRequest, dbReviewers []Reviewer) domain.PullRequest {
+	reviewers := make([]string, len(dbReviewers))
+	states := make([]domain.ReviewerState, len(dbReviewers))
+	for i, dbReviewer := range dbReviewers {
+		reviewers[i] = dbReviewer.ReviewerID
+		states[i] = domain.ReviewerState{
+			ReviewerID: dbReviewer.ReviewerID,
+			State:      domain.ReviewState(dbReviewer.State),
+			UpdatedAt:  dbReviewer.StateUpdatedAt,
+		}
+	}
+
+	// Повреждённый список файлов не мешает работе с PR: он нужен только для выбора по CODEOWNERS
+	var changedFiles []string
+	_ = json.Unmarshal(dbPR.ChangedFiles, &changedFiles)
+
+	createdAt := dbPR.CreatedAt
+	return domain.PullRequest{
+		ID:                dbPR.PullRequestID,
+		Name:              dbPR.PullRequestName,
+		AuthorID:          dbPR.AuthorID,
+		Status:            domain.PullRequestStatus(dbPR.Status),
+		AssignedReviewers: reviewers,
+		ReviewerStates:    states,
+		CreatedAt:         &createdAt,
+		MergedAt:          dbPR.MergedAt,
+		ClosedAt:          dbPR.ClosedAt,
+		MergeForced:       dbPR.MergeForced,
+		Repository:        derefString(dbPR.Repository),
+		ChangedFiles:      changedFiles,
+		CodeOwners:        derefString(dbPR.CodeOwners),
+		TeamName:          derefString(dbPR.TeamName),
+	}
+}
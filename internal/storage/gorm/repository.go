@@ -0,0 +1,124 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/storage"
+)
+
+type repositoryRepository struct {
+	db *gorm.DB
+}
+
+// NewRepositoryRepository создаёт новый репозиторий зарегистрированных репозиториев
+func NewRepositoryRepository(db *gorm.DB) storage.RepositoryRepository {
+	return &repositoryRepository{db: db}
+}
+
+// Get получает репозиторий с командами-владельцами
+func (r *repositoryRepository) Get(ctx context.Context, name string) (*domain.Repository, error) {
+	var dbRepository Repository
+	result := r.db.WithContext(ctx).First(&dbRepository, "name = ?", name)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, result.Error
+	}
+
+	var ownerTeams []RepositoryOwnerTeam
+	if err := r.db.WithContext(ctx).
+		Where("repository = ?", name).
+		Order("priority").
+		Find(&ownerTeams).Error; err != nil {
+		return nil, err
+	}
+
+	repository := mapRepositoryToDomain(dbRepository)
+	for _, ownerTeam := range ownerTeams {
+		repository.OwnerTeams = append(repository.OwnerTeams, ownerTeam.TeamName)
+	}
+
+	return &repository, nil
+}
+
+// List получает все репозитории с командами-владельцами
+func (r *repositoryRepository) List(ctx context.Context) ([]domain.Repository, error) {
+	var dbRepositories []Repository
+	if err := r.db.WithContext(ctx).Order("name").Find(&dbRepositories).Error; err != nil {
+		return nil, err
+	}
+
+	var ownerTeams []RepositoryOwnerTeam
+	if err := r.db.WithContext(ctx).Order("repository, priority").Find(&ownerTeams).Error; err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string][]string, len(dbRepositories))
+	for _, ownerTeam := range ownerTeams {
+		byName[ownerTeam.Repository] = append(byName[ownerTeam.Repository], ownerTeam.TeamName)
+	}
+
+	repositories := make([]domain.Repository, len(dbRepositories))
+	for i, dbRepository := range dbRepositories {
+		repositories[i] = mapRepositoryToDomain(dbRepository)
+		repositories[i].OwnerTeams = append(repositories[i].OwnerTeams, byName[dbRepository.Name]...)
+	}
+
+	return repositories, nil
+}
+
+// Upsert создаёт или обновляет репозиторий вместе со списком команд-владельцев
+func (r *repositoryRepository) Upsert(ctx context.Context, repository *domain.Repository) error {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at"}),
+		}).
+		Create(&Repository{
+			Name:      repository.Name,
+			CreatedAt: repository.CreatedAt,
+			UpdatedAt: repository.UpdatedAt,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// Список команд-владельцев полностью заменяется новым
+	if err := r.db.WithContext(ctx).
+		Where("repository = ?", repository.Name).
+		Delete(&RepositoryOwnerTeam{}).Error; err != nil {
+		return err
+	}
+
+	if len(repository.OwnerTeams) == 0 {
+		return nil
+	}
+
+	ownerTeams := make([]RepositoryOwnerTeam, len(repository.OwnerTeams))
+	for i, teamName := range repository.OwnerTeams {
+		ownerTeams[i] = RepositoryOwnerTeam{
+			Repository: repository.Name,
+			TeamName:   teamName,
+			Priority:   i,
+		}
+	}
+
+	return r.db.WithContext(ctx).Create(&ownerTeams).Error
+}
+
+func mapRepositoryToDomain(dbRepository Repository) domain.Repository {
+	return domain.Repository{
+		Name:       dbRepository.Name,
+		OwnerTeams: []string{},
+		CreatedAt:  dbRepository.CreatedAt,
+		UpdatedAt:  dbRepository.UpdatedAt,
+	}
+}
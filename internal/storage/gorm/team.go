@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"avitoTechAutumn2025/internal/domain"
 	"avitoTechAutumn2025/internal/storage"
@@ -20,8 +21,8 @@ func NewTeamRepository(db *gorm.DB) storage.TeamRepository {
 	return &teamRepository{db: db}
 }
 
-// Create создаёт новую команду вместе с пользователями
-func (r *teamRepository) Create(ctx context.Context, team *domain.Team, users []domain.User) error {
+// Create создаёт новую команду вместе с участниками
+func (r *teamRepository) Create(ctx context.Context, team *domain.Team, members []domain.TeamMember) error {
 	dbTeam := &Team{
 		TeamName: team.Name,
 	}
@@ -37,51 +38,51 @@ func (r *teamRepository) Create(ctx context.Context, team *domain.Team, users []
 	}
 
 	// Добавляем участников команды - создаём или обновляем каждого отдельно
-	if len(users) > 0 {
-		for _, user := range users {
-			// Пытаемся найти существующего пользователя
-			var existingUser User
-			err := r.db.WithContext(ctx).Where("user_id = ?", user.UserID).First(&existingUser).Error
-
-			if err != nil {
-				if errors.Is(err, gorm.ErrRecordNotFound) {
-					// Пользователь не существует - создаём нового через raw SQL
-					if err := r.db.WithContext(ctx).Exec(
-						"INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-						user.UserID, user.Username, team.Name, user.IsActive,
-					).Error; err != nil {
-						return err
-					}
-				} else {
-					return err
-				}
-			} else {
-				// Пользователь существует - обновляем все поля явно
-				if err := r.db.WithContext(ctx).Model(&existingUser).Updates(map[string]interface{}{
-					"username":  user.Username,
-					"team_name": team.Name,
-					"is_active": user.IsActive,
-				}).Error; err != nil {
-					return err
-				}
+	for _, member := range members {
+		membership := TeamMembership{
+			TeamName: team.Name,
+			UserID:   member.UserID,
+			Role:     string(member.Role),
+			IsActive: member.IsActive,
+		}
+
+		// Пытаемся найти существующего пользователя
+		var existingUser User
+		err := r.db.WithContext(ctx).Where("user_id = ?", member.UserID).First(&existingUser).Error
+
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			// Пользователь не существует - команда становится его основной, активность хранится у пользователя
+			if err := r.db.WithContext(ctx).Exec(
+				"INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+				member.UserID, member.Username, team.Name, member.IsActive,
+			).Error; err != nil {
+				return err
+			}
+			membership.IsActive = true
+		} else {
+			// Пользователь существует - остаётся в прежних командах, обновляется только имя
+			if err := r.db.WithContext(ctx).Model(&existingUser).Update("username", member.Username).Error; err != nil {
+				return err
 			}
 		}
+
+		if err := r.db.WithContext(ctx).Create(&membership).Error; err != nil {
+			return err
+		}
 	}
 
-	// Загружаем созданную команду с участниками
-	if err := r.db.WithContext(ctx).Preload("Members").First(dbTeam, "team_name = ?", team.Name).Error; err != nil {
+	// Загружаем участников созданной команды
+	loaded, err := r.members(ctx, team.Name)
+	if err != nil {
 		return err
 	}
 
 	// Обновляем domain модель
-	team.Members = make([]domain.TeamMember, len(dbTeam.Members))
-	for i, member := range dbTeam.Members {
-		team.Members[i] = domain.TeamMember{
-			UserID:   member.UserID,
-			Username: member.Username,
-			IsActive: member.IsActive,
-		}
-	}
+	team.Members = loaded
 
 	return nil
 }
@@ -89,9 +90,7 @@ func (r *teamRepository) Create(ctx context.Context, team *domain.Team, users []
 // GetByName получает команду по имени
 func (r *teamRepository) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
 	var dbTeam Team
-	result := r.db.WithContext(ctx).
-		Preload("Members").
-		First(&dbTeam, "team_name = ?", teamName)
+	result := r.db.WithContext(ctx).First(&dbTeam, "team_name = ?", teamName)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -100,13 +99,9 @@ func (r *teamRepository) GetByName(ctx context.Context, teamName string) (*domai
 		return nil, result.Error
 	}
 
-	members := make([]domain.TeamMember, len(dbTeam.Members))
-	for i, member := range dbTeam.Members {
-		members[i] = domain.TeamMember{
-			UserID:   member.UserID,
-			Username: member.Username,
-			IsActive: member.IsActive,
-		}
+	members, err := r.members(ctx, teamName)
+	if err != nil {
+		return nil, err
 	}
 
 	return &domain.Team{
@@ -115,16 +110,270 @@ func (r *teamRepository) GetByName(ctx context.Context, teamName string) (*domai
 	}, nil
 }
 
-// DeactivateAllMembers деактивирует всех участников команды (batch update)
-func (r *teamRepository) DeactivateAllMembers(ctx context.Context, teamName string) (int, error) {
+// members получает участников команды: участник активен в ней, если активны и пользователь, и членство
+func (r *teamRepository) members(ctx context.Context, teamName string) ([]domain.TeamMember, error) {
+	var rows []struct {
+		UserID   string
+		Username string
+		Role     string
+		IsActive bool
+	}
+	result := r.db.WithContext(ctx).
+		Table("team_memberships").
+		Select("users.user_id, users.username, team_memberships.role, users.is_active AND team_memberships.is_active AS is_active").
+		Joins("JOIN users ON users.user_id = team_memberships.user_id").
+		Where("team_memberships.team_name = ?", teamName).
+		Order("users.user_id").
+		Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	members := make([]domain.TeamMember, len(rows))
+	for i, row := range rows {
+		members[i] = domain.TeamMember{
+			UserID:   row.UserID,
+			Username: row.Username,
+			Role:     domain.TeamRole(row.Role),
+			IsActive: row.IsActive,
+		}
+	}
+
+	return members, nil
+}
+
+// GetMembership получает членство пользователя в команде
+func (r *teamRepository) GetMembership(ctx context.Context, teamName, userID string) (*domain.TeamMembership, error) {
+	var dbMembership TeamMembership
+	result := r.db.WithContext(ctx).First(&dbMembership, "team_name = ? AND user_id = ?", teamName, userID)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, result.Error
+	}
+
+	membership := mapMembershipToDomain(dbMembership)
+	return &membership, nil
+}
+
+// GetMemberships получает членства пользователя в порядке вступления
+func (r *teamRepository) GetMemberships(ctx context.Context, userID string) ([]domain.TeamMembership, error) {
+	var dbMemberships []TeamMembership
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("joined_at, team_name").
+		Find(&dbMemberships).Error; err != nil {
+		return nil, err
+	}
+
+	memberships := make([]domain.TeamMembership, len(dbMemberships))
+	for i, dbMembership := range dbMemberships {
+		memberships[i] = mapMembershipToDomain(dbMembership)
+	}
+
+	return memberships, nil
+}
+
+// UpsertMembership добавляет пользователя в команду или обновляет роль и флаг существующего членства
+func (r *teamRepository) UpsertMembership(ctx context.Context, membership *domain.TeamMembership) error {
+	dbMembership := &TeamMembership{
+		TeamName: membership.TeamName,
+		UserID:   membership.UserID,
+		Role:     string(membership.Role),
+		IsActive: membership.IsActive,
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "team_name"}, {Name: "user_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"role", "is_active"}),
+			},
+			clause.Returning{},
+		).
+		Create(dbMembership)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	*membership = mapMembershipToDomain(*dbMembership)
+	return nil
+}
+
+// DeleteMembership удаляет пользователя из команды
+func (r *teamRepository) DeleteMembership(ctx context.Context, teamName, userID string) error {
+	result := r.db.WithContext(ctx).
+		Where("team_name = ? AND user_id = ?", teamName, userID).
+		Delete(&TeamMembership{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete удаляет команду; членства, настройки и резервные команды удаляются каскадно
+func (r *teamRepository) Delete(ctx context.Context, teamName string) error {
 	result := r.db.WithContext(ctx).
-		Model(&User{}).
+		Where("team_name = ?", teamName).
+		Delete(&Team{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeactivateAllMembers отключает членство всех участников команды (batch update)
+// и деактивирует пользователей, у которых не осталось активных членств
+func (r *teamRepository) DeactivateAllMembers(ctx context.Context, teamName string) (int, error) {
+	// Считаем участников, которые были активны в команде
+	var activeCount int64
+	if err := r.db.WithContext(ctx).
+		Table("team_memberships").
+		Joins("JOIN users ON users.user_id = team_memberships.user_id").
+		Where("team_memberships.team_name = ? AND team_memberships.is_active AND users.is_active", teamName).
+		Count(&activeCount).Error; err != nil {
+		return 0, err
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&TeamMembership{}).
 		Where("team_name = ? AND is_active = ?", teamName, true).
-		Update("is_active", false)
+		Update("is_active", false).Error; err != nil {
+		return 0, err
+	}
+
+	// Участник других команд остаётся активным пользователем, остальные деактивируются,
+	// чтобы их открытые ревью переназначались как ревью неактивных пользователей
+	if err := r.db.WithContext(ctx).Exec(`UPDATE users SET is_active = false
+		WHERE is_active
+		AND user_id IN (SELECT user_id FROM team_memberships WHERE team_name = ?)
+		AND NOT EXISTS (SELECT 1 FROM team_memberships m WHERE m.user_id = users.user_id AND m.is_active)`,
+		teamName,
+	).Error; err != nil {
+		return 0, err
+	}
+
+	return int(activeCount), nil
+}
+
+// GetSettings получает настройки команды
+func (r *teamRepository) GetSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	var dbSettings TeamSettings
+	result := r.db.WithContext(ctx).First(&dbSettings, "team_name = ?", teamName)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, result.Error
+	}
+
+	settings := &domain.TeamSettings{
+		TeamName:          dbSettings.TeamName,
+		ReviewerCount:     dbSettings.ReviewerCount,
+		MinReviewers:      dbSettings.MinReviewers,
+		RequiredApprovals: dbSettings.RequiredApprovals,
+		CapacityPolicy:    domain.CapacityPolicy(dbSettings.CapacityPolicy),
+	}
+	if dbSettings.SelectionStrategy != nil {
+		settings.SelectionStrategy = domain.SelectionStrategy(*dbSettings.SelectionStrategy)
+	}
+	if dbSettings.MaxOpenReviews != nil {
+		settings.MaxOpenReviews = *dbSettings.MaxOpenReviews
+	}
+
+	// Резервные команды в порядке приоритета
+	var fallbacks []TeamFallback
+	if err := r.db.WithContext(ctx).
+		Where("team_name = ?", teamName).
+		Order("priority").
+		Find(&fallbacks).Error; err != nil {
+		return nil, err
+	}
+
+	settings.FallbackTeams = make([]string, len(fallbacks))
+	for i, fallback := range fallbacks {
+		settings.FallbackTeams[i] = fallback.FallbackTeamName
+	}
+
+	return settings, nil
+}
+
+// UpsertSettings создаёт или обновляет настройки команды вместе со списком резервных команд
+func (r *teamRepository) UpsertSettings(ctx context.Context, settings *domain.TeamSettings) error {
+	dbSettings := &TeamSettings{
+		TeamName:          settings.TeamName,
+		ReviewerCount:     settings.ReviewerCount,
+		MinReviewers:      settings.MinReviewers,
+		RequiredApprovals: settings.RequiredApprovals,
+		MaxOpenReviews:    nullableLimit(settings.MaxOpenReviews),
+		CapacityPolicy:    string(settings.CapacityPolicy),
+	}
+	if settings.SelectionStrategy != "" {
+		strategy := string(settings.SelectionStrategy)
+		dbSettings.SelectionStrategy = &strategy
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "team_name"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"reviewer_count", "min_reviewers", "selection_strategy", "required_approvals",
+				"max_open_reviews", "capacity_policy",
+			}),
+		}).
+		Create(dbSettings)
 
 	if result.Error != nil {
-		return 0, result.Error
+		return result.Error
 	}
 
-	return int(result.RowsAffected), nil
+	// Список резервных команд полностью заменяется новым
+	if err := r.db.WithContext(ctx).
+		Where("team_name = ?", settings.TeamName).
+		Delete(&TeamFallback{}).Error; err != nil {
+		return err
+	}
+
+	if len(settings.FallbackTeams) == 0 {
+		return nil
+	}
+
+	fallbacks := make([]TeamFallback, len(settings.FallbackTeams))
+	for i, fallbackTeam := range settings.FallbackTeams {
+		fallbacks[i] = TeamFallback{
+			TeamName:         settings.TeamName,
+			FallbackTeamName: fallbackTeam,
+			Priority:         i,
+		}
+	}
+
+	return r.db.WithContext(ctx).Create(&fallbacks).Error
+}
+
+// mapMembershipToDomain конвертирует модель БД членства в domain модель
+func mapMembershipToDomain(dbMembership TeamMembership) domain.TeamMembership {
+	return domain.TeamMembership{
+		TeamName: dbMembership.TeamName,
+		UserID:   dbMembership.UserID,
+		Role:     domain.TeamRole(dbMembership.Role),
+		IsActive: dbMembership.IsActive,
+		JoinedAt: dbMembership.JoinedAt,
+	}
 }
@@ -57,7 +57,8 @@ func NewTxManager(db *gorm.DB) (storage.TxManager, error) {
 				// Пересчитываем членов команд и активных
 				var teams []teamRow
 				// SQL: count and sum active per team
-				err := db.Raw(`SELECT team_name, COUNT(*) as count, SUM(CASE WHEN is_active THEN 1 ELSE 0 END) as active FROM users GROUP BY team_name`).Scan(&teams).Error
+				err := db.Raw(`SELECT m.team_name, COUNT(*) as count, SUM(CASE WHEN m.is_active AND u.is_active THEN 1 ELSE 0 END) as active
+					FROM team_memberships m JOIN users u ON u.user_id = m.user_id GROUP BY m.team_name`).Scan(&teams).Error
 				if err != nil {
 					log.Error().Err(err).Msg("failed to query team membership counts")
 					continue
@@ -103,6 +104,18 @@ func NewTxManager(db *gorm.DB) (storage.TxManager, error) {
 					}
 				}
 
+				// Пересчитываем нагрузку открытыми ревью тем же запросом, что использует least_loaded
+				var openReviews []openReviewCount
+				if err := openReviewCountsQuery(db).Scan(&openReviews).Error; err != nil {
+					log.Error().Err(err).Msg("failed to query open review counts")
+				} else {
+					metrics.UserOpenReviewAssignmentsCount.Reset()
+
+					for _, u := range openReviews {
+						metrics.UserOpenReviewAssignmentsCount.WithLabelValues(u.ReviewerID).Set(float64(u.Count))
+					}
+				}
+
 			case <-stopCh:
 				log.Info().Msg("stopping metrics reconciliation goroutine")
 				return
@@ -160,6 +173,31 @@ func (t *transaction) TeamRepo() storage.TeamRepository {
 	return NewTeamRepository(t.db)
 }
 
+// WebhookRepo возвращает репозиторий подписок на события в рамках транзакции
+func (t *transaction) WebhookRepo() storage.WebhookRepository {
+	return NewWebhookRepository(t.db)
+}
+
+// OutboxRepo возвращает репозиторий outbox в рамках транзакции
+func (t *transaction) OutboxRepo() storage.OutboxRepository {
+	return NewOutboxRepository(t.db)
+}
+
+// IngestionRepo возвращает репозиторий доставок из внешних систем в рамках транзакции
+func (t *transaction) IngestionRepo() storage.IngestionRepository {
+	return NewIngestionRepository(t.db)
+}
+
+// CodeOwnersRepo возвращает репозиторий CODEOWNERS в рамках транзакции
+func (t *transaction) CodeOwnersRepo() storage.CodeOwnersRepository {
+	return NewCodeOwnersRepository(t.db)
+}
+
+// RepositoryRepo возвращает репозиторий зарегистрированных репозиториев в рамках транзакции
+func (t *transaction) RepositoryRepo() storage.RepositoryRepository {
+	return NewRepositoryRepository(t.db)
+}
+
 // Commit не нужен, так как GORM автоматически коммитит
 func (t *transaction) Commit() error {
 	return nil
@@ -3,13 +3,24 @@ package gorm
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"avitoTechAutumn2025/internal/domain"
 	"avitoTechAutumn2025/internal/storage"
 )
 
+// notAbsentNowCondition отбрасывает пользователей, чей период отсутствия покрывает текущий момент
+const notAbsentNowCondition = `NOT EXISTS (
+	SELECT 1 FROM user_absences a
+	WHERE a.user_id = users.user_id AND a.starts_at <= now() AND a.ends_at > now()
+)`
+
+// teamMembershipJoin присоединяет к пользователям их членства в командах
+const teamMembershipJoin = "JOIN team_memberships ON team_memberships.user_id = users.user_id"
+
 type userRepository struct {
 	db *gorm.DB
 }
@@ -31,21 +42,41 @@ func (r *userRepository) GetByID(ctx context.Context, userID string) (*domain.Us
 		return nil, result.Error
 	}
 
-	return &domain.User{
-		UserID:   dbUser.UserID,
-		Username: dbUser.Username,
-		TeamName: dbUser.TeamName,
-		IsActive: dbUser.IsActive,
-	}, nil
+	user := mapUserToDomain(dbUser)
+	return &user, nil
 }
 
-// Update обновляет пользователя (включая is_active)
+// GetByIDs получает пользователей по списку ID
+func (r *userRepository) GetByIDs(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	if len(userIDs) == 0 {
+		return []domain.User{}, nil
+	}
+
+	var dbUsers []User
+	result := r.db.WithContext(ctx).
+		Where("user_id IN ?", userIDs).
+		Find(&dbUsers)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	users := make([]domain.User, len(dbUsers))
+	for i, dbUser := range dbUsers {
+		users[i] = mapUserToDomain(dbUser)
+	}
+
+	return users, nil
+}
+
+// Update обновляет пользователя (is_active и личный лимит открытых ревью)
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 	result := r.db.WithContext(ctx).
 		Model(&User{}).
 		Where("user_id = ?", user.UserID).
 		Updates(map[string]interface{}{
-			"is_active": user.IsActive,
+			"is_active":        user.IsActive,
+			"max_open_reviews": nullableLimit(user.MaxOpenReviews),
 		})
 
 	if result.Error != nil {
@@ -66,25 +97,57 @@ func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 	}
 
 	// Обновляем domain модель актуальными данными из БД
-	user.Username = dbUser.Username
-	user.TeamName = dbUser.TeamName
-	user.IsActive = dbUser.IsActive
+	*user = mapUserToDomain(dbUser)
 
 	return nil
 }
 
-// GetActiveTeamMembers получает активных членов команды по ID пользователя
-func (r *userRepository) GetActiveTeamMembers(ctx context.Context, userID string) ([]domain.User, error) {
-	// Сначала получаем пользователя чтобы узнать его команду
-	user, err := r.GetByID(ctx, userID)
-	if err != nil {
+// GetActiveTeamMembers получает активных участников команды, кроме указанного пользователя
+func (r *userRepository) GetActiveTeamMembers(ctx context.Context, teamName, excludeUserID string) ([]domain.User, error) {
+	return r.activeByTeam(ctx, teamName, excludeUserID)
+}
+
+// GetActiveByTeam получает активных и не отсутствующих участников команды по её имени
+func (r *userRepository) GetActiveByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
+	return r.activeByTeam(ctx, teamName, "")
+}
+
+// activeByTeam получает участников команды с активным членством, активных и не отсутствующих сейчас
+// (excludeUserID - пользователь, которого не нужно возвращать; пусто - никого)
+func (r *userRepository) activeByTeam(ctx context.Context, teamName, excludeUserID string) ([]domain.User, error) {
+	query := r.db.WithContext(ctx).
+		Joins(teamMembershipJoin).
+		Where("team_memberships.team_name = ? AND team_memberships.is_active = ? AND users.is_active = ?", teamName, true, true).
+		Where(notAbsentNowCondition)
+	if excludeUserID != "" {
+		query = query.Where("users.user_id != ?", excludeUserID)
+	}
+
+	var dbUsers []User
+	if err := query.Find(&dbUsers).Error; err != nil {
 		return nil, err
 	}
 
-	// Получаем всех активных членов команды кроме самого пользователя
+	// Участник выбирается как член этой команды, даже если его основная команда другая
+	users := make([]domain.User, len(dbUsers))
+	for i, dbUser := range dbUsers {
+		users[i] = mapUserToDomain(dbUser)
+		users[i].TeamName = teamName
+	}
+
+	return users, nil
+}
+
+// GetActiveByIDs получает активных и не отсутствующих сейчас пользователей из списка
+func (r *userRepository) GetActiveByIDs(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	if len(userIDs) == 0 {
+		return []domain.User{}, nil
+	}
+
 	var dbUsers []User
 	result := r.db.WithContext(ctx).
-		Where("team_name = ? AND is_active = ? AND user_id != ?", user.TeamName, true, userID).
+		Where("user_id IN ? AND is_active = ?", userIDs, true).
+		Where(notAbsentNowCondition).
 		Find(&dbUsers)
 
 	if result.Error != nil {
@@ -93,18 +156,45 @@ func (r *userRepository) GetActiveTeamMembers(ctx context.Context, userID string
 
 	users := make([]domain.User, len(dbUsers))
 	for i, dbUser := range dbUsers {
-		users[i] = domain.User{
-			UserID:   dbUser.UserID,
-			Username: dbUser.Username,
-			TeamName: dbUser.TeamName,
-			IsActive: dbUser.IsActive,
-		}
+		users[i] = mapUserToDomain(dbUser)
 	}
 
 	return users, nil
 }
 
-// CreateBatch создаёт несколько пользователей
+// GetUnavailableByTeam получает неактивных и отсутствующих сейчас участников команды.
+// Участник неактивен в команде, если неактивен пользователь или его членство.
+func (r *userRepository) GetUnavailableByTeam(ctx context.Context, teamName string) ([]domain.SelectionExclusion, error) {
+	var rows []struct {
+		UserID   string
+		IsActive bool
+	}
+	result := r.db.WithContext(ctx).
+		Model(&User{}).
+		Select("users.user_id, users.is_active AND team_memberships.is_active AS is_active").
+		Joins(teamMembershipJoin).
+		Where("team_memberships.team_name = ?", teamName).
+		Where("NOT (users.is_active AND team_memberships.is_active) OR NOT (" + notAbsentNowCondition + ")").
+		Order("users.user_id").
+		Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	unavailable := make([]domain.SelectionExclusion, len(rows))
+	for i, row := range rows {
+		reason := domain.ExclusionReasonOutOfOffice
+		if !row.IsActive {
+			reason = domain.ExclusionReasonInactive
+		}
+		unavailable[i] = domain.SelectionExclusion{UserID: row.UserID, Reason: reason}
+	}
+
+	return unavailable, nil
+}
+
+// CreateBatch создаёт несколько пользователей вместе с членством в их основной команде
 func (r *userRepository) CreateBatch(ctx context.Context, users []domain.User) error {
 	for _, user := range users {
 		if err := r.db.WithContext(ctx).Exec(
@@ -113,7 +203,186 @@ func (r *userRepository) CreateBatch(ctx context.Context, users []domain.User) e
 		).Error; err != nil {
 			return err
 		}
+		if err := r.db.WithContext(ctx).Create(&TeamMembership{
+			TeamName: user.TeamName,
+			UserID:   user.UserID,
+			Role:     string(domain.TeamRoleMember),
+			IsActive: true,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetPrimaryTeam меняет основную команду пользователя
+func (r *userRepository) SetPrimaryTeam(ctx context.Context, userID, teamName string) error {
+	result := r.db.WithContext(ctx).
+		Model(&User{}).
+		Where("user_id = ?", userID).
+		Update("team_name", teamName)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateAbsence создаёт период отсутствия пользователя
+func (r *userRepository) CreateAbsence(ctx context.Context, absence *domain.UserAbsence) error {
+	dbAbsence := &UserAbsence{
+		UserID:   absence.UserID,
+		StartsAt: absence.StartsAt,
+		EndsAt:   absence.EndsAt,
+		Reason:   absence.Reason,
+	}
+
+	if err := r.db.WithContext(ctx).Create(dbAbsence).Error; err != nil {
+		return err
+	}
+
+	absence.ID = dbAbsence.AbsenceID
+	return nil
+}
+
+// GetAbsences получает периоды отсутствия пользователя
+func (r *userRepository) GetAbsences(ctx context.Context, userID string) ([]domain.UserAbsence, error) {
+	var dbAbsences []UserAbsence
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("starts_at").
+		Find(&dbAbsences)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	absences := make([]domain.UserAbsence, len(dbAbsences))
+	for i, dbAbsence := range dbAbsences {
+		absences[i] = mapAbsenceToDomain(dbAbsence)
+	}
+
+	return absences, nil
+}
+
+// DeleteAbsence удаляет период отсутствия пользователя
+func (r *userRepository) DeleteAbsence(ctx context.Context, userID string, absenceID int64) error {
+	result := r.db.WithContext(ctx).
+		Where("absence_id = ? AND user_id = ?", absenceID, userID).
+		Delete(&UserAbsence{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// ClaimStartedAbsence блокирует один начавшийся и не обработанный период отсутствия
+func (r *userRepository) ClaimStartedAbsence(ctx context.Context, now time.Time) (*domain.UserAbsence, error) {
+	var dbAbsence UserAbsence
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("reassigned_at IS NULL AND starts_at <= ? AND ends_at > ?", now, now).
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now).
+		Order("starts_at").
+		First(&dbAbsence)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, result.Error
+	}
+
+	absence := mapAbsenceToDomain(dbAbsence)
+	return &absence, nil
+}
+
+// MarkAbsenceReassigned отмечает период отсутствия как обработанный
+func (r *userRepository) MarkAbsenceReassigned(ctx context.Context, absenceID int64, at time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&UserAbsence{}).
+		Where("absence_id = ?", absenceID).
+		Update("reassigned_at", at)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// RecordAbsenceFailure отмечает неудачную попытку переназначения и откладывает следующую
+func (r *userRepository) RecordAbsenceFailure(ctx context.Context, absenceID int64, lastError string, nextAttemptAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&UserAbsence{}).
+		Where("absence_id = ?", absenceID).
+		Updates(map[string]interface{}{
+			"reassign_attempts": gorm.Expr("reassign_attempts + 1"),
+			"last_error":        lastError,
+			"next_attempt_at":   nextAttemptAt,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return storage.ErrNotFound
 	}
 
 	return nil
 }
+
+// mapAbsenceToDomain конвертирует модель БД периода отсутствия в domain модель
+func mapAbsenceToDomain(dbAbsence UserAbsence) domain.UserAbsence {
+	return domain.UserAbsence{
+		ID:           dbAbsence.AbsenceID,
+		UserID:       dbAbsence.UserID,
+		StartsAt:     dbAbsence.StartsAt,
+		EndsAt:       dbAbsence.EndsAt,
+		Reason:       dbAbsence.Reason,
+		ReassignedAt: dbAbsence.ReassignedAt,
+
+		ReassignAttempts: dbAbsence.ReassignAttempts,
+		LastError:        dbAbsence.LastError,
+		NextAttemptAt:    dbAbsence.NextAttemptAt,
+	}
+}
+
+// mapUserToDomain конвертирует модель БД пользователя в domain модель
+func mapUserToDomain(dbUser User) domain.User {
+	user := domain.User{
+		UserID:   dbUser.UserID,
+		Username: dbUser.Username,
+		TeamName: dbUser.TeamName,
+		IsActive: dbUser.IsActive,
+	}
+	if dbUser.MaxOpenReviews != nil {
+		user.MaxOpenReviews = *dbUser.MaxOpenReviews
+	}
+	return user
+}
+
+// nullableLimit переводит лимит в значение колонки: 0 (лимит не задан) хранится как NULL
+func nullableLimit(limit int) *int {
+	if limit <= 0 {
+		return nil
+	}
+	return &limit
+}
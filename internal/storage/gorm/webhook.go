@@ -0,0 +1,289 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/storage"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository создаёт новый репозиторий подписок на события
+func NewWebhookRepository(db *gorm.DB) storage.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// CreateSubscription создаёт подписку вместе с её фильтром событий
+func (r *webhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	dbSubscription := &WebhookSubscription{
+		URL:       subscription.URL,
+		Secret:    subscription.Secret,
+		CreatedAt: subscription.CreatedAt,
+	}
+
+	if err := r.db.WithContext(ctx).Create(dbSubscription).Error; err != nil {
+		return err
+	}
+	subscription.ID = dbSubscription.SubscriptionID
+
+	if len(subscription.EventTypes) == 0 {
+		return nil
+	}
+
+	events := make([]WebhookSubscriptionEvent, len(subscription.EventTypes))
+	for i, eventType := range subscription.EventTypes {
+		events[i] = WebhookSubscriptionEvent{
+			SubscriptionID: subscription.ID,
+			EventType:      string(eventType),
+		}
+	}
+
+	return r.db.WithContext(ctx).Create(&events).Error
+}
+
+// GetSubscription получает подписку по ID
+func (r *webhookRepository) GetSubscription(ctx context.Context, subscriptionID int64) (*domain.WebhookSubscription, error) {
+	var dbSubscription WebhookSubscription
+	result := r.db.WithContext(ctx).First(&dbSubscription, "subscription_id = ?", subscriptionID)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, result.Error
+	}
+
+	subscriptions, err := r.withEventTypes(ctx, []WebhookSubscription{dbSubscription})
+	if err != nil {
+		return nil, err
+	}
+	return &subscriptions[0], nil
+}
+
+// ListSubscriptions получает все подписки
+func (r *webhookRepository) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var dbSubscriptions []WebhookSubscription
+	if err := r.db.WithContext(ctx).
+		Order("subscription_id").
+		Find(&dbSubscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return r.withEventTypes(ctx, dbSubscriptions)
+}
+
+// GetSubscriptionsForEvent получает подписки без фильтра и подписки, в фильтре которых есть eventType
+func (r *webhookRepository) GetSubscriptionsForEvent(ctx context.Context, eventType domain.WebhookEventType) ([]domain.WebhookSubscription, error) {
+	var dbSubscriptions []WebhookSubscription
+	if err := r.db.WithContext(ctx).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM webhook_subscription_events e
+			WHERE e.subscription_id = webhook_subscriptions.subscription_id
+		) OR EXISTS (
+			SELECT 1 FROM webhook_subscription_events e
+			WHERE e.subscription_id = webhook_subscriptions.subscription_id AND e.event_type = ?
+		)`, string(eventType)).
+		Order("subscription_id").
+		Find(&dbSubscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return r.withEventTypes(ctx, dbSubscriptions)
+}
+
+// DeleteSubscription удаляет подписку; фильтр и доставки удаляются каскадно
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	result := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Delete(&WebhookSubscription{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateDelivery ставит доставку события в очередь; повтор того же события подписчику пропускается
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	dbDelivery := mapWebhookDeliveryToDB(delivery)
+
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}},
+			DoNothing: true,
+		}).
+		Create(&dbDelivery).Error; err != nil {
+		return err
+	}
+
+	delivery.ID = dbDelivery.DeliveryID
+	return nil
+}
+
+// ClaimDueDelivery блокирует одну ожидающую доставку, время попытки которой наступило
+func (r *webhookRepository) ClaimDueDelivery(ctx context.Context, now time.Time) (*domain.WebhookDelivery, error) {
+	var dbDelivery WebhookDelivery
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", string(domain.WebhookDeliveryPending), now).
+		Order("next_attempt_at, delivery_id").
+		First(&dbDelivery)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, result.Error
+	}
+
+	delivery := mapWebhookDeliveryToDomain(dbDelivery)
+	return &delivery, nil
+}
+
+// UpdateDelivery сохраняет состояние доставки после попытки
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	dbDelivery := mapWebhookDeliveryToDB(delivery)
+
+	result := r.db.WithContext(ctx).
+		Model(&WebhookDelivery{}).
+		Where("delivery_id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":           dbDelivery.Status,
+			"attempts":         dbDelivery.Attempts,
+			"last_status_code": dbDelivery.LastStatusCode,
+			"last_error":       dbDelivery.LastError,
+			"next_attempt_at":  dbDelivery.NextAttemptAt,
+			"delivered_at":     dbDelivery.DeliveredAt,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetDeliveries получает последние доставки подписки
+func (r *webhookRepository) GetDeliveries(ctx context.Context, subscriptionID int64, status domain.WebhookDeliveryStatus, limit int) ([]domain.WebhookDelivery, error) {
+	query := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+
+	var dbDeliveries []WebhookDelivery
+	if err := query.
+		Order("delivery_id DESC").
+		Limit(limit).
+		Find(&dbDeliveries).Error; err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]domain.WebhookDelivery, len(dbDeliveries))
+	for i, dbDelivery := range dbDeliveries {
+		deliveries[i] = mapWebhookDeliveryToDomain(dbDelivery)
+	}
+
+	return deliveries, nil
+}
+
+// withEventTypes дополняет подписки их фильтрами событий
+func (r *webhookRepository) withEventTypes(ctx context.Context, dbSubscriptions []WebhookSubscription) ([]domain.WebhookSubscription, error) {
+	subscriptions := make([]domain.WebhookSubscription, len(dbSubscriptions))
+	if len(dbSubscriptions) == 0 {
+		return subscriptions, nil
+	}
+
+	ids := make([]int64, len(dbSubscriptions))
+	for i, dbSubscription := range dbSubscriptions {
+		ids[i] = dbSubscription.SubscriptionID
+	}
+
+	var events []WebhookSubscriptionEvent
+	if err := r.db.WithContext(ctx).
+		Where("subscription_id IN ?", ids).
+		Order("event_type").
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	eventTypes := make(map[int64][]domain.WebhookEventType, len(dbSubscriptions))
+	for _, event := range events {
+		eventTypes[event.SubscriptionID] = append(eventTypes[event.SubscriptionID], domain.WebhookEventType(event.EventType))
+	}
+
+	for i, dbSubscription := range dbSubscriptions {
+		subscriptions[i] = domain.WebhookSubscription{
+			ID:         dbSubscription.SubscriptionID,
+			URL:        dbSubscription.URL,
+			Secret:     dbSubscription.Secret,
+			EventTypes: eventTypes[dbSubscription.SubscriptionID],
+			CreatedAt:  dbSubscription.CreatedAt,
+		}
+		if subscriptions[i].EventTypes == nil {
+			subscriptions[i].EventTypes = []domain.WebhookEventType{}
+		}
+	}
+
+	return subscriptions, nil
+}
+
+// mapWebhookDeliveryToDB конвертирует domain модель доставки в модель БД
+func mapWebhookDeliveryToDB(delivery *domain.WebhookDelivery) WebhookDelivery {
+	dbDelivery := WebhookDelivery{
+		DeliveryID:     delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+	if delivery.LastStatusCode != 0 {
+		statusCode := delivery.LastStatusCode
+		dbDelivery.LastStatusCode = &statusCode
+	}
+	return dbDelivery
+}
+
+// mapWebhookDeliveryToDomain конвертирует модель БД доставки в domain модель
+func mapWebhookDeliveryToDomain(dbDelivery WebhookDelivery) domain.WebhookDelivery {
+	delivery := domain.WebhookDelivery{
+		ID:             dbDelivery.DeliveryID,
+		SubscriptionID: dbDelivery.SubscriptionID,
+		EventID:        dbDelivery.EventID,
+		EventType:      domain.WebhookEventType(dbDelivery.EventType),
+		Payload:        dbDelivery.Payload,
+		Status:         domain.WebhookDeliveryStatus(dbDelivery.Status),
+		Attempts:       dbDelivery.Attempts,
+		LastError:      dbDelivery.LastError,
+		NextAttemptAt:  dbDelivery.NextAttemptAt,
+		CreatedAt:      dbDelivery.CreatedAt,
+		DeliveredAt:    dbDelivery.DeliveredAt,
+	}
+	if dbDelivery.LastStatusCode != nil {
+		delivery.LastStatusCode = *dbDelivery.LastStatusCode
+	}
+	return delivery
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"avitoTechAutumn2025/internal/domain"
 )
@@ -23,6 +24,11 @@ type Tx interface {
 	PullRequestRepo() PullRequestRepository
 	UserRepo() UserRepository
 	TeamRepo() TeamRepository
+	WebhookRepo() WebhookRepository
+	OutboxRepo() OutboxRepository
+	IngestionRepo() IngestionRepository
+	CodeOwnersRepo() CodeOwnersRepository
+	RepositoryRepo() RepositoryRepository
 }
 
 // PullRequestRepository определяет операции с pull requests
@@ -47,11 +53,48 @@ type PullRequestRepository interface {
 	// GetReviewers возвращает список ревьюверов PR
 	GetReviewers(ctx context.Context, prID string) ([]string, error)
 
-	// GetPRsReviewedByUser возвращает список PR где пользователь является ревьювером
-	GetPRsReviewedByUser(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
+	// SetReviewState сохраняет решение ревьювера по PR (ErrNotFound если он не назначен)
+	SetReviewState(ctx context.Context, prID, reviewerID string, state domain.ReviewState, at time.Time) error
 
-	// GetInactiveReviewers возвращает список неактивных ревьюверов для данного PR
+	// List возвращает до limit PR по фильтру, начиная после курсора (nil - с начала)
+	List(ctx context.Context, filter domain.PullRequestFilter, after *domain.PageCursor, limit int) ([]domain.PullRequest, error)
+
+	// AddEvent добавляет событие в историю PR и заполняет его ID
+	AddEvent(ctx context.Context, event *domain.PullRequestEvent) error
+
+	// GetEvents возвращает историю событий PR в порядке добавления
+	GetEvents(ctx context.Context, prID string) ([]domain.PullRequestEvent, error)
+
+	// AddSelectionExplanation сохраняет объяснение выбора ревьюверов и заполняет его ID
+	AddSelectionExplanation(ctx context.Context, explanation *domain.SelectionExplanation) error
+
+	// GetSelectionExplanations возвращает объяснения выборов ревьюверов PR в порядке добавления
+	GetSelectionExplanations(ctx context.Context, prID string) ([]domain.SelectionExplanation, error)
+
+	// GetPRsReviewedByUser возвращает PR, где пользователь является ревьювером, в порядке created_at.
+	// Пустой statuses - все статусы, after - позиция после которой начинать (nil - с начала), limit 0 - без лимита.
+	GetPRsReviewedByUser(ctx context.Context, userID string, statuses []domain.PullRequestStatus, after *domain.PageCursor, limit int) ([]domain.PullRequestShort, error)
+
+	// GetInactiveReviewers возвращает список неактивных ревьюверов для данного PR:
+	// деактивированных пользователей и участников с выключенным членством в команде PR
 	GetInactiveReviewers(ctx context.Context, prID string) ([]string, error)
+
+	// GetOpenPRIDsByReviewers возвращает идентификаторы OPEN PR, где ревьювером назначен кто-то из пользователей
+	GetOpenPRIDsByReviewers(ctx context.Context, reviewerIDs []string) ([]string, error)
+
+	// GetOpenPRIDsByTeamReviewer возвращает идентификаторы OPEN PR команды, где ревьювером назначен пользователь
+	GetOpenPRIDsByTeamReviewer(ctx context.Context, teamName, reviewerID string) ([]string, error)
+
+	// CountOpenByTeam возвращает число незавершённых (OPEN и DRAFT) PR, созданных от имени команды
+	// или отправленных в репозитории, которыми команда владеет
+	CountOpenByTeam(ctx context.Context, teamName string) (int, error)
+
+	// GetOpenPRIDsWithInactiveReviewers возвращает идентификаторы OPEN PR с неактивными ревьюверами.
+	// Непустой teamName оставляет PR, где неактивный ревьювер из этой команды, непустой prIDs - только эти PR.
+	GetOpenPRIDsWithInactiveReviewers(ctx context.Context, teamName string, prIDs []string) ([]string, error)
+
+	// GetOpenReviewCounts возвращает количество OPEN PR, назначенных на каждого из пользователей
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
 }
 
 // UserRepository определяет операции с пользователями
@@ -61,26 +104,178 @@ type UserRepository interface {
 	// GetByID возвращает пользователя по ID
 	GetByID(ctx context.Context, userID string) (*domain.User, error)
 
+	// GetByIDs возвращает пользователей по списку ID (отсутствующие пропускаются, порядок не гарантирован)
+	GetByIDs(ctx context.Context, userIDs []string) ([]domain.User, error)
+
 	// Update обновляет пользователя
 	Update(ctx context.Context, user *domain.User) error
 
-	// GetActiveTeamMembers возвращает активных членов команды (исключая указанного пользователя)
-	GetActiveTeamMembers(ctx context.Context, excludeUserID string) ([]domain.User, error)
+	// GetActiveTeamMembers возвращает активных и не отсутствующих сейчас участников команды (исключая указанного пользователя)
+	GetActiveTeamMembers(ctx context.Context, teamName, excludeUserID string) ([]domain.User, error)
+
+	// GetActiveByTeam возвращает активных и не отсутствующих сейчас участников команды по её имени.
+	// Участник активен в команде, если активны и пользователь, и его членство; TeamName у результата - эта команда.
+	GetActiveByTeam(ctx context.Context, teamName string) ([]domain.User, error)
+
+	// GetActiveByIDs возвращает активных и не отсутствующих сейчас пользователей из списка (порядок не гарантирован)
+	GetActiveByIDs(ctx context.Context, userIDs []string) ([]domain.User, error)
+
+	// GetUnavailableByTeam возвращает участников команды, которых нельзя назначать: неактивных (INACTIVE)
+	// и активных, но отсутствующих сейчас (OUT_OF_OFFICE)
+	GetUnavailableByTeam(ctx context.Context, teamName string) ([]domain.SelectionExclusion, error)
+
+	// CreateAbsence создаёт период отсутствия пользователя и заполняет его ID
+	CreateAbsence(ctx context.Context, absence *domain.UserAbsence) error
+
+	// GetAbsences возвращает периоды отсутствия пользователя, отсортированные по началу
+	GetAbsences(ctx context.Context, userID string) ([]domain.UserAbsence, error)
+
+	// DeleteAbsence удаляет период отсутствия пользователя (ErrNotFound если его нет)
+	DeleteAbsence(ctx context.Context, userID string, absenceID int64) error
+
+	// ClaimStartedAbsence блокирует один начавшийся и ещё не обработанный период отсутствия,
+	// время следующей попытки которого наступило (ErrNotFound если таких нет). Периоды, заблокированные другими транзакциями, пропускаются.
+	ClaimStartedAbsence(ctx context.Context, now time.Time) (*domain.UserAbsence, error)
+
+	// MarkAbsenceReassigned отмечает, что ревью по периоду отсутствия переназначены
+	MarkAbsenceReassigned(ctx context.Context, absenceID int64, at time.Time) error
+
+	// RecordAbsenceFailure увеличивает счётчик неудачных попыток переназначения по периоду отсутствия,
+	// запоминает ошибку и откладывает следующую попытку до nextAttemptAt
+	RecordAbsenceFailure(ctx context.Context, absenceID int64, lastError string, nextAttemptAt time.Time) error
 
 	// CreateBatch создаёт нескольких пользователей за раз
 	CreateBatch(ctx context.Context, users []domain.User) error
+
+	// SetPrimaryTeam меняет основную команду пользователя (ErrNotFound если пользователя нет)
+	SetPrimaryTeam(ctx context.Context, userID, teamName string) error
 }
 
 // TeamRepository определяет операции с командами
 //
 //go:generate mockery --name=TeamRepository --output=../mocks --outpkg=mocks --filename=team_repository_mock.go
 type TeamRepository interface {
-	// Create создаёт команду и её участников (с upsert логикой)
-	Create(ctx context.Context, team *domain.Team, users []domain.User) error
+	// Create создаёт команду и её участников (с upsert логикой). Новые пользователи получают команду основной,
+	// существующие добавляются в неё без изменения основной команды. IsActive нового пользователя - его активность,
+	// существующего - флаг членства в команде.
+	Create(ctx context.Context, team *domain.Team, members []domain.TeamMember) error
 
 	// GetByName возвращает команду по имени с её участниками
 	GetByName(ctx context.Context, name string) (*domain.Team, error)
 
-	// DeactivateAllMembers деактивирует всех участников команды (batch update)
+	// GetMembership возвращает членство пользователя в команде (ErrNotFound если он в ней не состоит)
+	GetMembership(ctx context.Context, teamName, userID string) (*domain.TeamMembership, error)
+
+	// GetMemberships возвращает членства пользователя в порядке вступления
+	GetMemberships(ctx context.Context, userID string) ([]domain.TeamMembership, error)
+
+	// UpsertMembership добавляет пользователя в команду или меняет роль и флаг его членства; заполняет JoinedAt
+	UpsertMembership(ctx context.Context, membership *domain.TeamMembership) error
+
+	// DeleteMembership удаляет пользователя из команды (ErrNotFound если он в ней не состоит)
+	DeleteMembership(ctx context.Context, teamName, userID string) error
+
+	// Delete удаляет команду вместе с её членствами и настройками (ErrNotFound если её нет)
+	Delete(ctx context.Context, teamName string) error
+
+	// DeactivateAllMembers отключает членство всех участников команды и деактивирует пользователей,
+	// у которых не осталось активных членств. Возвращает число участников, активных в команде до вызова.
 	DeactivateAllMembers(ctx context.Context, teamName string) (int, error)
+
+	// GetSettings возвращает сохранённые настройки команды с резервными командами (ErrNotFound если не заданы)
+	GetSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error)
+
+	// UpsertSettings создаёт или обновляет настройки команды, заменяя список резервных команд
+	UpsertSettings(ctx context.Context, settings *domain.TeamSettings) error
+}
+
+// WebhookRepository определяет операции с подписками на события и журналом их доставок
+//
+//go:generate mockery --name=WebhookRepository --output=../mocks --outpkg=mocks --filename=webhook_repository_mock.go
+type WebhookRepository interface {
+	// CreateSubscription создаёт подписку и заполняет её ID
+	CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+
+	// GetSubscription возвращает подписку по ID
+	GetSubscription(ctx context.Context, subscriptionID int64) (*domain.WebhookSubscription, error)
+
+	// ListSubscriptions возвращает все подписки в порядке создания
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+
+	// GetSubscriptionsForEvent возвращает подписки, которые получают события этого типа
+	GetSubscriptionsForEvent(ctx context.Context, eventType domain.WebhookEventType) ([]domain.WebhookSubscription, error)
+
+	// DeleteSubscription удаляет подписку и её доставки (ErrNotFound если её нет)
+	DeleteSubscription(ctx context.Context, subscriptionID int64) error
+
+	// CreateDelivery ставит доставку события в очередь и заполняет её ID.
+	// Если доставка этого события подписчику уже есть, ничего не делает.
+	CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// ClaimDueDelivery блокирует одну ожидающую доставку, время попытки которой наступило
+	// (ErrNotFound если таких нет). Доставки, заблокированные другими транзакциями, пропускаются.
+	ClaimDueDelivery(ctx context.Context, now time.Time) (*domain.WebhookDelivery, error)
+
+	// UpdateDelivery сохраняет результат попытки доставки
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// GetDeliveries возвращает до limit последних доставок подписки, новые первыми.
+	// Пустой status - все состояния.
+	GetDeliveries(ctx context.Context, subscriptionID int64, status domain.WebhookDeliveryStatus, limit int) ([]domain.WebhookDelivery, error)
+}
+
+// OutboxRepository определяет операции с outbox - очередью событий, записанных вместе с изменениями
+//
+//go:generate mockery --name=OutboxRepository --output=../mocks --outpkg=mocks --filename=outbox_repository_mock.go
+type OutboxRepository interface {
+	// Add записывает событие в outbox и заполняет его ID
+	Add(ctx context.Context, event *domain.OutboxEvent) error
+
+	// ClaimDue блокирует самое старое неопубликованное событие, время попытки которого наступило
+	// (ErrNotFound если таких нет). События, заблокированные другими транзакциями, пропускаются.
+	ClaimDue(ctx context.Context, now time.Time) (*domain.OutboxEvent, error)
+
+	// Update сохраняет результат попытки публикации
+	Update(ctx context.Context, event *domain.OutboxEvent) error
+}
+
+// IngestionRepository определяет операции с журналом доставок событий из внешних систем
+//
+//go:generate mockery --name=IngestionRepository --output=../mocks --outpkg=mocks --filename=ingestion_repository_mock.go
+type IngestionRepository interface {
+	// ClaimDelivery занимает доставку для обработки (статус PENDING). Доставка в PENDING,
+	// занятая раньше staleBefore, занимается заново. Возвращает false, если доставка
+	// обработана или занята после staleBefore.
+	ClaimDelivery(ctx context.Context, delivery *domain.IngestedDelivery, staleBefore time.Time) (bool, error)
+
+	// CompleteDelivery отмечает занятую доставку обработанной (статус DONE)
+	CompleteDelivery(ctx context.Context, source domain.IngestionSource, deliveryID string) error
+
+	// ReleaseDelivery снимает занятую, но не обработанную доставку, чтобы её можно было повторить
+	ReleaseDelivery(ctx context.Context, source domain.IngestionSource, deliveryID string) error
+}
+
+// RepositoryRepository определяет операции с зарегистрированными репозиториями и их командами-владельцами
+//
+//go:generate mockery --name=RepositoryRepository --output=../mocks --outpkg=mocks --filename=repository_repository_mock.go
+type RepositoryRepository interface {
+	// Get возвращает репозиторий с командами-владельцами в порядке приоритета (ErrNotFound если не зарегистрирован)
+	Get(ctx context.Context, name string) (*domain.Repository, error)
+
+	// List возвращает все репозитории с командами-владельцами, отсортированные по имени
+	List(ctx context.Context) ([]domain.Repository, error)
+
+	// Upsert создаёт или обновляет репозиторий, заменяя список команд-владельцев
+	Upsert(ctx context.Context, repository *domain.Repository) error
+}
+
+// CodeOwnersRepository определяет операции с CODEOWNERS репозиториев
+//
+//go:generate mockery --name=CodeOwnersRepository --output=../mocks --outpkg=mocks --filename=code_owners_repository_mock.go
+type CodeOwnersRepository interface {
+	// Get возвращает CODEOWNERS репозитория (ErrNotFound если не зарегистрирован)
+	Get(ctx context.Context, repository string) (*domain.RepositoryCodeOwners, error)
+
+	// Upsert регистрирует CODEOWNERS репозитория, заменяя предыдущий
+	Upsert(ctx context.Context, codeOwners *domain.RepositoryCodeOwners) error
 }
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS team_settings (
+    team_name TEXT PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+    reviewer_count INTEGER NOT NULL DEFAULT 2 CHECK (reviewer_count >= 0),
+    min_reviewers INTEGER NOT NULL DEFAULT 0 CHECK (min_reviewers >= 0),
+    selection_strategy TEXT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    CHECK (min_reviewers <= reviewer_count)
+);
+
+CREATE TRIGGER update_team_settings_updated_at
+BEFORE UPDATE ON team_settings
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
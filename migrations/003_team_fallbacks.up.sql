@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS team_fallbacks (
+    team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    fallback_team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    priority INTEGER NOT NULL,
+    PRIMARY KEY (team_name, fallback_team_name),
+    UNIQUE (team_name, priority),
+    CHECK (team_name <> fallback_team_name)
+);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS user_absences (
+    absence_id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    reassigned_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_absences_user_period ON user_absences(user_id, starts_at, ends_at);
+CREATE INDEX IF NOT EXISTS idx_user_absences_pending ON user_absences(starts_at) WHERE reassigned_at IS NULL;
+
+CREATE TRIGGER update_user_absences_updated_at
+BEFORE UPDATE ON user_absences
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER NULL CHECK (max_open_reviews > 0);
+
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER NULL CHECK (max_open_reviews > 0),
+    ADD COLUMN IF NOT EXISTS capacity_policy TEXT NOT NULL DEFAULT 'assign_least_loaded'
+        CHECK (capacity_policy IN ('reject', 'assign_least_loaded'));
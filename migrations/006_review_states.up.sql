@@ -0,0 +1,5 @@
+CREATE TYPE review_state AS ENUM ('PENDING', 'APPROVED', 'CHANGES_REQUESTED', 'DISMISSED');
+
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN IF NOT EXISTS state review_state NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN IF NOT EXISTS state_updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS pull_request_events (
+    event_id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+    event_type TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    old_reviewer_id TEXT NULL,
+    new_reviewer_id TEXT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_events_pr ON pull_request_events(pull_request_id, event_id);
+
+-- История только дополняется: изменение и удаление событий запрещены
+CREATE OR REPLACE FUNCTION forbid_pull_request_event_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'pull_request_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pull_request_events_append_only
+BEFORE UPDATE OR DELETE ON pull_request_events
+FOR EACH ROW
+EXECUTE FUNCTION forbid_pull_request_event_change();
@@ -0,0 +1,5 @@
+-- Индексы для keyset-пагинации списка PR: (поле сортировки, pull_request_id)
+CREATE INDEX IF NOT EXISTS idx_pr_created_at ON pull_requests(created_at, pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_pr_status_created_at ON pull_requests(status, created_at, pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_pr_author_created_at ON pull_requests(author_id, created_at, pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_pr_merged_at ON pull_requests(merged_at, pull_request_id) WHERE merged_at IS NOT NULL;
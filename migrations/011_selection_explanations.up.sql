@@ -0,0 +1,12 @@
+-- Объяснения выбора ревьюверов: пул кандидатов, исключённые с причинами и стратегии команд.
+-- Подробности хранятся в JSONB, так как их структура зависит от числа рассмотренных команд.
+CREATE TABLE IF NOT EXISTS reviewer_selection_explanations (
+    explanation_id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+    trigger TEXT NOT NULL,
+    replaced_reviewer_id TEXT NULL,
+    details JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_selection_explanations_pr ON reviewer_selection_explanations(pull_request_id, explanation_id);
@@ -0,0 +1,35 @@
+-- Подписки внешних систем на события сервиса
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    subscription_id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Фильтр событий подписки: подписка без строк получает все события
+CREATE TABLE IF NOT EXISTS webhook_subscription_events (
+    subscription_id BIGINT NOT NULL REFERENCES webhook_subscriptions(subscription_id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    PRIMARY KEY (subscription_id, event_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscription_events_type ON webhook_subscription_events(event_type);
+
+-- Журнал доставок: одна строка на событие и подписчика, попытки повторяются до DELIVERED или FAILED
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    delivery_id BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT NOT NULL REFERENCES webhook_subscriptions(subscription_id) ON DELETE CASCADE,
+    event_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'DELIVERED', 'FAILED')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_status_code INTEGER NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, delivery_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
@@ -0,0 +1,9 @@
+-- Доставки событий PR из внешних систем (GitHub): повтор доставки с тем же ID не применяется
+CREATE TABLE IF NOT EXISTS ingested_deliveries (
+    source TEXT NOT NULL,
+    delivery_id TEXT NOT NULL,
+    action TEXT NOT NULL,
+    pull_request_id TEXT NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (source, delivery_id)
+);
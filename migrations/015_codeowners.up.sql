@@ -0,0 +1,12 @@
+-- CODEOWNERS репозиториев: владельцы изменённых файлов назначаются ревьюверами в первую очередь
+CREATE TABLE IF NOT EXISTS repository_codeowners (
+    repository TEXT PRIMARY KEY,
+    content TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Репозиторий и изменённые файлы PR нужны при назначении ревьюверов, в том числе когда черновик становится OPEN.
+-- codeowners - CODEOWNERS, переданный при создании PR; NULL - используется зарегистрированный для репозитория.
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS repository TEXT NULL;
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS changed_files JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS codeowners TEXT NULL;
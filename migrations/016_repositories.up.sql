@@ -0,0 +1,17 @@
+-- Зарегистрированные репозитории: ревьюверы PR в репозиторий выбираются из его команд-владельцев, а не из команды автора
+CREATE TABLE IF NOT EXISTS repositories (
+    name TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS repository_owner_teams (
+    repository TEXT NOT NULL REFERENCES repositories(name) ON DELETE CASCADE,
+    team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    priority INTEGER NOT NULL,
+    PRIMARY KEY (repository, team_name),
+    UNIQUE (repository, priority)
+);
+
+-- pull_requests.repository (015) не ссылается на repositories: PR может указывать незарегистрированный репозиторий
+CREATE INDEX IF NOT EXISTS idx_pull_requests_repository ON pull_requests(repository);
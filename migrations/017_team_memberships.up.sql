@@ -0,0 +1,24 @@
+-- Членство пользователей в командах: пользователь может состоять в нескольких командах.
+-- users.team_name остаётся основной командой пользователя - командой его PR по умолчанию.
+CREATE TABLE IF NOT EXISTS team_memberships (
+    team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    role TEXT NOT NULL DEFAULT 'MEMBER' CHECK (role IN ('MEMBER', 'MAINTAINER')),
+    -- Участник рассматривается в команде, только если активны и членство, и сам пользователь
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_name, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_memberships_user ON team_memberships(user_id);
+
+INSERT INTO team_memberships (team_name, user_id)
+SELECT team_name, user_id FROM users
+ON CONFLICT DO NOTHING;
+
+-- Команда, от имени которой создан PR: её настройки и участники выбирают ревьюверов
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS team_name TEXT REFERENCES teams(team_name) ON DELETE SET NULL;
+
+UPDATE pull_requests SET team_name = users.team_name
+FROM users
+WHERE users.user_id = pull_requests.author_id AND pull_requests.team_name IS NULL;
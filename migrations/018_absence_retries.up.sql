@@ -0,0 +1,4 @@
+-- Неудачное переназначение ревью по периоду отсутствия откладывается, чтобы не блокировать остальные периоды
+ALTER TABLE user_absences ADD COLUMN IF NOT EXISTS reassign_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE user_absences ADD COLUMN IF NOT EXISTS last_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE user_absences ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ NULL;
@@ -0,0 +1,3 @@
+-- Доставка занимается до применения действия (PENDING) и отмечается обработанной после него (DONE),
+-- поэтому одновременные повторы не применяют действие дважды
+ALTER TABLE ingested_deliveries ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'DONE';
@@ -0,0 +1,3 @@
+-- Время захвата доставки: PENDING старше срока аренды снова может занять повтор доставки,
+-- иначе доставка, чей обработчик упал, навсегда отвечала бы duplicate
+ALTER TABLE ingested_deliveries ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMPTZ NOT NULL DEFAULT now();
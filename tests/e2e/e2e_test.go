@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
 	"testing"
 	"time"
 
@@ -55,11 +57,13 @@ func TestMain(m *testing.M) {
 	_ = os.Setenv("USER_TOKEN", "user-token-e2e")
 
 	// Загружаем конфигурацию
-	cfg := config.NewEnvConfig()
+	cfg, err := config.NewEnvConfig()
+	if err != nil {
+		panic(fmt.Sprintf("failed to load config: %v", err))
+	}
 	baseURL = fmt.Sprintf("http://localhost:%s", cfg.Port)
 
 	// Подключаемся к БД и применяем миграции
-	var err error
 	testDB, err = connectTestDB(cfg)
 	if err != nil {
 		panic(fmt.Sprintf("failed to connect to test DB: %v", err))
@@ -139,8 +143,9 @@ func connectTestDB(cfg *config.Config) (*gormlib.DB, error) {
 	return db, nil
 }
 
-// applyMigrations применяет SQL миграции напрямую
+// applyMigrations применяет SQL миграции из каталога migrations напрямую
 func applyMigrations(db *gormlib.DB) error {
+	// Проверяем, нужно ли применять миграции
 	var exists bool
 	err := db.Raw("SELECT EXISTS (SELECT FROM information_schema.tables WHERE table_name = 'teams')").Scan(&exists).Error
 	if err != nil {
@@ -151,68 +156,24 @@ func applyMigrations(db *gormlib.DB) error {
 		return nil
 	}
 
-	migrationSQL := `
-CREATE TABLE IF NOT EXISTS teams (
-    team_name TEXT PRIMARY KEY,
-    created_at TIMESTAMPTZ DEFAULT now(),
-    updated_at TIMESTAMPTZ DEFAULT now()
-);
-
-CREATE TABLE IF NOT EXISTS users (
-    user_id TEXT PRIMARY KEY,
-    username TEXT NOT NULL,
-    team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
-    is_active BOOLEAN NOT NULL DEFAULT TRUE,
-    created_at TIMESTAMPTZ DEFAULT now(),
-    updated_at TIMESTAMPTZ DEFAULT now()
-);
-
-DO $$ BEGIN
-    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'pr_status') THEN
-        CREATE TYPE pr_status AS ENUM ('OPEN', 'MERGED');
-    END IF;
-END $$;
-
-CREATE TABLE IF NOT EXISTS pull_requests (
-    pull_request_id TEXT PRIMARY KEY,
-    pull_request_name TEXT NOT NULL,
-    author_id TEXT NOT NULL REFERENCES users(user_id),
-    status pr_status NOT NULL DEFAULT 'OPEN',
-    created_at TIMESTAMPTZ DEFAULT now(),
-    updated_at TIMESTAMPTZ DEFAULT now(),
-    merged_at TIMESTAMPTZ NULL
-);
-
-CREATE TABLE IF NOT EXISTS pull_request_reviewers (
-    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
-    reviewer_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
-    PRIMARY KEY (pull_request_id, reviewer_id)
-);
-
-CREATE INDEX IF NOT EXISTS idx_pr_reviewers_reviewer ON pull_request_reviewers(reviewer_id);
-CREATE INDEX IF NOT EXISTS idx_pr_status ON pull_requests(status);
-CREATE INDEX IF NOT EXISTS idx_users_team_active ON users(team_name, is_active);
-CREATE INDEX IF NOT EXISTS idx_pr_author ON pull_requests(author_id);
-
-CREATE OR REPLACE FUNCTION update_updated_at_column()
-RETURNS TRIGGER AS $$
-BEGIN
-   NEW.updated_at = now();
-   RETURN NEW;
-END;
-$$ language 'plpgsql';
-
-DROP TRIGGER IF EXISTS update_teams_updated_at ON teams;
-CREATE TRIGGER update_teams_updated_at BEFORE UPDATE ON teams FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-
-DROP TRIGGER IF EXISTS update_users_updated_at ON users;
-CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON users FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-
-DROP TRIGGER IF EXISTS update_pull_requests_updated_at ON pull_requests;
-CREATE TRIGGER update_pull_requests_updated_at BEFORE UPDATE ON pull_requests FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-`
-
-	return db.Exec(migrationSQL).Error
+	// Применяем миграции в порядке номеров
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.up.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		migrationSQL, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := db.Exec(string(migrationSQL)).Error; err != nil {
+			return fmt.Errorf("migration %s: %w", filepath.Base(file), err)
+		}
+	}
+
+	return nil
 }
 
 // setupTest очищает БД перед каждым тестом
@@ -3,12 +3,20 @@ package integration
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,9 +35,10 @@ import (
 )
 
 var (
-	testDB      *gormlib.DB
-	testService domain.AssignmentService
-	testRouter  *gin.Engine
+	testDB         *gormlib.DB
+	testService    domain.AssignmentService
+	testRouter     *gin.Engine
+	testOutboxSink = service.NewMemorySink()
 )
 
 // TestMain настраивает тестовое окружение
@@ -50,12 +59,16 @@ func TestMain(m *testing.M) {
 	_ = os.Setenv("PRODUCTION_TYPE", "test")
 	_ = os.Setenv("ADMIN_TOKEN", "admin")
 	_ = os.Setenv("USER_TOKEN", "user")
+	_ = os.Setenv("GITHUB_WEBHOOK_SECRET", "gh-secret")
+	_ = os.Setenv("GITLAB_WEBHOOK_TOKEN", "gl-token")
 
 	// Загружаем конфигурацию
-	cfg := config.NewEnvConfig()
+	cfg, err := config.NewEnvConfig()
+	if err != nil {
+		panic(fmt.Sprintf("failed to load config: %v", err))
+	}
 
 	// Подключаемся к БД и применяем миграции
-	var err error
 	testDB, err = connectTestDB(cfg)
 	if err != nil {
 		panic(fmt.Sprintf("failed to connect to test DB: %v", err))
@@ -66,7 +79,17 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		panic(fmt.Sprintf("failed to create tx manager: %v", err))
 	}
-	testService = service.New(txManager)
+	// Повторы публикации и доставки событий с короткой задержкой, чтобы тесты не ждали
+	testService = service.New(txManager,
+		service.WithWebhooks(&http.Client{Timeout: 5 * time.Second}, 3, 50*time.Millisecond),
+		service.WithOutbox(50*time.Millisecond, service.NewWebhookSink(txManager), testOutboxSink),
+		service.WithExternalUsers(domain.IngestionSourceGitHub, map[string]string{"octocat": "test-user-github-0"}),
+		service.WithExternalUsers(domain.IngestionSourceGitLab, map[string]string{
+			"jdoe":  "test-user-gitlab-0",
+			"alice": "test-user-gitlab-1",
+			"bob":   "test-user-gitlab-2",
+		}),
+	)
 
 	// Создаём роутер
 	gin.SetMode(gin.TestMode)
@@ -120,7 +143,7 @@ func connectTestDB(cfg *config.Config) (*gormlib.DB, error) {
 	return db, nil
 }
 
-// applyMigrations применяет SQL миграции напрямую
+// applyMigrations применяет SQL миграции из каталога migrations напрямую
 func applyMigrations(db *gormlib.DB) error {
 	// Проверяем, нужно ли применять миграции
 	var exists bool
@@ -133,69 +156,24 @@ func applyMigrations(db *gormlib.DB) error {
 		return nil
 	}
 
-	// Применяем миграцию
-	migrationSQL := `
-CREATE TABLE IF NOT EXISTS teams (
-    team_name TEXT PRIMARY KEY,
-    created_at TIMESTAMPTZ DEFAULT now(),
-    updated_at TIMESTAMPTZ DEFAULT now()
-);
-
-CREATE TABLE IF NOT EXISTS users (
-    user_id TEXT PRIMARY KEY,
-    username TEXT NOT NULL,
-    team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
-    is_active BOOLEAN NOT NULL DEFAULT TRUE,
-    created_at TIMESTAMPTZ DEFAULT now(),
-    updated_at TIMESTAMPTZ DEFAULT now()
-);
-
-DO $$ BEGIN
-    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'pr_status') THEN
-        CREATE TYPE pr_status AS ENUM ('OPEN', 'MERGED');
-    END IF;
-END $$;
-
-CREATE TABLE IF NOT EXISTS pull_requests (
-    pull_request_id TEXT PRIMARY KEY,
-    pull_request_name TEXT NOT NULL,
-    author_id TEXT NOT NULL REFERENCES users(user_id),
-    status pr_status NOT NULL DEFAULT 'OPEN',
-    created_at TIMESTAMPTZ DEFAULT now(),
-    updated_at TIMESTAMPTZ DEFAULT now(),
-    merged_at TIMESTAMPTZ NULL
-);
-
-CREATE TABLE IF NOT EXISTS pull_request_reviewers (
-    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
-    reviewer_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
-    PRIMARY KEY (pull_request_id, reviewer_id)
-);
-
-CREATE INDEX IF NOT EXISTS idx_pr_reviewers_reviewer ON pull_request_reviewers(reviewer_id);
-CREATE INDEX IF NOT EXISTS idx_pr_status ON pull_requests(status);
-CREATE INDEX IF NOT EXISTS idx_users_team_active ON users(team_name, is_active);
-CREATE INDEX IF NOT EXISTS idx_pr_author ON pull_requests(author_id);
-
-CREATE OR REPLACE FUNCTION update_updated_at_column()
-RETURNS TRIGGER AS $$
-BEGIN
-   NEW.updated_at = now();
-   RETURN NEW;
-END;
-$$ language 'plpgsql';
-
-DROP TRIGGER IF EXISTS update_teams_updated_at ON teams;
-CREATE TRIGGER update_teams_updated_at BEFORE UPDATE ON teams FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-
-DROP TRIGGER IF EXISTS update_users_updated_at ON users;
-CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON users FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-
-DROP TRIGGER IF EXISTS update_pull_requests_updated_at ON pull_requests;
-CREATE TRIGGER update_pull_requests_updated_at BEFORE UPDATE ON pull_requests FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-`
-
-	return db.Exec(migrationSQL).Error
+	// Применяем миграции в порядке номеров
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.up.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		migrationSQL, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := db.Exec(string(migrationSQL)).Error; err != nil {
+			return fmt.Errorf("migration %s: %w", filepath.Base(file), err)
+		}
+	}
+
+	return nil
 }
 
 // setupTest очищает БД перед каждым тестом
@@ -204,8 +182,16 @@ func setupTest(t *testing.T) {
 
 	// Очищаем таблицы в правильном порядке (FK constraints)
 	tables := []string{
+		"outbox",
+		"ingested_deliveries",
+		"repository_codeowners",
+		"repository_owner_teams",
+		"repositories",
+		"webhook_subscriptions",
+		"pull_request_events",
 		"pull_request_reviewers",
 		"pull_requests",
+		"team_memberships",
 		"users",
 		"teams",
 	}
@@ -251,6 +237,20 @@ func createTestTeam(t *testing.T, teamName string, userCount int) []string {
 	return userIDs
 }
 
+// loadTestUser читает основную команду и активность пользователя напрямую из БД
+func loadTestUser(t *testing.T, userID string) domain.User {
+	t.Helper()
+
+	var user domain.User
+	err := testDB.Table("users").
+		Select("user_id, username, team_name, is_active").
+		Where("user_id = ?", userID).
+		Take(&user).Error
+	require.NoError(t, err, "failed to load user %s", userID)
+
+	return user
+}
+
 // createTestPR создаёт тестовый PR
 func createTestPR(t *testing.T, prID, authorID string) *domain.PullRequest {
 	t.Helper()
@@ -316,7 +316,7 @@ func TestTeamAdd_UpsertUsers(t *testing.T) {
 	testRouter.ServeHTTP(w1, req1)
 	require.Equal(t, http.StatusCreated, w1.Code)
 
-	// Создаём другую команду с тем же пользователем (должен переместиться)
+	// Создаём другую команду с тем же пользователем (остаётся и в первой команде)
 	reqBody2 := map[string]interface{}{
 		"team_name": "team2",
 		"members": []map[string]interface{}{
@@ -340,6 +340,17 @@ func TestTeamAdd_UpsertUsers(t *testing.T) {
 	assert.Equal(t, "u1", member.UserID)
 	assert.Equal(t, "Alice Updated", member.Username)
 	assert.Equal(t, false, member.IsActive) // Критично! Проверяем is_active=false
+
+	// Членство в первой команде не потеряно, основная команда и активность пользователя не изменились
+	team, err = testService.GetTeam(ctx, "team1")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(team.Members))
+	assert.Equal(t, "u1", team.Members[0].UserID)
+	assert.True(t, team.Members[0].IsActive)
+
+	user := loadTestUser(t, "u1")
+	assert.Equal(t, "team1", user.TeamName)
+	assert.True(t, user.IsActive)
 }
 
 // TestTeamAdd_IsActiveFalse проверяет что is_active=false сохраняется правильно
@@ -686,6 +697,106 @@ func TestPullRequestCreate_NoReviewers(t *testing.T) {
 	assert.Equal(t, 0, len(reviewers))
 }
 
+// TestPullRequestCreate_TeamSettings проверяет назначение по настройкам команды
+func TestPullRequestCreate_TeamSettings(t *testing.T) {
+	setupTest(t)
+
+	// Команда из 5 участников: 4 кандидата кроме автора
+	userIDs := createTestTeam(t, "platform", 5)
+	authorID := userIDs[0]
+
+	// Требуем 3 ревьюверов
+	settingsBody, _ := json.Marshal(map[string]interface{}{
+		"team_name":      "platform",
+		"reviewer_count": 3,
+		"min_reviewers":  3,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/team/settings/set", bytes.NewReader(settingsBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin")
+
+	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Создаём PR - должно быть назначено ровно 3 ревьювера
+	pr := createTestPR(t, "pr-settings-1", authorID)
+	assert.Len(t, pr.AssignedReviewers, 3)
+
+	// Деактивируем двух участников - кандидатов остаётся меньше минимума
+	_, err := testService.SetUserIsActive(context.Background(), &domain.SetUserActiveInput{UserID: userIDs[1], IsActive: false})
+	require.NoError(t, err)
+	_, err = testService.SetUserIsActive(context.Background(), &domain.SetUserActiveInput{UserID: userIDs[2], IsActive: false})
+	require.NoError(t, err)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"pull_request_id":   "pr-settings-2",
+		"pull_request_name": "Not enough reviewers",
+		"author_id":         authorID,
+	})
+	req = httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin")
+
+	w = httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	errorObj := response["error"].(map[string]interface{})
+	assert.Equal(t, "NOT_ENOUGH_REVIEWERS", errorObj["code"])
+}
+
+// TestPullRequestReassign_FallbackTeam проверяет выбор ревьювера из резервной команды
+func TestPullRequestReassign_FallbackTeam(t *testing.T) {
+	setupTest(t)
+
+	// Маленькая команда: автор и один ревьювер
+	smallIDs := createTestTeam(t, "small", 2)
+	helperIDs := createTestTeam(t, "helpers", 2)
+	authorID := smallIDs[0]
+
+	settingsBody, _ := json.Marshal(map[string]interface{}{
+		"team_name":      "small",
+		"reviewer_count": 1,
+		"fallback_teams": []string{"helpers"},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/team/settings/set", bytes.NewReader(settingsBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin")
+
+	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Единственный кандидат своей команды назначается без резерва
+	pr := createTestPR(t, "pr-fallback", authorID)
+	require.Equal(t, []string{smallIDs[1]}, pr.AssignedReviewers)
+	assert.Equal(t, "small", pr.ReviewerTeams[smallIDs[1]])
+
+	// При переназначении в своей команде кандидатов нет - берём из helpers
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"pull_request_id": "pr-fallback",
+		"old_reviewer_id": smallIDs[1],
+	})
+	req = httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin")
+
+	w = httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Contains(t, helperIDs, response["replaced_by"])
+	assert.Equal(t, "helpers", response["replaced_by_team"])
+}
+
 // TestPullRequestCreate_OnlyActiveReviewers проверяет что назначаются только активные
 func TestPullRequestCreate_OnlyActiveReviewers(t *testing.T) {
 	setupTest(t)
@@ -975,7 +1086,7 @@ func TestReassignInactive_Success(t *testing.T) {
 	for _, reviewerID := range pr.AssignedReviewers {
 		if reviewerID == "active-1" || reviewerID == "active-2" {
 			// Деактивируем
-			_, err := testService.SetUserIsActive(ctx, reviewerID, false)
+			_, err := testService.SetUserIsActive(ctx, &domain.SetUserActiveInput{UserID: reviewerID, IsActive: false})
 			require.NoError(t, err)
 		}
 	}
@@ -1019,6 +1130,48 @@ func TestReassignInactive_Success(t *testing.T) {
 	}
 }
 
+// TestSetIsActive_ReassignOpenReviews проверяет переназначение открытых ревью при деактивации
+func TestSetIsActive_ReassignOpenReviews(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+
+	// Команда из четырёх: автор, два ревьювера и свободный участник для замены
+	members := createTestTeam(t, "deactivate-reassign", 4)
+	pr := createTestPR(t, "pr-deactivate-reassign", members[0])
+	require.Len(t, pr.AssignedReviewers, 2)
+	leaving := pr.AssignedReviewers[0]
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"user_id":               leaving,
+		"is_active":             false,
+		"reassign_open_reviews": true,
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin")
+
+	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.False(t, response["is_active"].(bool))
+	reassignments := response["reassignments"].([]interface{})
+	require.Len(t, reassignments, 1)
+	first := reassignments[0].(map[string]interface{})
+	assert.Equal(t, pr.ID, first["pull_request_id"])
+
+	// Уходящий ревьювер заменён в той же транзакции
+	details, err := testService.GetPullRequest(ctx, pr.ID)
+	require.NoError(t, err)
+	assert.NotContains(t, details.AssignedReviewers, leaving)
+	assert.Len(t, details.AssignedReviewers, 2)
+}
+
 // TestReassignInactive_NoInactive проверяет что ничего не происходит если нет неактивных
 func TestReassignInactive_NoInactive(t *testing.T) {
 	setupTest(t)
@@ -1112,7 +1265,7 @@ func TestReassignInactive_RemovesWhenNoCandidates(t *testing.T) {
 	require.Equal(t, "reviewer", pr.AssignedReviewers[0])
 
 	// Деактивируем ревьювера
-	_, err = testService.SetUserIsActive(ctx, "reviewer", false)
+	_, err = testService.SetUserIsActive(ctx, &domain.SetUserActiveInput{UserID: "reviewer", IsActive: false})
 	require.NoError(t, err)
 
 	// Переназначаем - должен быть удалён (нет кандидатов)
@@ -1142,6 +1295,60 @@ func TestReassignInactive_RemovesWhenNoCandidates(t *testing.T) {
 	assert.True(t, detail["was_removed"].(bool))
 }
 
+// TestUserAbsence_ExcludedAndReassigned проверяет исключение отсутствующих из выбора и переназначение их ревью
+func TestUserAbsence_ExcludedAndReassigned(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	// Автор и 3 кандидата: на PR назначаются двое, третий свободен
+	userIDs := createTestTeam(t, "absence", 4)
+	authorID := userIDs[0]
+
+	pr := createTestPR(t, "pr-absence", authorID)
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	// Один из ревьюверов уходит в отпуск прямо сейчас
+	absentID := pr.AssignedReviewers[0]
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"user_id":   absentID,
+		"starts_at": time.Now().Add(-time.Minute).Format(time.RFC3339),
+		"ends_at":   time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+		"reason":    "vacation",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/users/absence/add", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin")
+
+	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// Фоновая задача заменяет отсутствующего ревьювера свободным участником
+	svc := testService.(*service.Service)
+	processed, err := svc.ReassignAbsentReviewers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	reviews, err := testService.GetReviewerAssignments(ctx, &domain.ReviewerAssignmentsInput{UserID: absentID})
+	require.NoError(t, err)
+	assert.Empty(t, reviews.PullRequests)
+
+	// Повторный запуск ничего не делает
+	processed, err = svc.ReassignAbsentReviewers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+
+	absences, err := testService.GetUserAbsences(ctx, absentID)
+	require.NoError(t, err)
+	require.Len(t, absences, 1)
+	assert.NotNil(t, absences[0].ReassignedAt)
+
+	// Новый PR не получает отсутствующего ревьювера
+	second := createTestPR(t, "pr-absence-2", authorID)
+	assert.Len(t, second.AssignedReviewers, 2)
+	assert.NotContains(t, second.AssignedReviewers, absentID)
+}
+
 // TestAuth_RequiresAdmin проверяет что административные эндпоинты требуют admin токен
 func TestAuth_RequiresAdmin(t *testing.T) {
 	setupTest(t)
@@ -1195,3 +1402,1371 @@ func TestAuth_RequiresAdmin(t *testing.T) {
 		})
 	}
 }
+
+// TestPullRequestCreate_ReviewCapacity проверяет лимит открытых ревью и политику команды при его превышении
+func TestPullRequestCreate_ReviewCapacity(t *testing.T) {
+	setupTest(t)
+
+	// Команда из автора и одного ревьювера, лимит - одно открытое ревью
+	userIDs := createTestTeam(t, "capacity", 2)
+	authorID := userIDs[0]
+	reviewerID := userIDs[1]
+
+	settingsBody, _ := json.Marshal(map[string]interface{}{
+		"team_name":        "capacity",
+		"reviewer_count":   1,
+		"max_open_reviews": 1,
+		"capacity_policy":  "reject",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/team/settings/set", bytes.NewReader(settingsBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin")
+
+	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	pr := createTestPR(t, "pr-capacity-1", authorID)
+	require.Equal(t, []string{reviewerID}, pr.AssignedReviewers)
+
+	// Ревьювер на пределе - команда запрещает превышение
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"pull_request_id":   "pr-capacity-2",
+		"pull_request_name": "Over capacity",
+		"author_id":         authorID,
+	})
+	req = httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin")
+
+	w = httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	errorObj := response["error"].(map[string]interface{})
+	assert.Equal(t, "ALL_AT_CAPACITY", errorObj["code"])
+
+	// Нагрузка и лимит видны в /users/getReview
+	req = httptest.NewRequest(http.MethodGet, "/users/getReview?user_id="+reviewerID, nil)
+	req.Header.Set("Authorization", "Bearer user")
+
+	w = httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	response = nil
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, float64(1), response["open_reviews"])
+	assert.Equal(t, float64(1), response["max_open_reviews"])
+
+	// После смены политики назначается наименее загруженный сверх лимита
+	policy := domain.CapacityPolicyAssignLeastLoaded
+	_, err := testService.SetTeamSettings(context.Background(), &domain.SetTeamSettingsInput{
+		TeamName:       "capacity",
+		CapacityPolicy: &policy,
+	})
+	require.NoError(t, err)
+
+	pr = createTestPR(t, "pr-capacity-3", authorID)
+	assert.Equal(t, []string{reviewerID}, pr.AssignedReviewers)
+}
+
+// TestPullRequestReview_States проверяет сохранение решений ревьюверов
+func TestPullRequestReview_States(t *testing.T) {
+	setupTest(t)
+
+	userIDs := createTestTeam(t, "reviewing", 3)
+	pr := createTestPR(t, "pr-review", userIDs[0])
+	require.Len(t, pr.AssignedReviewers, 2)
+	reviewerID := pr.AssignedReviewers[0]
+
+	submit := func(reviewer, state string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"pull_request_id": "pr-review",
+			"reviewer_id":     reviewer,
+			"state":           state,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer admin")
+
+		w := httptest.NewRecorder()
+		testRouter.ServeHTTP(w, req)
+		return w
+	}
+
+	// Решение может оставить только назначенный ревьювер
+	w := submit(userIDs[0], "APPROVED")
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = submit(reviewerID, "CHANGES_REQUESTED")
+	require.Equal(t, http.StatusOK, w.Code)
+	w = submit(reviewerID, "APPROVED")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	states := response["pr"].(map[string]interface{})["reviewer_states"].([]interface{})
+	require.Len(t, states, 2)
+
+	byReviewer := make(map[string]string)
+	for _, s := range states {
+		state := s.(map[string]interface{})
+		byReviewer[state["reviewer_id"].(string)] = state["state"].(string)
+		assert.NotNil(t, state["updated_at"])
+	}
+	assert.Equal(t, "APPROVED", byReviewer[reviewerID])
+	assert.Equal(t, "PENDING", byReviewer[pr.AssignedReviewers[1]])
+
+	// После merge решения больше не принимаются
+	_, err := testService.MergePullRequest(context.Background(), &domain.MergePullRequestInput{PullRequestID: "pr-review"})
+	require.NoError(t, err)
+
+	w = submit(reviewerID, "DISMISSED")
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+// TestPullRequestMerge_RequiresApprovals проверяет блокировку merge без одобрений и принудительный merge
+func TestPullRequestMerge_RequiresApprovals(t *testing.T) {
+	setupTest(t)
+
+	userIDs := createTestTeam(t, "gated", 3)
+
+	required := 2
+	_, err := testService.SetTeamSettings(context.Background(), &domain.SetTeamSettingsInput{
+		TeamName:          "gated",
+		RequiredApprovals: &required,
+	})
+	require.NoError(t, err)
+
+	pr := createTestPR(t, "pr-gated", userIDs[0])
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	merge := func(force bool) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"pull_request_id": "pr-gated",
+			"force":           force,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer admin")
+
+		w := httptest.NewRecorder()
+		testRouter.ServeHTTP(w, req)
+		return w
+	}
+
+	// Одного одобрения недостаточно
+	_, err = testService.SubmitReview(context.Background(), &domain.SubmitReviewInput{
+		PullRequestID: "pr-gated",
+		ReviewerID:    pr.AssignedReviewers[0],
+		State:         domain.ReviewStateApproved,
+	})
+	require.NoError(t, err)
+
+	w := merge(false)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "NOT_APPROVED", response["error"].(map[string]interface{})["code"])
+
+	// Администратор мержит принудительно, это сохраняется в PR
+	w = merge(true)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	response = nil
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	mergedPR := response["pr"].(map[string]interface{})
+	assert.Equal(t, "MERGED", mergedPR["status"])
+	assert.Equal(t, true, mergedPR["merge_forced"])
+}
+
+// TestPullRequestLifecycle_DraftReadyCloseReopen проверяет переходы DRAFT -> OPEN -> CLOSED -> OPEN
+func TestPullRequestLifecycle_DraftReadyCloseReopen(t *testing.T) {
+	setupTest(t)
+
+	userIDs := createTestTeam(t, "lifecycle", 3)
+
+	post := func(route string, payload map[string]interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest"+route, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer admin")
+
+		w := httptest.NewRecorder()
+		testRouter.ServeHTTP(w, req)
+		return w
+	}
+	prOf := func(w *httptest.ResponseRecorder) map[string]interface{} {
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return response["pr"].(map[string]interface{})
+	}
+
+	// Черновик создаётся без ревьюверов
+	w := post("/create", map[string]interface{}{
+		"pull_request_id":   "pr-draft",
+		"pull_request_name": "Draft feature",
+		"author_id":         userIDs[0],
+		"draft":             true,
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	pr := prOf(w)
+	assert.Equal(t, "DRAFT", pr["status"])
+	assert.Empty(t, pr["assigned_reviewers"])
+
+	// Черновик нельзя смержить
+	w = post("/merge", map[string]interface{}{"pull_request_id": "pr-draft", "force": true})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// При переводе в OPEN назначаются ревьюверы
+	w = post("/ready", map[string]interface{}{"pull_request_id": "pr-draft"})
+	require.Equal(t, http.StatusOK, w.Code)
+	pr = prOf(w)
+	assert.Equal(t, "OPEN", pr["status"])
+	assert.Len(t, pr["assigned_reviewers"], 2)
+
+	w = post("/ready", map[string]interface{}{"pull_request_id": "pr-draft"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Закрытый PR не принимает переназначения
+	w = post("/close", map[string]interface{}{"pull_request_id": "pr-draft"})
+	require.Equal(t, http.StatusOK, w.Code)
+	pr = prOf(w)
+	assert.Equal(t, "CLOSED", pr["status"])
+	assert.NotNil(t, pr["closed_at"])
+
+	w = post("/reassignInactive", map[string]interface{}{"pull_request_id": "pr-draft"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// После повторного открытия ревьюверы сохраняются
+	w = post("/reopen", map[string]interface{}{"pull_request_id": "pr-draft"})
+	require.Equal(t, http.StatusOK, w.Code)
+	pr = prOf(w)
+	assert.Equal(t, "OPEN", pr["status"])
+	assert.Nil(t, pr["closed_at"])
+	assert.Len(t, pr["assigned_reviewers"], 2)
+}
+
+// TestPullRequestHistory_RecordsTimeline проверяет историю назначений, замен и merge
+func TestPullRequestHistory_RecordsTimeline(t *testing.T) {
+	setupTest(t)
+
+	userIDs := createTestTeam(t, "audited", 4)
+
+	post := func(route string, payload map[string]interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest"+route, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer admin")
+
+		w := httptest.NewRecorder()
+		testRouter.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("/create", map[string]interface{}{
+		"pull_request_id":   "pr-audit",
+		"pull_request_name": "Audited feature",
+		"author_id":         userIDs[0],
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var created map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+	reviewers := created["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+	require.Len(t, reviewers, 2)
+	oldReviewer := reviewers[0].(string)
+
+	w = post("/reassign", map[string]interface{}{"pull_request_id": "pr-audit", "old_user_id": oldReviewer})
+	require.Equal(t, http.StatusOK, w.Code)
+	w = post("/merge", map[string]interface{}{"pull_request_id": "pr-audit", "force": true})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/history?pull_request_id=pr-audit", nil)
+	req.Header.Set("Authorization", "Bearer user")
+	w = httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	events := response["events"].([]interface{})
+	require.Len(t, events, 5)
+
+	types := make([]string, len(events))
+	for i, e := range events {
+		event := e.(map[string]interface{})
+		types[i] = event["event_type"].(string)
+		assert.Equal(t, "admin", event["actor"])
+	}
+	assert.Equal(t, []string{"CREATED", "REVIEWER_ASSIGNED", "REVIEWER_ASSIGNED", "REVIEWER_REPLACED", "MERGED"}, types)
+
+	replaced := events[3].(map[string]interface{})
+	assert.Equal(t, oldReviewer, replaced["old_reviewer_id"])
+	assert.Equal(t, "manual", replaced["reason"])
+	assert.Equal(t, "forced", events[4].(map[string]interface{})["reason"])
+
+	// История только дополняется
+	err := testDB.Exec("DELETE FROM pull_request_events WHERE pull_request_id = ?", "pr-audit").Error
+	assert.Error(t, err)
+}
+
+// TestPullRequestGet_ReturnsReviewerDetails проверяет чтение PR с автором и ревьюверами
+func TestPullRequestGet_ReturnsReviewerDetails(t *testing.T) {
+	setupTest(t)
+
+	userIDs := createTestTeam(t, "readable", 3)
+	pr := createTestPR(t, "pr-read", userIDs[0])
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	_, err := testService.SetUserIsActive(context.Background(), &domain.SetUserActiveInput{UserID: pr.AssignedReviewers[1], IsActive: false})
+	require.NoError(t, err)
+
+	get := func(prID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/get?pull_request_id="+prID, nil)
+		req.Header.Set("Authorization", "Bearer user")
+
+		w := httptest.NewRecorder()
+		testRouter.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("pr-read")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	got := response["pr"].(map[string]interface{})
+
+	assert.Equal(t, "OPEN", got["status"])
+	assert.NotNil(t, got["created_at"])
+	assert.Equal(t, userIDs[0], got["author"].(map[string]interface{})["user_id"])
+
+	reviewers := got["reviewers"].([]interface{})
+	require.Len(t, reviewers, 2)
+	activeByID := make(map[string]bool)
+	for _, r := range reviewers {
+		reviewer := r.(map[string]interface{})
+		assert.NotEmpty(t, reviewer["username"])
+		assert.Equal(t, "PENDING", reviewer["state"])
+		activeByID[reviewer["user_id"].(string)] = reviewer["is_active"].(bool)
+	}
+	assert.True(t, activeByID[pr.AssignedReviewers[0]])
+	assert.False(t, activeByID[pr.AssignedReviewers[1]])
+
+	w = get("pr-missing")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// TestPullRequestList_FiltersAndPagination проверяет фильтры списка PR и обход страниц по курсору
+func TestPullRequestList_FiltersAndPagination(t *testing.T) {
+	setupTest(t)
+
+	backend := createTestTeam(t, "list-backend", 3)
+	frontend := createTestTeam(t, "list-frontend", 3)
+
+	createTestPR(t, "pr-list-1", backend[0])
+	createTestPR(t, "pr-list-2", backend[1])
+	createTestPR(t, "pr-list-3", backend[2])
+	createTestPR(t, "pr-list-4", frontend[0])
+
+	_, err := testService.MergePullRequest(context.Background(), &domain.MergePullRequestInput{PullRequestID: "pr-list-2"})
+	require.NoError(t, err)
+
+	list := func(query string) map[string]interface{} {
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/list?"+query, nil)
+		req.Header.Set("Authorization", "Bearer user")
+
+		w := httptest.NewRecorder()
+		testRouter.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+	ids := func(response map[string]interface{}) []string {
+		var result []string
+		for _, pr := range response["pull_requests"].([]interface{}) {
+			result = append(result, pr.(map[string]interface{})["pull_request_id"].(string))
+		}
+		return result
+	}
+
+	// Открытые PR команды backend по одному на страницу, от старых к новым
+	var collected []string
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		response := list("status=OPEN&team_name=list-backend&order=asc&limit=1&cursor=" + url.QueryEscape(cursor))
+		collected = append(collected, ids(response)...)
+		if response["next_cursor"] == nil {
+			break
+		}
+		cursor = response["next_cursor"].(string)
+	}
+	assert.Equal(t, []string{"pr-list-1", "pr-list-3"}, collected)
+
+	// Сортировка по merged_at оставляет только смерженные PR
+	assert.Equal(t, []string{"pr-list-2"}, ids(list("sort_by=merged_at")))
+
+	// Фильтр по автору
+	assert.Equal(t, []string{"pr-list-4"}, ids(list("author_id="+frontend[0])))
+}
+
+func TestUserGetReview_FiltersAndPagination(t *testing.T) {
+	setupTest(t)
+
+	// В команде из трёх человек оба оставшихся участника ревьюят каждый PR автора
+	members := createTestTeam(t, "review-list", 3)
+	reviewerID := members[1]
+
+	createTestPR(t, "pr-review-1", members[0])
+	createTestPR(t, "pr-review-2", members[0])
+	createTestPR(t, "pr-review-3", members[0])
+
+	_, err := testService.MergePullRequest(context.Background(), &domain.MergePullRequestInput{PullRequestID: "pr-review-2"})
+	require.NoError(t, err)
+
+	getReview := func(query string) map[string]interface{} {
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id="+reviewerID+"&"+query, nil)
+		req.Header.Set("Authorization", "Bearer user")
+
+		w := httptest.NewRecorder()
+		testRouter.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+
+	// Открытые ревью по одному на страницу, от старых к новым
+	var collected []string
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		response := getReview("status=OPEN&limit=1&cursor=" + url.QueryEscape(cursor))
+		for _, pr := range response["pull_requests"].([]interface{}) {
+			item := pr.(map[string]interface{})
+			assert.NotNil(t, item["created_at"])
+			collected = append(collected, item["pull_request_id"].(string))
+		}
+		if response["next_cursor"] == nil {
+			break
+		}
+		cursor = response["next_cursor"].(string)
+	}
+	assert.Equal(t, []string{"pr-review-1", "pr-review-3"}, collected)
+
+	// Без фильтра возвращаются все назначения, включая смерженные
+	response := getReview("")
+	assert.Len(t, response["pull_requests"].([]interface{}), 3)
+	assert.Nil(t, response["next_cursor"])
+}
+
+func TestBulkReassignInactive_DryRunThenApply(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+
+	members := createTestTeam(t, "bulk-reassign", 5)
+	createTestPR(t, "pr-bulk-1", members[0])
+	createTestPR(t, "pr-bulk-2", members[0])
+
+	leaving := members[1]
+	_, err := testService.SetUserIsActive(ctx, &domain.SetUserActiveInput{UserID: leaving, IsActive: false})
+	require.NoError(t, err)
+
+	affected, err := testService.GetReviewerAssignments(ctx, &domain.ReviewerAssignmentsInput{UserID: leaving})
+	require.NoError(t, err)
+
+	bulk := func(dryRun bool) []interface{} {
+		body, _ := json.Marshal(map[string]interface{}{
+			"team_name": "bulk-reassign",
+			"dry_run":   dryRun,
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassignInactiveBulk", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer admin")
+
+		w := httptest.NewRecorder()
+		testRouter.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return response["pull_requests"].([]interface{})
+	}
+
+	// Пробный запуск показывает замены, но ничего не меняет
+	planned := bulk(true)
+	assert.Len(t, planned, len(affected.PullRequests))
+	for _, pr := range planned {
+		item := pr.(map[string]interface{})
+		assert.Nil(t, item["error"])
+		assert.Len(t, item["replaced"].([]interface{}), 1)
+	}
+
+	stillAssigned, err := testService.GetReviewerAssignments(ctx, &domain.ReviewerAssignmentsInput{UserID: leaving})
+	require.NoError(t, err)
+	assert.Len(t, stillAssigned.PullRequests, len(affected.PullRequests))
+
+	// Настоящий запуск снимает неактивного ревьювера со всех PR
+	applied := bulk(false)
+	assert.Len(t, applied, len(affected.PullRequests))
+
+	remaining, err := testService.GetReviewerAssignments(ctx, &domain.ReviewerAssignmentsInput{
+		UserID:   leaving,
+		Statuses: []domain.PullRequestStatus{domain.PullRequestStatusOpen},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, remaining.PullRequests)
+
+	// Повторный запуск ничего не находит
+	assert.Empty(t, bulk(false))
+}
+
+func TestDryRun_DoesNotPersistChanges(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+
+	members := createTestTeam(t, "dry-run", 4)
+
+	post := func(path string, payload map[string]interface{}) (int, map[string]interface{}) {
+		body, _ := json.Marshal(payload)
+
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer admin")
+
+		w := httptest.NewRecorder()
+		testRouter.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response
+	}
+
+	// Пробное создание возвращает PR с ревьюверами, но не сохраняет его
+	code, response := post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-dry-run",
+		"pull_request_name": "Dry run",
+		"author_id":         members[0],
+		"dry_run":           true,
+	})
+	require.Equal(t, http.StatusOK, code, response)
+	assert.Equal(t, true, response["dry_run"])
+	assert.Len(t, response["pr"].(map[string]interface{})["assigned_reviewers"], 2)
+
+	_, err := testService.GetPullRequest(ctx, "pr-dry-run")
+	assert.ErrorIs(t, err, domain.ErrResourceNotFound)
+
+	// Пробная деактивация команды показывает число участников, но все остаются активными
+	code, response = post("/team/deactivate", map[string]interface{}{
+		"team_name":             "dry-run",
+		"reassign_open_reviews": true,
+		"dry_run":               true,
+	})
+	require.Equal(t, http.StatusOK, code, response)
+	assert.EqualValues(t, len(members), response["deactivated_user_count"])
+
+	team, err := testService.GetTeam(ctx, "dry-run")
+	require.NoError(t, err)
+	for _, member := range team.Members {
+		assert.True(t, member.IsActive, member.UserID)
+	}
+}
+
+func TestExplainPullRequest_RecordsExclusions(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+
+	members := createTestTeam(t, "explain", 5)
+	author, inactive, absent := members[0], members[1], members[2]
+
+	_, err := testService.SetUserIsActive(ctx, &domain.SetUserActiveInput{UserID: inactive, IsActive: false})
+	require.NoError(t, err)
+	_, err = testService.AddUserAbsence(ctx, &domain.AddUserAbsenceInput{
+		UserID:   absent,
+		StartsAt: time.Now().Add(-time.Hour),
+		EndsAt:   time.Now().Add(24 * time.Hour),
+		Reason:   "vacation",
+	})
+	require.NoError(t, err)
+
+	pr := createTestPR(t, "pr-explain", author)
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/explain?pull_request_id=pr-explain", nil)
+	req.Header.Set("Authorization", "Bearer admin")
+
+	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	explanations := response["explanations"].([]interface{})
+	require.Len(t, explanations, 1)
+
+	explanation := explanations[0].(map[string]interface{})
+	assert.Equal(t, "CREATED", explanation["trigger"])
+	assert.ElementsMatch(t, pr.AssignedReviewers, explanation["selected"])
+
+	reasons := make(map[string]string)
+	for _, item := range explanation["excluded"].([]interface{}) {
+		exclusion := item.(map[string]interface{})
+		reasons[exclusion["user_id"].(string)] = exclusion["reason"].(string)
+	}
+	assert.Equal(t, map[string]string{
+		author:   "AUTHOR",
+		inactive: "INACTIVE",
+		absent:   "OUT_OF_OFFICE",
+	}, reasons)
+}
+
+// TestWebhooks_DeliversSignedEventsWithRetry проверяет подписанную доставку событий и повтор после ошибки подписчика
+func TestWebhooks_DeliversSignedEventsWithRetry(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+	svc := testService.(*service.Service)
+
+	// Подписчик отвечает ошибкой на первый запрос и принимает остальные
+	var mu sync.Mutex
+	var received []map[string]interface{}
+	requests := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mac := hmac.New(sha256.New, []byte("s3cret"))
+		mac.Write(body)
+		if r.Header.Get(service.WebhookSignatureHeader) != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var event map[string]interface{}
+		_ = json.Unmarshal(body, &event)
+		received = append(received, event)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"url":         receiver.URL,
+		"secret":      "s3cret",
+		"event_types": []string{"pull_request.created", "pull_request.merged"},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/create", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin")
+
+	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+	subscriptionID := int64(created["webhook"].(map[string]interface{})["subscription_id"].(float64))
+
+	userIDs := createTestTeam(t, "webhooks", 3)
+	pr := createTestPR(t, "pr-webhooks", userIDs[0])
+
+	// Деактивация пользователя не входит в фильтр подписки
+	_, err := testService.SetUserIsActive(ctx, &domain.SetUserActiveInput{UserID: userIDs[2], IsActive: false})
+	require.NoError(t, err)
+
+	// Relay ставит в очередь доставки только событие из фильтра подписки
+	_, err = svc.PublishOutbox(ctx)
+	require.NoError(t, err)
+
+	// Первая попытка неудачна, событие остаётся в очереди
+	attempted, err := svc.DeliverWebhooks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	assert.Empty(t, received)
+
+	// После задержки повтор доставляет событие
+	time.Sleep(100 * time.Millisecond)
+	attempted, err = svc.DeliverWebhooks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+
+	_, err = testService.MergePullRequest(ctx, &domain.MergePullRequestInput{PullRequestID: pr.ID, Force: true})
+	require.NoError(t, err)
+
+	_, err = svc.PublishOutbox(ctx)
+	require.NoError(t, err)
+
+	attempted, err = svc.DeliverWebhooks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+
+	require.Len(t, received, 2)
+	assert.Equal(t, "pull_request.created", received[0]["event_type"])
+	assert.Equal(t, "pr-webhooks", received[0]["data"].(map[string]interface{})["pull_request_id"])
+	assert.Equal(t, "pull_request.merged", received[1]["event_type"])
+	assert.Equal(t, "MERGED", received[1]["data"].(map[string]interface{})["status"])
+
+	// Журнал доставок: новые первыми, у первого события две попытки
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/webhooks/deliveries?subscription_id=%d", subscriptionID), nil)
+	req.Header.Set("Authorization", "Bearer admin")
+
+	w = httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var deliveryLog map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &deliveryLog)
+
+	deliveries := deliveryLog["deliveries"].([]interface{})
+	require.Len(t, deliveries, 2)
+
+	merged := deliveries[0].(map[string]interface{})
+	assert.Equal(t, "pull_request.merged", merged["event_type"])
+	assert.Equal(t, "DELIVERED", merged["status"])
+	assert.Equal(t, float64(1), merged["attempts"])
+
+	createdDelivery := deliveries[1].(map[string]interface{})
+	assert.Equal(t, "pull_request.created", createdDelivery["event_type"])
+	assert.Equal(t, "DELIVERED", createdDelivery["status"])
+	assert.Equal(t, float64(2), createdDelivery["attempts"])
+}
+
+// TestOutbox_PublishesOnlyCommittedEventsOnce проверяет, что события откаченных операций не публикуются,
+// а опубликованные не публикуются повторно
+func TestOutbox_PublishesOnlyCommittedEventsOnce(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+	svc := testService.(*service.Service)
+
+	userIDs := createTestTeam(t, "outbox", 3)
+
+	// Приёмник общий для всех тестов: считаем только новые события
+	published := len(testOutboxSink.Events())
+
+	// Пробный запуск откатывается вместе со своим событием
+	_, err := testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-outbox-dry",
+		PullRequestName: "Dry run",
+		AuthorID:        userIDs[0],
+		DryRun:          true,
+	})
+	require.NoError(t, err)
+
+	// Ошибка операции откатывает и событие
+	_, err = testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-outbox",
+		PullRequestName: "Missing author",
+		AuthorID:        "missing-user",
+	})
+	require.Error(t, err)
+
+	createTestPR(t, "pr-outbox", userIDs[0])
+
+	count, err := svc.PublishOutbox(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	events := testOutboxSink.Events()[published:]
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.WebhookEventPullRequestCreated, events[0].EventType)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+	assert.Equal(t, "pr-outbox", payload["data"].(map[string]interface{})["pull_request_id"])
+
+	// Опубликованное событие остаётся в outbox с отметкой и больше не выбирается
+	count, err = svc.PublishOutbox(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	var unpublished int64
+	require.NoError(t, testDB.Table("outbox").Where("published_at IS NULL").Count(&unpublished).Error)
+	assert.Zero(t, unpublished)
+}
+
+// postGitHubFixture отправляет записанный payload вебхука GitHub, подписанный тестовым секретом
+func postGitHubFixture(t *testing.T, fixture, deliveryID string) map[string]interface{} {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("..", "testdata", "github", fixture))
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte("gh-secret"))
+	mac.Write(body)
+
+	req := httptest.NewRequest(http.MethodPost, "/integrations/github", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	req.Header.Set("X-GitHub-Delivery", deliveryID)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+// TestGitHubWebhook_ReplaysPullRequestLifecycle проводит PR через весь жизненный цикл записанными вебхуками GitHub
+func TestGitHubWebhook_ReplaysPullRequestLifecycle(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	createTestTeam(t, "github", 3)
+	const prID = "octo-org/review-service#42"
+
+	steps := []struct {
+		fixture string
+		status  domain.PullRequestStatus
+	}{
+		{fixture: "pull_request_opened_draft.json", status: domain.PullRequestStatusDraft},
+		{fixture: "pull_request_ready_for_review.json", status: domain.PullRequestStatusOpen},
+		{fixture: "pull_request_closed.json", status: domain.PullRequestStatusClosed},
+		{fixture: "pull_request_reopened.json", status: domain.PullRequestStatusOpen},
+		{fixture: "pull_request_closed_merged.json", status: domain.PullRequestStatusMerged},
+	}
+
+	for i, step := range steps {
+		response := postGitHubFixture(t, step.fixture, fmt.Sprintf("delivery-%d", i))
+		assert.Equal(t, "processed", response["status"], step.fixture)
+
+		pr, err := testService.GetPullRequest(ctx, prID)
+		require.NoError(t, err)
+		assert.Equal(t, step.status, pr.PullRequest.Status, step.fixture)
+	}
+
+	// Повтор уже обработанной доставки ничего не меняет
+	response := postGitHubFixture(t, "pull_request_reopened.json", "delivery-3")
+	assert.Equal(t, "duplicate", response["status"])
+
+	pr, err := testService.GetPullRequest(ctx, prID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PullRequestStatusMerged, pr.PullRequest.Status)
+	assert.Equal(t, "test-user-github-0", pr.PullRequest.AuthorID)
+	assert.NotEmpty(t, pr.PullRequest.AssignedReviewers)
+
+	history, err := testService.GetPullRequestHistory(ctx, prID)
+	require.NoError(t, err)
+	for _, event := range history {
+		assert.Equal(t, "github", event.ActorID)
+	}
+}
+
+// TestGitHubWebhook_ReclaimsStaleDeliveryClaim проверяет, что доставка, оставшаяся в PENDING после
+// падения обработчика, обрабатывается повтором после истечения аренды, а свежая - нет
+func TestGitHubWebhook_ReclaimsStaleDeliveryClaim(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	createTestTeam(t, "github", 3)
+
+	claim := func(deliveryID string, claimedAt time.Time) {
+		t.Helper()
+		require.NoError(t, testDB.Exec(
+			`INSERT INTO ingested_deliveries (source, delivery_id, action, pull_request_id, status, received_at, claimed_at)
+			VALUES ('github', ?, 'opened', 'octo-org/review-service#42', 'PENDING', ?, ?)`,
+			deliveryID, claimedAt, claimedAt).Error)
+	}
+
+	// Обработчик занял доставку только что и ещё работает
+	claim("delivery-fresh", time.Now())
+	response := postGitHubFixture(t, "pull_request_opened_draft.json", "delivery-fresh")
+	assert.Equal(t, "duplicate", response["status"])
+
+	_, err := testService.GetPullRequest(ctx, "octo-org/review-service#42")
+	assert.ErrorIs(t, err, domain.ErrResourceNotFound)
+
+	// Обработчик упал час назад, не применив действие
+	claim("delivery-stale", time.Now().Add(-time.Hour))
+	response = postGitHubFixture(t, "pull_request_opened_draft.json", "delivery-stale")
+	assert.Equal(t, "processed", response["status"])
+
+	pr, err := testService.GetPullRequest(ctx, "octo-org/review-service#42")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PullRequestStatusDraft, pr.PullRequest.Status)
+
+	var status string
+	require.NoError(t, testDB.Raw(
+		"SELECT status FROM ingested_deliveries WHERE source = 'github' AND delivery_id = 'delivery-stale'").
+		Scan(&status).Error)
+	assert.Equal(t, string(domain.IngestedDeliveryDone), status)
+
+	// Завершённая доставка больше не перезанимается
+	response = postGitHubFixture(t, "pull_request_opened_draft.json", "delivery-stale")
+	assert.Equal(t, "duplicate", response["status"])
+}
+
+// postGitLabFixture отправляет записанный payload вебхука GitLab с тестовым токеном
+func postGitLabFixture(t *testing.T, fixture, deliveryID string) map[string]interface{} {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("..", "testdata", "gitlab", fixture))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/integrations/gitlab/webhook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Event-UUID", deliveryID)
+	req.Header.Set("X-Gitlab-Token", "gl-token")
+
+	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+// TestGitLabWebhook_ReplaysMergeRequestLifecycle проводит MR через весь жизненный цикл записанными вебхуками GitLab
+// и проверяет, что назначенные ревьюверы возвращаются логинами GitLab
+func TestGitLabWebhook_ReplaysMergeRequestLifecycle(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	createTestTeam(t, "gitlab", 3)
+	const prID = "platform/backend/review-service!17"
+
+	response := postGitLabFixture(t, "merge_request_open_draft.json", "gl-delivery-0")
+	assert.Equal(t, "processed", response["status"])
+	assert.NotContains(t, response, "quick_action")
+
+	// Снятие черновика назначает ревьюверов: CI получает их логины и quick action для MR
+	response = postGitLabFixture(t, "merge_request_update_ready.json", "gl-delivery-1")
+	assert.Equal(t, "processed", response["status"])
+	assert.ElementsMatch(t, []interface{}{"alice", "bob"}, response["reviewer_logins"])
+	assert.Empty(t, response["unmapped_reviewers"])
+	assert.Contains(t, response["quick_action"], "/assign_reviewer @")
+
+	steps := []struct {
+		fixture string
+		status  domain.PullRequestStatus
+	}{
+		{fixture: "merge_request_close.json", status: domain.PullRequestStatusClosed},
+		{fixture: "merge_request_reopen.json", status: domain.PullRequestStatusOpen},
+		{fixture: "merge_request_merge.json", status: domain.PullRequestStatusMerged},
+	}
+
+	for i, step := range steps {
+		response := postGitLabFixture(t, step.fixture, fmt.Sprintf("gl-delivery-%d", i+2))
+		assert.Equal(t, "processed", response["status"], step.fixture)
+
+		pr, err := testService.GetPullRequest(ctx, prID)
+		require.NoError(t, err)
+		assert.Equal(t, step.status, pr.PullRequest.Status, step.fixture)
+	}
+
+	// Повтор уже обработанной доставки ничего не меняет
+	response = postGitLabFixture(t, "merge_request_reopen.json", "gl-delivery-3")
+	assert.Equal(t, "duplicate", response["status"])
+
+	pr, err := testService.GetPullRequest(ctx, prID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PullRequestStatusMerged, pr.PullRequest.Status)
+	assert.Equal(t, "test-user-gitlab-0", pr.PullRequest.AuthorID)
+
+	history, err := testService.GetPullRequestHistory(ctx, prID)
+	require.NoError(t, err)
+	for _, event := range history {
+		assert.Equal(t, "gitlab", event.ActorID)
+	}
+}
+
+// TestCodeOwners_DraftKeepsChangedFilesUntilReady проверяет, что черновик запоминает репозиторий и изменённые файлы,
+// и при переводе в OPEN первыми назначаются владельцы из зарегистрированного CODEOWNERS
+func TestCodeOwners_DraftKeepsChangedFilesUntilReady(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	backend := createTestTeam(t, "backend", 3)
+	dba := createTestTeam(t, "dba", 2)
+
+	_, err := testService.SetCodeOwners(ctx, &domain.SetCodeOwnersInput{
+		Repository: "octo-org/review-service",
+		Content:    "*          @octo/backend\n*.sql      @octo/dba\n/docs/     @" + backend[2] + "\n",
+	})
+	require.NoError(t, err)
+
+	_, err = testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-codeowners",
+		PullRequestName: "Add migration",
+		AuthorID:        backend[0],
+		Draft:           true,
+		Repository:      "octo-org/review-service",
+		ChangedFiles:    []string{"migrations/015_codeowners.up.sql"},
+	})
+	require.NoError(t, err)
+
+	pr, err := testService.MarkPullRequestReady(ctx, &domain.MarkReadyInput{PullRequestID: "pr-codeowners"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, dba, pr.AssignedReviewers)
+
+	explanations, err := testService.ExplainPullRequest(ctx, "pr-codeowners")
+	require.NoError(t, err)
+	require.Len(t, explanations, 1)
+	assert.Equal(t, []domain.CodeOwnersMatch{{
+		Line:    2,
+		Pattern: "*.sql",
+		Owners:  []string{"@octo/dba"},
+		Paths:   []string{"migrations/015_codeowners.up.sql"},
+	}}, explanations[0].CodeOwners)
+	require.NotEmpty(t, explanations[0].Teams)
+	assert.True(t, explanations[0].Teams[0].CodeOwners)
+}
+
+func TestRepository_OwnerTeamsReviewPullRequestsFromOtherTeams(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	backend := createTestTeam(t, "backend", 3)
+	frontend := createTestTeam(t, "frontend", 3)
+
+	_, err := testService.SetRepository(ctx, &domain.SetRepositoryInput{
+		Name:       "octo-org/web-app",
+		OwnerTeams: []string{"frontend"},
+	})
+	require.NoError(t, err)
+
+	// Бэкендер меняет фронтенд-репозиторий - ревьюят владельцы репозитория
+	pr, err := testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-web-app",
+		PullRequestName: "Fix API client",
+		AuthorID:        backend[0],
+		Repository:      "octo-org/web-app",
+	})
+	require.NoError(t, err)
+	assert.Len(t, pr.AssignedReviewers, 2)
+	assert.Subset(t, frontend, pr.AssignedReviewers)
+
+	// PR в незарегистрированный репозиторий ревьюит команда автора
+	pr, err = testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-review-service",
+		PullRequestName: "Add endpoint",
+		AuthorID:        backend[0],
+		Repository:      "octo-org/review-service",
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, backend[1:], pr.AssignedReviewers)
+
+	explanations, err := testService.ExplainPullRequest(ctx, "pr-web-app")
+	require.NoError(t, err)
+	require.Len(t, explanations, 1)
+	assert.Equal(t, "octo-org/web-app", explanations[0].Repository)
+
+	page, err := testService.ListPullRequests(ctx, &domain.ListPullRequestsInput{
+		PullRequestFilter: domain.PullRequestFilter{Repository: "octo-org/web-app"},
+	})
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 1)
+	assert.Equal(t, "pr-web-app", page.PullRequests[0].ID)
+
+	repository, err := testService.GetRepository(ctx, "octo-org/web-app")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend"}, repository.OwnerTeams)
+}
+
+// TestTeamMembership_PullRequestTeamContext проверяет выбор ревьюверов из команды, указанной при создании PR
+func TestTeamMembership_PullRequestTeamContext(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	backend := createTestTeam(t, "backend", 3)
+
+	// Бэкендер также состоит в platform
+	_, err := testService.CreateTeam(ctx, &domain.Team{
+		Name: "platform",
+		Members: []domain.TeamMember{
+			{UserID: backend[0], Username: "User 0", Role: domain.TeamRoleMaintainer, IsActive: true},
+			{UserID: "platform-1", Username: "Platform 1", IsActive: true},
+			{UserID: "platform-2", Username: "Platform 2", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	pr, err := testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-platform",
+		PullRequestName: "Tune connection pool",
+		AuthorID:        backend[0],
+		TeamName:        "platform",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "platform", pr.TeamName)
+	assert.ElementsMatch(t, []string{"platform-1", "platform-2"}, pr.AssignedReviewers)
+
+	// Без команды PR - основная команда автора
+	pr, err = testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-backend",
+		PullRequestName: "Add endpoint",
+		AuthorID:        backend[0],
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "backend", pr.TeamName)
+	assert.ElementsMatch(t, backend[1:], pr.AssignedReviewers)
+
+	// Автор не состоит в команде
+	_, err = testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-foreign",
+		PullRequestName: "Foreign team",
+		AuthorID:        "platform-1",
+		TeamName:        "backend",
+	})
+	assert.ErrorIs(t, err, domain.ErrNotTeamMember)
+
+	saved, err := testService.GetPullRequest(ctx, "pr-platform")
+	require.NoError(t, err)
+	assert.Equal(t, "platform", saved.TeamName)
+
+	// Деактивация platform не деактивирует участника, который активен в backend
+	result, err := testService.DeactivateTeamMembers(ctx, &domain.DeactivateTeamInput{TeamName: "platform"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.DeactivatedUserCount)
+
+	assert.True(t, loadTestUser(t, backend[0]).IsActive)
+	assert.False(t, loadTestUser(t, "platform-1").IsActive)
+
+	team, err := testService.GetTeam(ctx, "platform")
+	require.NoError(t, err)
+	for _, member := range team.Members {
+		assert.False(t, member.IsActive)
+		if member.UserID == backend[0] {
+			assert.Equal(t, domain.TeamRoleMaintainer, member.Role)
+		}
+	}
+}
+
+func TestTeamMembers_AddMoveRemove(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	backend := createTestTeam(t, "backend", 3)
+	platform := createTestTeam(t, "platform", 2)
+
+	pr, err := testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-backend",
+		PullRequestName: "Add endpoint",
+		AuthorID:        backend[0],
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, backend[1:], pr.AssignedReviewers)
+
+	// Новый пользователь создаётся с командой в качестве основной
+	added, err := testService.AddTeamMember(ctx, &domain.AddTeamMemberInput{
+		TeamName: "backend",
+		UserID:   "newcomer",
+		Username: "Newcomer",
+		Role:     domain.TeamRoleMaintainer,
+		IsActive: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "backend", added.PrimaryTeam)
+	assert.Equal(t, domain.TeamRoleMaintainer, added.Membership.Role)
+	assert.False(t, added.Membership.JoinedAt.IsZero())
+
+	// Ревьювер переходит в platform, его ревью на PR backend достаются оставшимся участникам backend
+	moved, err := testService.MoveTeamMember(ctx, &domain.MoveTeamMemberInput{
+		FromTeam:            "backend",
+		ToTeam:              "platform",
+		UserID:              backend[1],
+		ReassignOpenReviews: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "platform", moved.PrimaryTeam)
+	require.Len(t, moved.Reassignments, 1)
+	assert.Equal(t, "newcomer", moved.Reassignments[0].ReassignmentDetails[0].NewReviewerID)
+	assert.Equal(t, "platform", loadTestUser(t, backend[1]).TeamName)
+
+	saved, err := testService.GetPullRequest(ctx, "pr-backend")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{backend[2], "newcomer"}, saved.AssignedReviewers)
+
+	// Из единственной команды удалить нельзя
+	_, err = testService.RemoveTeamMember(ctx, &domain.RemoveTeamMemberInput{TeamName: "platform", UserID: platform[0]})
+	assert.ErrorIs(t, err, domain.ErrLastTeam)
+
+	// Дополнительная команда удаляется, основная остаётся прежней
+	_, err = testService.AddTeamMember(ctx, &domain.AddTeamMemberInput{TeamName: "backend", UserID: platform[0], IsActive: true})
+	require.NoError(t, err)
+	removed, err := testService.RemoveTeamMember(ctx, &domain.RemoveTeamMemberInput{TeamName: "backend", UserID: platform[0]})
+	require.NoError(t, err)
+	assert.Nil(t, removed.Membership)
+	assert.Equal(t, "platform", removed.PrimaryTeam)
+
+	team, err := testService.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	memberIDs := make([]string, len(team.Members))
+	for i, member := range team.Members {
+		memberIDs[i] = member.UserID
+	}
+	assert.ElementsMatch(t, []string{backend[0], backend[2], "newcomer"}, memberIDs)
+}
+
+func TestDeleteTeam_RequiresNoOpenPRs(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	backend := createTestTeam(t, "backend", 3)
+	createTestTeam(t, "platform", 1)
+
+	// Для backend[0] platform станет основной командой после удаления backend
+	_, err := testService.AddTeamMember(ctx, &domain.AddTeamMemberInput{TeamName: "platform", UserID: backend[0], IsActive: true})
+	require.NoError(t, err)
+
+	_, err = testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-backend",
+		PullRequestName: "Add endpoint",
+		AuthorID:        backend[0],
+	})
+	require.NoError(t, err)
+
+	_, err = testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-backend-draft",
+		PullRequestName: "Draft endpoint",
+		AuthorID:        backend[1],
+		Draft:           true,
+	})
+	require.NoError(t, err)
+
+	err = testService.DeleteTeam(ctx, "backend")
+	assert.ErrorIs(t, err, domain.ErrTeamHasOpenPRs)
+
+	_, err = testService.MergePullRequest(ctx, &domain.MergePullRequestInput{PullRequestID: "pr-backend", Force: true})
+	require.NoError(t, err)
+
+	// Черновик команды тоже блокирует удаление
+	err = testService.DeleteTeam(ctx, "backend")
+	assert.ErrorIs(t, err, domain.ErrTeamHasOpenPRs)
+
+	_, err = testService.ClosePullRequest(ctx, &domain.ClosePullRequestInput{PullRequestID: "pr-backend-draft"})
+	require.NoError(t, err)
+
+	// У остальных участников backend других команд нет
+	err = testService.DeleteTeam(ctx, "backend")
+	assert.ErrorIs(t, err, domain.ErrLastTeam)
+
+	for _, userID := range backend[1:] {
+		_, err = testService.MoveTeamMember(ctx, &domain.MoveTeamMemberInput{FromTeam: "backend", ToTeam: "platform", UserID: userID})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, testService.DeleteTeam(ctx, "backend"))
+
+	_, err = testService.GetTeam(ctx, "backend")
+	assert.ErrorIs(t, err, domain.ErrResourceNotFound)
+	assert.Equal(t, "platform", loadTestUser(t, backend[0]).TeamName)
+
+	saved, err := testService.GetPullRequest(ctx, "pr-backend")
+	require.NoError(t, err)
+	assert.Empty(t, saved.TeamName)
+}
+
+func TestDeleteTeam_BlockedByOpenPRsInOwnedRepository(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	backend := createTestTeam(t, "backend", 1)
+	createTestTeam(t, "frontend", 3)
+
+	_, err := testService.SetRepository(ctx, &domain.SetRepositoryInput{
+		Name:       "octo-org/web-app",
+		OwnerTeams: []string{"frontend"},
+	})
+	require.NoError(t, err)
+
+	// PR создан от имени backend, но ревьюят его владельцы репозитория
+	_, err = testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-web-app",
+		PullRequestName: "Fix API client",
+		AuthorID:        backend[0],
+		Repository:      "octo-org/web-app",
+	})
+	require.NoError(t, err)
+
+	err = testService.DeleteTeam(ctx, "frontend")
+	assert.ErrorIs(t, err, domain.ErrTeamHasOpenPRs)
+
+	_, err = testService.MergePullRequest(ctx, &domain.MergePullRequestInput{PullRequestID: "pr-web-app", Force: true})
+	require.NoError(t, err)
+
+	// Открытых PR больше нет - удаление упирается только в участников без другой команды
+	err = testService.DeleteTeam(ctx, "frontend")
+	assert.ErrorIs(t, err, domain.ErrLastTeam)
+}
+
+func TestDeactivateTeam_ReassignsMultiTeamMemberOnTeamPRs(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	backend := createTestTeam(t, "backend", 3)
+
+	// backend[1] также состоит в platform
+	_, err := testService.CreateTeam(ctx, &domain.Team{
+		Name: "platform",
+		Members: []domain.TeamMember{
+			{UserID: backend[1], Username: "User 1", IsActive: true},
+			{UserID: "platform-1", Username: "Platform 1", IsActive: true},
+			{UserID: "platform-2", Username: "Platform 2", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	backendPR, err := testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-backend",
+		PullRequestName: "Add endpoint",
+		AuthorID:        backend[0],
+	})
+	require.NoError(t, err)
+	require.Contains(t, backendPR.AssignedReviewers, backend[1])
+
+	platformPR, err := testService.CreatePullRequest(ctx, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-platform",
+		PullRequestName: "Tune connection pool",
+		AuthorID:        "platform-1",
+		TeamName:        "platform",
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{backend[1], "platform-2"}, platformPR.AssignedReviewers)
+
+	result, err := testService.DeactivateTeamMembers(ctx, &domain.DeactivateTeamInput{
+		TeamName:            "platform",
+		ReassignOpenReviews: true,
+	})
+	require.NoError(t, err)
+
+	// Пользователь остаётся активным, но на PR platform его членство выключено
+	assert.True(t, loadTestUser(t, backend[1]).IsActive)
+	require.Len(t, result.Reassignments, 1)
+	assert.Equal(t, "pr-platform", result.Reassignments[0].PullRequestID)
+
+	oldReviewers := make([]string, 0, 2)
+	for _, detail := range result.Reassignments[0].ReassignmentDetails {
+		oldReviewers = append(oldReviewers, detail.OldReviewerID)
+	}
+	assert.ElementsMatch(t, []string{backend[1], "platform-2"}, oldReviewers)
+
+	saved, err := testService.GetPullRequest(ctx, "pr-platform")
+	require.NoError(t, err)
+	assert.NotContains(t, saved.AssignedReviewers, backend[1])
+
+	// Ревью в backend не затронуты
+	saved, err = testService.GetPullRequest(ctx, "pr-backend")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, backendPR.AssignedReviewers, saved.AssignedReviewers)
+}
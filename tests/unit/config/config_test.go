@@ -0,0 +1,62 @@
+package config_test
+
+import (
+	"testing"
+
+	"avitoTechAutumn2025/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEnvConfig_ParsesAssignmentPairs(t *testing.T) {
+	// Arrange
+	t.Setenv("ASSIGNMENT_TEAM_STRATEGIES", "backend:round_robin, design:weighted,")
+	t.Setenv("ASSIGNMENT_USER_WEIGHTS", "u1:3,u2:0")
+	t.Setenv("GITHUB_USER_MAPPING", "octocat:u1")
+	t.Setenv("GITLAB_USER_MAPPING", "")
+
+	// Act
+	cfg, err := config.NewEnvConfig()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"backend": "round_robin", "design": "weighted"}, cfg.Assignment.TeamStrategies)
+	assert.Equal(t, map[string]int{"u1": 3, "u2": 0}, cfg.Assignment.UserWeights)
+	assert.Equal(t, map[string]string{"octocat": "u1"}, cfg.GitHub.Logins)
+	assert.Empty(t, cfg.GitLab.Logins)
+}
+
+func TestNewEnvConfig_RejectsMalformedEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		value   string
+		wantErr string
+	}{
+		{name: "pair without value", env: "ASSIGNMENT_TEAM_STRATEGIES", value: "backend:round_robin,design", wantErr: "ASSIGNMENT_TEAM_STRATEGIES"},
+		{name: "pair without key", env: "GITLAB_USER_MAPPING", value: ":u1", wantErr: "GITLAB_USER_MAPPING"},
+		{name: "duplicate key", env: "GITHUB_USER_MAPPING", value: "octocat:u1,octocat:u2", wantErr: "GITHUB_USER_MAPPING"},
+		{name: "non-numeric weight", env: "ASSIGNMENT_USER_WEIGHTS", value: "u1:three", wantErr: "ASSIGNMENT_USER_WEIGHTS"},
+		{name: "negative weight", env: "ASSIGNMENT_USER_WEIGHTS", value: "u1:-1", wantErr: "ASSIGNMENT_USER_WEIGHTS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("ASSIGNMENT_TEAM_STRATEGIES", "")
+			t.Setenv("ASSIGNMENT_USER_WEIGHTS", "")
+			t.Setenv("GITHUB_USER_MAPPING", "")
+			t.Setenv("GITLAB_USER_MAPPING", "")
+			t.Setenv(tt.env, tt.value)
+
+			// Act
+			cfg, err := config.NewEnvConfig()
+
+			// Assert
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Nil(t, cfg)
+		})
+	}
+}
@@ -2,19 +2,26 @@ package handlers_test
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"avitoTechAutumn2025/internal/api/handlers"
+	"avitoTechAutumn2025/internal/api/middleware"
 	"avitoTechAutumn2025/internal/domain"
 	"avitoTechAutumn2025/internal/mocks"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func setupTestRouter(mockService *mocks.AssignmentService) *gin.Engine {
@@ -134,6 +141,46 @@ func TestCreatePullRequestHandler_DuplicatePR(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestCreatePullRequestHandler_DryRun(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	requestBody := map[string]interface{}{
+		"pull_request_id":   "pr-001",
+		"pull_request_name": "Add feature",
+		"author_id":         "user-1",
+		"dry_run":           true,
+	}
+
+	mockService.On("CreatePullRequest", mock.Anything, mock.MatchedBy(func(input *domain.CreatePullRequestInput) bool {
+		return input.PullRequestID == "pr-001" && input.DryRun
+	})).Return(&domain.PullRequest{
+		ID:                "pr-001",
+		AuthorID:          "user-1",
+		Status:            domain.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user-2"},
+	}, nil)
+
+	// Act
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert: PR не создан, поэтому 200 вместо 201
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Equal(t, true, response["dry_run"])
+	pr := response["pr"].(map[string]interface{})
+	assert.Equal(t, "pr-001", pr["pull_request_id"])
+}
+
 func TestMergePullRequestHandler_Success(t *testing.T) {
 	// Arrange
 	mockService := mocks.NewAssignmentService(t)
@@ -174,6 +221,53 @@ func TestMergePullRequestHandler_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestSubmitReviewHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	reviewedAt := time.Now()
+	expectedPR := &domain.PullRequest{
+		ID:                "pr-001",
+		Name:              "Test PR",
+		AuthorID:          "user-1",
+		Status:            domain.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user-2", "user-3"},
+		ReviewerStates: []domain.ReviewerState{
+			{ReviewerID: "user-2", State: domain.ReviewStateApproved, UpdatedAt: reviewedAt},
+		},
+	}
+
+	mockService.On("SubmitReview", mock.Anything, &domain.SubmitReviewInput{
+		PullRequestID: "pr-001",
+		ReviewerID:    "user-2",
+		State:         domain.ReviewStateApproved,
+	}).Return(expectedPR, nil)
+
+	// Act
+	body := `{"pull_request_id":"pr-001","reviewer_id":"user-2","state":"APPROVED"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	pr := response["pr"].(map[string]interface{})
+	states := pr["reviewer_states"].([]interface{})
+	require.Len(t, states, 2)
+	assert.Equal(t, "APPROVED", states[0].(map[string]interface{})["state"])
+	// Состояние ещё не прочитанного ревьювера считается PENDING
+	assert.Equal(t, "PENDING", states[1].(map[string]interface{})["state"])
+
+	mockService.AssertExpectations(t)
+}
+
 func TestAddTeamHandler_Success(t *testing.T) {
 	// Arrange
 	mockService := mocks.NewAssignmentService(t)
@@ -281,6 +375,298 @@ func TestGetTeamHandler_NotFound(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestClosePullRequestHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	closedAt := time.Now()
+	expectedPR := &domain.PullRequest{
+		ID:                "pr-001",
+		Name:              "Test PR",
+		AuthorID:          "user-1",
+		Status:            domain.PullRequestStatusClosed,
+		AssignedReviewers: []string{"user-2"},
+		ClosedAt:          &closedAt,
+	}
+
+	mockService.On("ClosePullRequest", mock.Anything, &domain.ClosePullRequestInput{
+		PullRequestID: "pr-001",
+	}).Return(expectedPR, nil)
+
+	// Act
+	body := `{"pull_request_id":"pr-001"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/close", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	pr := response["pr"].(map[string]interface{})
+	assert.Equal(t, "CLOSED", pr["status"])
+	assert.NotNil(t, pr["closed_at"])
+}
+
+func TestMarkPullRequestReadyHandler_InvalidTransition(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("MarkPullRequestReady", mock.Anything, &domain.MarkReadyInput{
+		PullRequestID: "pr-001",
+	}).Return(nil, domain.ErrInvalidTransition)
+
+	// Act
+	body := `{"pull_request_id":"pr-001"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/ready", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	errorObj := response["error"].(map[string]interface{})
+	assert.Equal(t, "INVALID_TRANSITION", errorObj["code"])
+}
+
+func TestGetPullRequestHistoryHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	at := time.Now()
+	mockService.On("GetPullRequestHistory", mock.Anything, "pr-001").Return([]domain.PullRequestEvent{
+		{ID: 1, PullRequestID: "pr-001", Type: domain.PullRequestEventCreated, ActorID: "admin", Reason: "OPEN", CreatedAt: at},
+		{ID: 2, PullRequestID: "pr-001", Type: domain.PullRequestEventReviewerReplaced, ActorID: "system",
+			OldReviewerID: "user-2", NewReviewerID: "user-3", Reason: domain.EventReasonAbsence, CreatedAt: at},
+	}, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/history?pull_request_id=pr-001", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	events := response["events"].([]interface{})
+	require.Len(t, events, 2)
+
+	created := events[0].(map[string]interface{})
+	assert.Equal(t, "CREATED", created["event_type"])
+	assert.Nil(t, created["old_reviewer_id"])
+
+	replaced := events[1].(map[string]interface{})
+	assert.Equal(t, "REVIEWER_REPLACED", replaced["event_type"])
+	assert.Equal(t, "system", replaced["actor"])
+	assert.Equal(t, "user-2", replaced["old_reviewer_id"])
+	assert.Equal(t, "user-3", replaced["new_reviewer_id"])
+	assert.Equal(t, "absence", replaced["reason"])
+}
+
+func TestExplainPullRequestHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("ExplainPullRequest", mock.Anything, "pr-001").Return([]domain.SelectionExplanation{
+		{
+			ID:             1,
+			PullRequestID:  "pr-001",
+			Trigger:        domain.SelectionTriggerCreated,
+			RequestedCount: 2,
+			Teams: []domain.TeamSelection{
+				{TeamName: "backend", Strategy: domain.SelectionStrategyRandom, Candidates: []string{"user-2"}, Selected: []string{"user-2"}},
+			},
+			Excluded: []domain.SelectionExclusion{
+				{UserID: "user-1", Reason: domain.ExclusionReasonAuthor},
+				{UserID: "user-3", Reason: domain.ExclusionReasonOutOfOffice},
+			},
+			Selected:  []string{"user-2"},
+			CreatedAt: time.Now(),
+		},
+	}, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/explain?pull_request_id=pr-001", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	explanations := response["explanations"].([]interface{})
+	require.Len(t, explanations, 1)
+
+	explanation := explanations[0].(map[string]interface{})
+	assert.Equal(t, "CREATED", explanation["trigger"])
+	assert.Nil(t, explanation["replaced_reviewer_id"])
+
+	teams := explanation["teams"].([]interface{})
+	require.Len(t, teams, 1)
+	assert.Equal(t, "random", teams[0].(map[string]interface{})["strategy"])
+
+	excluded := explanation["excluded"].([]interface{})
+	require.Len(t, excluded, 2)
+	assert.Equal(t, "OUT_OF_OFFICE", excluded[1].(map[string]interface{})["reason"])
+}
+
+func TestExplainPullRequestHandler_MissingID(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/explain", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetPullRequestHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	approvedAt := time.Now()
+	mockService.On("GetPullRequest", mock.Anything, "pr-001").Return(&domain.PullRequestDetails{
+		PullRequest: domain.PullRequest{
+			ID:                "pr-001",
+			Name:              "Test PR",
+			AuthorID:          "user-1",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"user-2", "user-3"},
+			ReviewerStates: []domain.ReviewerState{
+				{ReviewerID: "user-2", State: domain.ReviewStateApproved, UpdatedAt: approvedAt},
+				{ReviewerID: "user-3", State: domain.ReviewStatePending, UpdatedAt: approvedAt},
+			},
+		},
+		Author: domain.User{UserID: "user-1", Username: "Alice", TeamName: "backend", IsActive: true},
+		Reviewers: []domain.User{
+			{UserID: "user-2", Username: "Bob", TeamName: "backend", IsActive: true},
+			{UserID: "user-3", Username: "Carol", TeamName: "backend", IsActive: false},
+		},
+	}, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/get?pull_request_id=pr-001", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	pr := response["pr"].(map[string]interface{})
+	assert.Equal(t, "OPEN", pr["status"])
+	assert.Equal(t, "Alice", pr["author"].(map[string]interface{})["username"])
+
+	reviewers := pr["reviewers"].([]interface{})
+	require.Len(t, reviewers, 2)
+	first := reviewers[0].(map[string]interface{})
+	assert.Equal(t, "Bob", first["username"])
+	assert.Equal(t, "APPROVED", first["state"])
+	second := reviewers[1].(map[string]interface{})
+	assert.Equal(t, false, second["is_active"])
+	assert.Equal(t, "PENDING", second["state"])
+}
+
+func TestGetPullRequestHandler_MissingID(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/get", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "GetPullRequest", mock.Anything, mock.Anything)
+}
+
+func TestListPullRequestsHandler_ParsesQuery(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	createdFrom := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	mockService.On("ListPullRequests", mock.Anything, mock.MatchedBy(func(input *domain.ListPullRequestsInput) bool {
+		return assert.ObjectsAreEqual([]domain.PullRequestStatus{domain.PullRequestStatusOpen, domain.PullRequestStatusDraft}, input.Statuses) &&
+			input.TeamName == "backend" &&
+			input.Repository == "octo-org/web-app" &&
+			input.ReviewerID == "user-2" &&
+			input.CreatedFrom != nil && input.CreatedFrom.Equal(createdFrom) &&
+			input.CreatedTo == nil &&
+			input.SortBy == domain.PullRequestSortMergedAt &&
+			!input.Descending &&
+			input.Limit == 10 &&
+			input.Cursor == "abc"
+	})).Return(&domain.PullRequestPage{
+		PullRequests: []domain.PullRequest{{ID: "pr-001", AuthorID: "user-1", Status: domain.PullRequestStatusOpen}},
+		NextCursor:   "next",
+	}, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet,
+		"/pullRequest/list?status=OPEN,DRAFT&team_name=backend&repository=octo-org/web-app&reviewer_id=user-2&created_from=2025-11-01T00:00:00Z"+
+			"&sort_by=merged_at&order=asc&limit=10&cursor=abc", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response["pull_requests"], 1)
+	assert.Equal(t, "next", response["next_cursor"])
+}
+
+func TestListPullRequestsHandler_InvalidDate(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/list?created_from=yesterday", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ListPullRequests", mock.Anything, mock.Anything)
+}
+
 func TestSetUserIsActiveHandler_Success(t *testing.T) {
 	// Arrange
 	mockService := mocks.NewAssignmentService(t)
@@ -297,8 +683,8 @@ func TestSetUserIsActiveHandler_Success(t *testing.T) {
 		IsActive: false,
 	}
 
-	mockService.On("SetUserIsActive", mock.Anything, "u1", false).
-		Return(expectedUser, nil)
+	mockService.On("SetUserIsActive", mock.Anything, &domain.SetUserActiveInput{UserID: "u1", IsActive: false}).
+		Return(&domain.SetUserActiveResult{User: *expectedUser}, nil)
 
 	// Act
 	body, _ := json.Marshal(requestBody)
@@ -316,6 +702,58 @@ func TestSetUserIsActiveHandler_Success(t *testing.T) {
 
 	assert.Equal(t, "u1", response["user_id"])
 	assert.False(t, response["is_active"].(bool))
+	assert.NotContains(t, response, "reassignments")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestSetUserIsActiveHandler_ReassignOpenReviews(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	requestBody := map[string]interface{}{
+		"user_id":               "u1",
+		"is_active":             false,
+		"reassign_open_reviews": true,
+	}
+
+	mockService.On("SetUserIsActive", mock.Anything, &domain.SetUserActiveInput{
+		UserID:              "u1",
+		IsActive:            false,
+		ReassignOpenReviews: true,
+	}).Return(&domain.SetUserActiveResult{
+		User: domain.User{UserID: "u1", Username: "Alice"},
+		Reassignments: []domain.ReassignInactiveResult{
+			{
+				PullRequestID: "pr-1",
+				ReassignmentDetails: []domain.ReviewerReassignment{
+					{OldReviewerID: "u1", NewReviewerID: "u3", NewReviewerTeam: "backend"},
+				},
+			},
+		},
+	}, nil)
+
+	// Act
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	reassignments := response["reassignments"].([]interface{})
+	require.Len(t, reassignments, 1)
+	first := reassignments[0].(map[string]interface{})
+	assert.Equal(t, "pr-1", first["pull_request_id"])
+	details := first["reassignment_details"].([]interface{})
+	assert.Equal(t, "u3", details[0].(map[string]interface{})["new_reviewer_id"])
 
 	mockService.AssertExpectations(t)
 }
@@ -340,8 +778,10 @@ func TestGetReviewHandler_Success(t *testing.T) {
 		},
 	}
 
-	mockService.On("GetReviewerAssignments", mock.Anything, "reviewer-1").
-		Return(expectedPRs, nil)
+	mockService.On("GetReviewerAssignments", mock.Anything, &domain.ReviewerAssignmentsInput{UserID: "reviewer-1"}).
+		Return(&domain.ReviewerAssignmentsPage{PullRequests: expectedPRs}, nil)
+	mockService.On("GetReviewCapacity", mock.Anything, "reviewer-1").
+		Return(&domain.ReviewCapacity{UserID: "reviewer-1", OpenReviews: 2, MaxOpenReviews: 3}, nil)
 
 	// Act
 	req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=reviewer-1", nil)
@@ -358,6 +798,1102 @@ func TestGetReviewHandler_Success(t *testing.T) {
 	assert.Equal(t, "reviewer-1", response["user_id"])
 	prs := response["pull_requests"].([]interface{})
 	assert.Equal(t, 2, len(prs))
+	assert.Equal(t, float64(2), response["open_reviews"])
+	assert.Equal(t, float64(3), response["max_open_reviews"])
+	assert.Nil(t, response["next_cursor"])
 
 	mockService.AssertExpectations(t)
 }
+
+func TestGetReviewHandler_PassesFilterAndCursor(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	expectedInput := &domain.ReviewerAssignmentsInput{
+		UserID:   "reviewer-1",
+		Statuses: []domain.PullRequestStatus{domain.PullRequestStatusOpen, domain.PullRequestStatusDraft},
+		Limit:    10,
+		Cursor:   "abc",
+	}
+
+	mockService.On("GetReviewerAssignments", mock.Anything, expectedInput).
+		Return(&domain.ReviewerAssignmentsPage{
+			PullRequests: []domain.PullRequestShort{{ID: "pr-001", Status: domain.PullRequestStatusOpen}},
+			NextCursor:   "next",
+		}, nil)
+	mockService.On("GetReviewCapacity", mock.Anything, "reviewer-1").
+		Return(&domain.ReviewCapacity{UserID: "reviewer-1", OpenReviews: 1}, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet,
+		"/users/getReview?user_id=reviewer-1&status=OPEN,DRAFT&limit=10&cursor=abc", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Equal(t, "next", response["next_cursor"])
+	prs := response["pull_requests"].([]interface{})
+	require.Len(t, prs, 1)
+	assert.Contains(t, prs[0].(map[string]interface{}), "created_at")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetReviewHandler_InvalidLimit(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=reviewer-1&limit=ten", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	errObj := response["error"].(map[string]interface{})
+	assert.Equal(t, "INVALID_REQUEST", errObj["code"])
+
+	mockService.AssertNotCalled(t, "GetReviewerAssignments", mock.Anything, mock.Anything)
+}
+
+func TestSetReviewLimitHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	expectedUser := &domain.User{
+		UserID:         "user-1",
+		Username:       "Alice",
+		TeamName:       "backend",
+		IsActive:       true,
+		MaxOpenReviews: 4,
+	}
+
+	mockService.On("SetUserReviewLimit", mock.Anything, "user-1", 4).
+		Return(expectedUser, nil)
+
+	// Act
+	body := `{"user_id":"user-1","max_open_reviews":4}`
+	req := httptest.NewRequest(http.MethodPost, "/users/setReviewLimit", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Equal(t, "user-1", response["user_id"])
+	assert.Equal(t, float64(4), response["max_open_reviews"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestSetTeamSettingsHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	requestBody := map[string]interface{}{
+		"team_name":          "backend",
+		"reviewer_count":     3,
+		"selection_strategy": "least_loaded",
+	}
+
+	expectedSettings := &domain.TeamSettings{
+		TeamName:          "backend",
+		ReviewerCount:     3,
+		MinReviewers:      0,
+		SelectionStrategy: domain.SelectionStrategyLeastLoaded,
+	}
+
+	mockService.On("SetTeamSettings", mock.Anything, mock.MatchedBy(func(input *domain.SetTeamSettingsInput) bool {
+		return input.TeamName == "backend" &&
+			input.ReviewerCount != nil && *input.ReviewerCount == 3 &&
+			input.MinReviewers == nil &&
+			input.SelectionStrategy != nil && *input.SelectionStrategy == domain.SelectionStrategyLeastLoaded
+	})).Return(expectedSettings, nil)
+
+	// Act
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/team/settings/set", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Equal(t, "backend", response["team_name"])
+	assert.Equal(t, float64(3), response["reviewer_count"])
+	assert.Equal(t, "least_loaded", response["selection_strategy"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAddAbsenceHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	startsAt := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)
+
+	requestBody := map[string]interface{}{
+		"user_id":   "u1",
+		"starts_at": startsAt.Format(time.RFC3339),
+		"ends_at":   endsAt.Format(time.RFC3339),
+		"reason":    "vacation",
+	}
+
+	mockService.On("AddUserAbsence", mock.Anything, mock.MatchedBy(func(input *domain.AddUserAbsenceInput) bool {
+		return input.UserID == "u1" &&
+			input.StartsAt.Equal(startsAt) &&
+			input.EndsAt.Equal(endsAt) &&
+			input.Reason == "vacation"
+	})).Return(&domain.UserAbsence{
+		ID:       1,
+		UserID:   "u1",
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Reason:   "vacation",
+	}, nil)
+
+	// Act
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/users/absence/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	absence := response["absence"].(map[string]interface{})
+	assert.Equal(t, float64(1), absence["absence_id"])
+	assert.Equal(t, "vacation", absence["reason"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestBulkReassignInactiveHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("BulkReassignInactiveReviewers", mock.Anything, &domain.BulkReassignInactiveInput{
+		TeamName: "backend",
+		DryRun:   true,
+	}).Return(&domain.BulkReassignInactiveResult{
+		DryRun: true,
+		PullRequests: []domain.BulkReassignPullRequestResult{
+			{
+				PullRequestID: "pr-1",
+				Replaced:      []domain.ReviewerReassignment{{OldReviewerID: "u2", NewReviewerID: "u3", NewReviewerTeam: "backend"}},
+				Removed:       []string{},
+				Failed:        []string{},
+			},
+			{
+				PullRequestID: "pr-2",
+				Replaced:      []domain.ReviewerReassignment{},
+				Removed:       []string{},
+				Failed:        []string{"u4"},
+				Failure:       domain.ErrInternal,
+			},
+		},
+	}, nil)
+
+	// Act
+	body := []byte(`{"team_name":"backend","dry_run":true}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassignInactiveBulk", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.True(t, response["dry_run"].(bool))
+	prs := response["pull_requests"].([]interface{})
+	require.Len(t, prs, 2)
+
+	first := prs[0].(map[string]interface{})
+	assert.Nil(t, first["error"])
+	replaced := first["replaced"].([]interface{})
+	assert.Equal(t, "u3", replaced[0].(map[string]interface{})["new_reviewer_id"])
+
+	second := prs[1].(map[string]interface{})
+	assert.Equal(t, []interface{}{"u4"}, second["failed"])
+	assert.Equal(t, "INTERNAL_ERROR", second["error"].(map[string]interface{})["code"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateWebhookHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("CreateWebhook", mock.Anything, &domain.CreateWebhookInput{
+		URL:        "https://ci.example.com/hooks",
+		Secret:     "s3cret",
+		EventTypes: []domain.WebhookEventType{domain.WebhookEventPullRequestMerged},
+	}).Return(&domain.WebhookSubscription{
+		ID:         7,
+		URL:        "https://ci.example.com/hooks",
+		Secret:     "s3cret",
+		EventTypes: []domain.WebhookEventType{domain.WebhookEventPullRequestMerged},
+		CreatedAt:  time.Now(),
+	}, nil)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"url":         "https://ci.example.com/hooks",
+		"secret":      "s3cret",
+		"event_types": []string{"pull_request.merged"},
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/create", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	webhook := response["webhook"].(map[string]interface{})
+	assert.Equal(t, float64(7), webhook["subscription_id"])
+	assert.Equal(t, []interface{}{"pull_request.merged"}, webhook["event_types"])
+	assert.NotContains(t, webhook, "secret")
+}
+
+func TestGetWebhookDeliveriesHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("GetWebhookDeliveries", mock.Anything, &domain.WebhookDeliveriesInput{
+		SubscriptionID: 7,
+		Status:         domain.WebhookDeliveryPending,
+	}).Return([]domain.WebhookDelivery{
+		{
+			ID:             3,
+			SubscriptionID: 7,
+			EventID:        "evt-1",
+			EventType:      domain.WebhookEventPullRequestCreated,
+			Payload:        []byte(`{"event_type":"pull_request.created"}`),
+			Status:         domain.WebhookDeliveryPending,
+			Attempts:       2,
+			LastStatusCode: http.StatusBadGateway,
+			LastError:      "unexpected response status 502",
+		},
+	}, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/deliveries?subscription_id=7&status=PENDING", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	deliveries := response["deliveries"].([]interface{})
+	require.Len(t, deliveries, 1)
+
+	delivery := deliveries[0].(map[string]interface{})
+	assert.Equal(t, float64(2), delivery["attempts"])
+	assert.Equal(t, float64(http.StatusBadGateway), delivery["last_status_code"])
+	assert.Equal(t, "pull_request.created", delivery["payload"].(map[string]interface{})["event_type"])
+}
+
+func TestGetWebhookDeliveriesHandler_InvalidSubscriptionID(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/deliveries?subscription_id=abc", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// githubWebhookRequest готовит запрос вебхука GitHub с записанным payload из tests/testdata/github,
+// подписанный секретом secret
+func githubWebhookRequest(t *testing.T, fixture, event, deliveryID, secret string) *http.Request {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("..", "..", "testdata", "github", fixture))
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	req := httptest.NewRequest(http.MethodPost, "/integrations/github", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", deliveryID)
+	req.Header.Set(middleware.GitHubSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestGitHubWebhookHandler_MapsFixturesToActions(t *testing.T) {
+	t.Setenv("GITHUB_WEBHOOK_SECRET", "gh-secret")
+
+	tests := []struct {
+		fixture string
+		action  domain.ExternalPullRequestAction
+		draft   bool
+	}{
+		{fixture: "pull_request_opened.json", action: domain.ExternalActionOpened},
+		{fixture: "pull_request_opened_draft.json", action: domain.ExternalActionOpened, draft: true},
+		{fixture: "pull_request_ready_for_review.json", action: domain.ExternalActionReadyForReview},
+		{fixture: "pull_request_closed.json", action: domain.ExternalActionClosed},
+		{fixture: "pull_request_reopened.json", action: domain.ExternalActionReopened},
+		{fixture: "pull_request_closed_merged.json", action: domain.ExternalActionMerged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			// Arrange
+			mockService := mocks.NewAssignmentService(t)
+			router := setupTestRouter(mockService)
+
+			mockService.On("IngestPullRequestEvent", mock.Anything, &domain.ExternalPullRequestEvent{
+				Source:      domain.IngestionSourceGitHub,
+				DeliveryID:  "delivery-1",
+				Action:      tt.action,
+				Repository:  "octo-org/review-service",
+				Number:      42,
+				Title:       "Add reviewer load balancing",
+				AuthorLogin: "octocat",
+				Draft:       tt.draft,
+			}).Return(&domain.IngestionResult{
+				DeliveryID:    "delivery-1",
+				PullRequestID: "octo-org/review-service#42",
+				Action:        tt.action,
+				PullRequest:   &domain.PullRequest{ID: "octo-org/review-service#42", Status: domain.PullRequestStatusOpen},
+			}, nil)
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, githubWebhookRequest(t, tt.fixture, "pull_request", "delivery-1", "gh-secret"))
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var response map[string]interface{}
+			_ = json.Unmarshal(w.Body.Bytes(), &response)
+			assert.Equal(t, "processed", response["status"])
+			assert.Equal(t, "octo-org/review-service#42", response["pull_request_id"])
+			assert.Equal(t, string(tt.action), response["action"])
+		})
+	}
+}
+
+func TestGitHubWebhookHandler_RejectsInvalidSignature(t *testing.T) {
+	// Arrange
+	t.Setenv("GITHUB_WEBHOOK_SECRET", "gh-secret")
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, githubWebhookRequest(t, "pull_request_opened.json", "pull_request", "delivery-1", "wrong-secret"))
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestGitHubWebhookHandler_IgnoresUnsupportedAction(t *testing.T) {
+	// Arrange
+	t.Setenv("GITHUB_WEBHOOK_SECRET", "gh-secret")
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, githubWebhookRequest(t, "pull_request_labeled.json", "pull_request", "delivery-1", "gh-secret"))
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"ignored"`)
+}
+
+func TestGitHubWebhookHandler_UnknownAuthor(t *testing.T) {
+	// Arrange
+	t.Setenv("GITHUB_WEBHOOK_SECRET", "gh-secret")
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("IngestPullRequestEvent", mock.Anything, mock.Anything).
+		Return(nil, domain.ErrUnknownExternalUser)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, githubWebhookRequest(t, "pull_request_opened.json", "pull_request", "delivery-1", "gh-secret"))
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"UNKNOWN_USER"`)
+}
+
+// gitlabWebhookRequest готовит запрос вебхука GitLab с записанным payload из tests/testdata/gitlab
+func gitlabWebhookRequest(t *testing.T, fixture, event, deliveryID, token string) *http.Request {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("..", "..", "testdata", "gitlab", fixture))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/integrations/gitlab/webhook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", event)
+	req.Header.Set("X-Gitlab-Event-UUID", deliveryID)
+	req.Header.Set(middleware.GitLabTokenHeader, token)
+	return req
+}
+
+func TestGitLabWebhookHandler_MapsFixturesToActions(t *testing.T) {
+	t.Setenv("GITLAB_WEBHOOK_TOKEN", "gl-token")
+
+	tests := []struct {
+		fixture     string
+		action      domain.ExternalPullRequestAction
+		authorLogin string
+		draft       bool
+	}{
+		{fixture: "merge_request_open.json", action: domain.ExternalActionOpened, authorLogin: "jdoe"},
+		{fixture: "merge_request_open_draft.json", action: domain.ExternalActionOpened, authorLogin: "jdoe", draft: true},
+		{fixture: "merge_request_update_ready.json", action: domain.ExternalActionReadyForReview},
+		{fixture: "merge_request_close.json", action: domain.ExternalActionClosed},
+		{fixture: "merge_request_reopen.json", action: domain.ExternalActionReopened},
+		{fixture: "merge_request_merge.json", action: domain.ExternalActionMerged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			// Arrange
+			mockService := mocks.NewAssignmentService(t)
+			router := setupTestRouter(mockService)
+
+			title := "Ingest merge request events"
+			if tt.draft {
+				title = "Draft: " + title
+			}
+
+			mockService.On("IngestPullRequestEvent", mock.Anything, &domain.ExternalPullRequestEvent{
+				Source:      domain.IngestionSourceGitLab,
+				DeliveryID:  "delivery-1",
+				Action:      tt.action,
+				Repository:  "platform/backend/review-service",
+				Number:      17,
+				Title:       title,
+				AuthorLogin: tt.authorLogin,
+				Draft:       tt.draft,
+			}).Return(&domain.IngestionResult{
+				DeliveryID:    "delivery-1",
+				PullRequestID: "platform/backend/review-service!17",
+				Action:        tt.action,
+				PullRequest:   &domain.PullRequest{ID: "platform/backend/review-service!17", Status: domain.PullRequestStatusMerged},
+			}, nil)
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, gitlabWebhookRequest(t, tt.fixture, "Merge Request Hook", "delivery-1", "gl-token"))
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var response map[string]interface{}
+			_ = json.Unmarshal(w.Body.Bytes(), &response)
+			assert.Equal(t, "processed", response["status"])
+			assert.Equal(t, "platform/backend/review-service!17", response["pull_request_id"])
+			assert.Equal(t, string(tt.action), response["action"])
+			assert.NotContains(t, response, "quick_action")
+		})
+	}
+}
+
+func TestGitLabWebhookHandler_ReturnsReviewersForMergeRequest(t *testing.T) {
+	// Arrange
+	t.Setenv("GITLAB_WEBHOOK_TOKEN", "gl-token")
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("IngestPullRequestEvent", mock.Anything, mock.Anything).Return(&domain.IngestionResult{
+		DeliveryID:    "delivery-1",
+		PullRequestID: "platform/backend/review-service!17",
+		Action:        domain.ExternalActionOpened,
+		PullRequest: &domain.PullRequest{
+			ID:                "platform/backend/review-service!17",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"u2", "u3", "u4"},
+		},
+		ReviewerLogins:    []string{"alice", "bob"},
+		UnmappedReviewers: []string{"u4"},
+	}, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, gitlabWebhookRequest(t, "merge_request_open.json", "Merge Request Hook", "delivery-1", "gl-token"))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, []interface{}{"alice", "bob"}, response["reviewer_logins"])
+	assert.Equal(t, []interface{}{"u4"}, response["unmapped_reviewers"])
+	assert.Equal(t, "/assign_reviewer @alice @bob", response["quick_action"])
+}
+
+func TestGitLabWebhookHandler_RejectsInvalidToken(t *testing.T) {
+	// Arrange
+	t.Setenv("GITLAB_WEBHOOK_TOKEN", "gl-token")
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, gitlabWebhookRequest(t, "merge_request_open.json", "Merge Request Hook", "delivery-1", "wrong-token"))
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestGitLabWebhookHandler_IgnoresUnsupportedAction(t *testing.T) {
+	t.Setenv("GITLAB_WEBHOOK_TOKEN", "gl-token")
+
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, gitlabWebhookRequest(t, "merge_request_approved.json", "Merge Request Hook", "delivery-1", "gl-token"))
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"ignored"`)
+}
+
+func TestGitLabWebhookHandler_ReportsConvertToDraftAsUnsupported(t *testing.T) {
+	// Arrange
+	t.Setenv("GITLAB_WEBHOOK_TOKEN", "gl-token")
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, gitlabWebhookRequest(t, "merge_request_update_draft.json", "Merge Request Hook", "delivery-1", "gl-token"))
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "unsupported", response["status"])
+	assert.Contains(t, response["reason"], "back to draft")
+	mockService.AssertNotCalled(t, "IngestPullRequestEvent", mock.Anything, mock.Anything)
+}
+
+func TestCreatePullRequestHandler_PassesChangedFilesAndCodeOwners(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("CreatePullRequest", mock.Anything, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		Repository:      "octo-org/review-service",
+		ChangedFiles:    []string{"internal/api/handlers/user.go"},
+		CodeOwners:      "/internal/api/ @api-owner\n",
+	}).Return(&domain.PullRequest{
+		ID:                "pr-001",
+		Name:              "Add feature",
+		AuthorID:          "user-1",
+		Status:            domain.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user-7"},
+		Repository:        "octo-org/review-service",
+	}, nil)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"pull_request_id":   "pr-001",
+		"pull_request_name": "Add feature",
+		"author_id":         "user-1",
+		"repository":        "octo-org/review-service",
+		"changed_files":     []string{"internal/api/handlers/user.go"},
+		"codeowners":        "/internal/api/ @api-owner\n",
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"repository":"octo-org/review-service"`)
+}
+
+func TestSetCodeOwnersHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("SetCodeOwners", mock.Anything, &domain.SetCodeOwnersInput{
+		Repository: "octo-org/review-service",
+		Content:    "*.go @octo/backend\n",
+	}).Return(&domain.RepositoryCodeOwners{
+		Repository: "octo-org/review-service",
+		Content:    "*.go @octo/backend\n",
+		UpdatedAt:  time.Now(),
+	}, nil)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"repository": "octo-org/review-service",
+		"content":    "*.go @octo/backend\n",
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/codeowners/set", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	codeOwners := response["codeowners"].(map[string]interface{})
+	assert.Equal(t, "octo-org/review-service", codeOwners["repository"])
+	assert.Equal(t, "*.go @octo/backend\n", codeOwners["content"])
+}
+
+func TestSetCodeOwnersHandler_InvalidContent(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("SetCodeOwners", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidInput)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"repository": "octo-org/review-service",
+		"content":    "*.go backend\n",
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/codeowners/set", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetCodeOwnersHandler_NotFound(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("GetCodeOwners", mock.Anything, "octo-org/unknown").Return(nil, domain.ErrResourceNotFound)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/codeowners/get?repository=octo-org/unknown", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSetRepositoryHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("SetRepository", mock.Anything, &domain.SetRepositoryInput{
+		Name:       "octo-org/web-app",
+		OwnerTeams: []string{"frontend", "design"},
+	}).Return(&domain.Repository{
+		Name:       "octo-org/web-app",
+		OwnerTeams: []string{"frontend", "design"},
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}, nil)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"repository":  "octo-org/web-app",
+		"owner_teams": []string{"frontend", "design"},
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/repositories/set", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	repository := response["repository"].(map[string]interface{})
+	assert.Equal(t, "octo-org/web-app", repository["repository"])
+	assert.Equal(t, []interface{}{"frontend", "design"}, repository["owner_teams"])
+}
+
+func TestSetRepositoryHandler_MissingRepository(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"owner_teams": []string{"frontend"},
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/repositories/set", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListRepositoriesHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("ListRepositories", mock.Anything).Return([]domain.Repository{
+		{Name: "octo-org/review-service", OwnerTeams: []string{}},
+		{Name: "octo-org/web-app", OwnerTeams: []string{"frontend"}},
+	}, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/repositories/list", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	repositories := response["repositories"].([]interface{})
+	require.Len(t, repositories, 2)
+	assert.Equal(t, []interface{}{}, repositories[0].(map[string]interface{})["owner_teams"])
+}
+
+func TestCreatePullRequestHandler_PassesTeamContext(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("CreatePullRequest", mock.Anything, &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		TeamName:        "platform",
+	}).Return(&domain.PullRequest{
+		ID:                "pr-001",
+		Name:              "Add feature",
+		AuthorID:          "user-1",
+		Status:            domain.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user-5"},
+		TeamName:          "platform",
+	}, nil)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"pull_request_id":   "pr-001",
+		"pull_request_name": "Add feature",
+		"author_id":         "user-1",
+		"team_name":         "platform",
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"team_name":"platform"`)
+}
+
+func TestCreatePullRequestHandler_NotTeamMember(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("CreatePullRequest", mock.Anything, mock.Anything).Return(nil, domain.ErrNotTeamMember)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"pull_request_id":   "pr-001",
+		"pull_request_name": "Add feature",
+		"author_id":         "user-1",
+		"team_name":         "platform",
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"NOT_TEAM_MEMBER"`)
+}
+
+func TestAddTeamHandler_PassesMemberRoles(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("CreateTeam", mock.Anything, mock.MatchedBy(func(team *domain.Team) bool {
+		return len(team.Members) == 2 &&
+			team.Members[0].Role == domain.TeamRoleMaintainer &&
+			team.Members[1].Role == ""
+	})).Return(&domain.Team{
+		Name: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", Role: domain.TeamRoleMaintainer, IsActive: true},
+			{UserID: "u2", Username: "Bob", Role: domain.TeamRoleMember, IsActive: true},
+		},
+	}, nil)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "role": "MAINTAINER", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+		},
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	members := response["members"].([]interface{})
+	assert.Equal(t, "MAINTAINER", members[0].(map[string]interface{})["role"])
+	assert.Equal(t, "MEMBER", members[1].(map[string]interface{})["role"])
+}
+
+func TestAddTeamMemberHandler_DefaultsToActive(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("AddTeamMember", mock.Anything, mock.MatchedBy(func(input *domain.AddTeamMemberInput) bool {
+		return input.TeamName == "platform" &&
+			input.UserID == "user-1" &&
+			input.Role == domain.TeamRoleMaintainer &&
+			input.IsActive
+	})).Return(&domain.TeamMemberResult{
+		TeamName: "platform",
+		Membership: &domain.TeamMembership{
+			TeamName: "platform",
+			UserID:   "user-1",
+			Role:     domain.TeamRoleMaintainer,
+			IsActive: true,
+		},
+		PrimaryTeam: "backend",
+	}, nil)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"team_name": "platform",
+		"user_id":   "user-1",
+		"role":      "MAINTAINER",
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/team/members/add", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "backend", response["primary_team"])
+	membership := response["membership"].(map[string]interface{})
+	assert.Equal(t, "MAINTAINER", membership["role"])
+	assert.Equal(t, true, membership["is_active"])
+	assert.Empty(t, response["reassignments"])
+}
+
+func TestAddTeamMemberHandler_RequiresAdmin(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"team_name": "platform",
+		"user_id":   "user-1",
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/team/members/add", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockService.AssertNotCalled(t, "AddTeamMember", mock.Anything, mock.Anything)
+}
+
+func TestRemoveTeamMemberHandler_LastTeam(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("RemoveTeamMember", mock.Anything, mock.MatchedBy(func(input *domain.RemoveTeamMemberInput) bool {
+		return input.TeamName == "backend" && input.UserID == "user-1" && input.ReassignOpenReviews
+	})).Return(nil, domain.ErrLastTeam)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"team_name":             "backend",
+		"user_id":               "user-1",
+		"reassign_open_reviews": true,
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/team/members/remove", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"LAST_TEAM"`)
+}
+
+func TestMoveTeamMemberHandler_Success(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("MoveTeamMember", mock.Anything, mock.MatchedBy(func(input *domain.MoveTeamMemberInput) bool {
+		return input.FromTeam == "backend" && input.ToTeam == "platform" && input.UserID == "user-2"
+	})).Return(&domain.TeamMemberResult{
+		TeamName:    "platform",
+		Membership:  &domain.TeamMembership{TeamName: "platform", UserID: "user-2", Role: domain.TeamRoleMember, IsActive: true},
+		PrimaryTeam: "platform",
+		Reassignments: []domain.ReassignInactiveResult{
+			{
+				PullRequestID: "pr-001",
+				ReassignmentDetails: []domain.ReviewerReassignment{
+					{OldReviewerID: "user-2", NewReviewerID: "user-3", NewReviewerTeam: "backend"},
+				},
+			},
+		},
+	}, nil)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"from_team":             "backend",
+		"to_team":               "platform",
+		"user_id":               "user-2",
+		"reassign_open_reviews": true,
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/team/members/move", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"primary_team":"platform"`)
+	assert.Contains(t, w.Body.String(), `"pull_request_id":"pr-001"`)
+}
+
+func TestDeleteTeamHandler_HasOpenPRs(t *testing.T) {
+	// Arrange
+	mockService := mocks.NewAssignmentService(t)
+	router := setupTestRouter(mockService)
+
+	mockService.On("DeleteTeam", mock.Anything, "backend").Return(domain.ErrTeamHasOpenPRs)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{"team_name": "backend"})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/team/delete", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"TEAM_HAS_OPEN_PRS"`)
+}
@@ -1,10 +1,15 @@
 package middleware_test
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"avitoTechAutumn2025/internal/api/middleware"
@@ -39,6 +44,31 @@ func TestAuthMiddleware_AdminToken(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `"role":"admin"`)
 }
 
+func TestAuthMiddleware_SetsActorInRequestContext(t *testing.T) {
+	// Arrange
+	_ = os.Setenv("ADMIN_TOKEN", "test-admin-token")
+	defer func() { _ = os.Unsetenv("ADMIN_TOKEN") }()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.AuthMiddleware())
+
+	router.GET("/actor", func(c *gin.Context) {
+		actor, _ := c.Request.Context().Value(middleware.ActorKey).(string)
+		c.JSON(http.StatusOK, gin.H{"actor": actor})
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/actor", nil)
+	req.Header.Set("Authorization", "Bearer test-admin-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"actor":"admin"`)
+}
+
 func TestAuthMiddleware_UserToken(t *testing.T) {
 	// Arrange
 	_ = os.Setenv("ADMIN_TOKEN", "test-admin-token")
@@ -248,3 +278,101 @@ func TestRecoveryMiddleware(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	assert.Contains(t, w.Body.String(), "internal server error")
 }
+
+func TestRequireGitHubSignature_RejectsWhenSecretMissing(t *testing.T) {
+	// Arrange
+	_ = os.Unsetenv("GITHUB_WEBHOOK_SECRET")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/github", middleware.RequireGitHubSignature(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	// Подпись пустым ключом не должна проходить, когда секрет не настроен
+	body := `{"action":"opened"}`
+	mac := hmac.New(sha256.New, nil)
+	mac.Write([]byte(body))
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/github", strings.NewReader(body))
+	req.Header.Set(middleware.GitHubSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRequireGitHubSignature_PassesBodyAndActor(t *testing.T) {
+	// Arrange
+	t.Setenv("GITHUB_WEBHOOK_SECRET", "gh-secret")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/github", middleware.RequireGitHubSignature(), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		actor, _ := c.Request.Context().Value(middleware.ActorKey).(string)
+		c.JSON(http.StatusOK, gin.H{"body": string(body), "actor": actor})
+	})
+
+	body := `{"action":"opened"}`
+	mac := hmac.New(sha256.New, []byte("gh-secret"))
+	mac.Write([]byte(body))
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/github", strings.NewReader(body))
+	req.Header.Set(middleware.GitHubSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"actor":"github"`)
+	assert.Contains(t, w.Body.String(), `{\"action\":\"opened\"}`)
+}
+
+func TestRequireGitLabToken_RejectsWhenTokenMissing(t *testing.T) {
+	// Arrange
+	_ = os.Unsetenv("GITLAB_WEBHOOK_TOKEN")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/gitlab", middleware.RequireGitLabToken(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	// Пустой токен не должен проходить, когда токен не настроен
+	req := httptest.NewRequest(http.MethodPost, "/gitlab", strings.NewReader(`{}`))
+	req.Header.Set(middleware.GitLabTokenHeader, "")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRequireGitLabToken_SetsActor(t *testing.T) {
+	// Arrange
+	t.Setenv("GITLAB_WEBHOOK_TOKEN", "gl-token")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/gitlab", middleware.RequireGitLabToken(), func(c *gin.Context) {
+		actor, _ := c.Request.Context().Value(middleware.ActorKey).(string)
+		c.JSON(http.StatusOK, gin.H{"actor": actor})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/gitlab", strings.NewReader(`{}`))
+	req.Header.Set(middleware.GitLabTokenHeader, "gl-token")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"actor":"gitlab"`)
+}
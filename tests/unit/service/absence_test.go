@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddUserAbsence_InvalidPeriod(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+
+	svc := service.New(mockTxMgr)
+
+	now := time.Now()
+	input := &domain.AddUserAbsenceInput{
+		UserID:   "user-1",
+		StartsAt: now,
+		EndsAt:   now.Add(-time.Hour),
+		Reason:   "vacation",
+	}
+
+	// Act
+	result, err := svc.AddUserAbsence(context.Background(), input)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockTxMgr.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+}
+
+func TestReassignAbsentReviewers_ReplacesOpenReviews(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	absence := &domain.UserAbsence{
+		ID:       7,
+		UserID:   "user-2",
+		StartsAt: time.Now().Add(-time.Minute),
+		EndsAt:   time.Now().Add(24 * time.Hour),
+	}
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	// Первая транзакция забирает период отсутствия, вторая не находит больше ни одного
+	mockUserRepo.On("ClaimStartedAbsence", mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(absence, nil).Once()
+	mockUserRepo.On("ClaimStartedAbsence", mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(nil, storage.ErrNotFound).Once()
+
+	// Переназначаются только открытые PR - смерженные отфильтрованы запросом
+	mockPRRepo.On("GetPRsReviewedByUser", mock.Anything, "user-2",
+		[]domain.PullRequestStatus{domain.PullRequestStatusOpen}, (*domain.PageCursor)(nil), 0).
+		Return([]domain.PullRequestShort{
+			{ID: "pr-open", AuthorID: "user-1", Status: domain.PullRequestStatusOpen},
+		}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-open").
+		Return(&domain.PullRequest{
+			ID:                "pr-open",
+			AuthorID:          "user-1",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"user-2"},
+		}, nil)
+
+	mockUserRepo.On("GetByID", mock.Anything, "user-2").
+		Return(&domain.User{UserID: "user-2", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+		Return(nil, storage.ErrNotFound)
+
+	// Отсутствующий пользователь уже отфильтрован репозиторием
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "backend").
+		Return(testCandidates("user-1", "user-3"), nil)
+
+	mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-open", "user-2").Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-open", "user-3").Return(nil)
+	mockUserRepo.On("MarkAbsenceReassigned", mock.Anything, int64(7), mock.AnythingOfType("time.Time")).
+		Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+			require.NoError(t, fn(context.Background(), mockTx))
+		}).Return(nil)
+
+	// Act
+	processed, err := svc.ReassignAbsentReviewers(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+}
+
+func TestReassignAbsentReviewers_FailedAbsenceDoesNotBlockOthers(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	failing := &domain.UserAbsence{ID: 7, UserID: "user-2", ReassignAttempts: 2}
+	next := &domain.UserAbsence{ID: 8, UserID: "user-4"}
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+
+	// Отложенный период повторно не забирается, поэтому третья транзакция не находит ни одного
+	mockUserRepo.On("ClaimStartedAbsence", mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(failing, nil).Once()
+	mockUserRepo.On("ClaimStartedAbsence", mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(next, nil).Once()
+	mockUserRepo.On("ClaimStartedAbsence", mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(nil, storage.ErrNotFound).Once()
+
+	mockPRRepo.On("GetPRsReviewedByUser", mock.Anything, "user-2",
+		[]domain.PullRequestStatus{domain.PullRequestStatusOpen}, (*domain.PageCursor)(nil), 0).
+		Return(nil, domain.ErrAllAtCapacity)
+	mockPRRepo.On("GetPRsReviewedByUser", mock.Anything, "user-4",
+		[]domain.PullRequestStatus{domain.PullRequestStatusOpen}, (*domain.PageCursor)(nil), 0).
+		Return([]domain.PullRequestShort{}, nil)
+
+	// Третья неудача подряд откладывает период на backoff * 2^2
+	mockUserRepo.On("RecordAbsenceFailure", mock.Anything, int64(7), domain.ErrAllAtCapacity.Error(),
+		mock.MatchedBy(func(nextAttemptAt time.Time) bool {
+			return nextAttemptAt.After(time.Now().Add(3*time.Minute)) && nextAttemptAt.Before(time.Now().Add(5*time.Minute))
+		})).Return(nil)
+	mockUserRepo.On("MarkAbsenceReassigned", mock.Anything, int64(8), mock.AnythingOfType("time.Time")).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	processed, err := svc.ReassignAbsentReviewers(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	mockUserRepo.AssertNotCalled(t, "MarkAbsenceReassigned", mock.Anything, int64(7), mock.Anything)
+}
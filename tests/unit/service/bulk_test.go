@@ -0,0 +1,268 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// runInTx выполняет функцию транзакции на mockTx и возвращает её ошибку, как настоящий TxManager
+func runInTx(mockTx *mocks.Tx) func(context.Context, func(context.Context, storage.Tx) error) error {
+	return func(ctx context.Context, fn func(context.Context, storage.Tx) error) error {
+		return fn(ctx, mockTx)
+	}
+}
+
+// bulkTestMocks настраивает репозитории для PR pr-1 (inactive-1 заменяется на user-3)
+// и pr-2 (inactive-2 снимается: в его команде нет активных кандидатов)
+func bulkTestMocks(t *testing.T) (*mocks.Tx, *mocks.PullRequestRepository) {
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockTx.On("UserRepo").Return(mockUserRepo).Maybe()
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockTx.On("TeamRepo").Return(mockTeamRepo).Maybe()
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+		Return(&domain.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "user-1",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"inactive-1"},
+		}, nil).Maybe()
+	mockPRRepo.On("GetByID", mock.Anything, "pr-2").
+		Return(&domain.PullRequest{
+			ID:                "pr-2",
+			AuthorID:          "user-1",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"inactive-2"},
+		}, nil).Maybe()
+	mockPRRepo.On("GetInactiveReviewers", mock.Anything, "pr-1").Return([]string{"inactive-1"}, nil).Maybe()
+	mockPRRepo.On("GetInactiveReviewers", mock.Anything, "pr-2").Return([]string{"inactive-2"}, nil).Maybe()
+
+	mockUserRepo.On("GetByID", mock.Anything, "inactive-1").
+		Return(&domain.User{UserID: "inactive-1", TeamName: "backend"}, nil).Maybe()
+	mockUserRepo.On("GetByID", mock.Anything, "inactive-2").
+		Return(&domain.User{UserID: "inactive-2", TeamName: "frontend"}, nil).Maybe()
+	mockTeamRepo.On("GetSettings", mock.Anything, mock.Anything).Return(nil, storage.ErrNotFound).Maybe()
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "backend").
+		Return(testCandidates("user-1", "user-3"), nil).Maybe()
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "frontend").
+		Return([]domain.User{}, nil).Maybe()
+
+	mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-1", "inactive-1").Return(nil).Maybe()
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-1", "user-3").Return(nil).Maybe()
+	mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-2", "inactive-2").Return(nil).Maybe()
+
+	return mockTx, mockPRRepo
+}
+
+func TestBulkReassignInactiveReviewers_ReplacesAndRemoves(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx, mockPRRepo := bulkTestMocks(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockPRRepo.On("GetOpenPRIDsWithInactiveReviewers", mock.Anything, "", []string(nil)).
+		Return([]string{"pr-1", "pr-2"}, nil)
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.BulkReassignInactiveReviewers(context.Background(), &domain.BulkReassignInactiveInput{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, result.DryRun)
+	require.Len(t, result.PullRequests, 2)
+
+	assert.Equal(t, "pr-1", result.PullRequests[0].PullRequestID)
+	require.Len(t, result.PullRequests[0].Replaced, 1)
+	assert.Equal(t, "user-3", result.PullRequests[0].Replaced[0].NewReviewerID)
+	assert.Empty(t, result.PullRequests[0].Removed)
+
+	assert.Equal(t, "pr-2", result.PullRequests[1].PullRequestID)
+	assert.Equal(t, []string{"inactive-2"}, result.PullRequests[1].Removed)
+	assert.Nil(t, result.PullRequests[1].Failure)
+
+	// Оба PR обработаны одной транзакцией порции (плюс транзакция выборки)
+	mockTxMgr.AssertNumberOfCalls(t, "Do", 2)
+}
+
+func TestBulkReassignInactiveReviewers_DryRun(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx, mockPRRepo := bulkTestMocks(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockPRRepo.On("GetOpenPRIDsWithInactiveReviewers", mock.Anything, "", []string{"pr-1"}).
+		Return([]string{"pr-1"}, nil)
+
+	var chunkErr error
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(func(ctx context.Context, fn func(context.Context, storage.Tx) error) error {
+			chunkErr = fn(ctx, mockTx)
+			return chunkErr
+		})
+
+	// Act
+	result, err := svc.BulkReassignInactiveReviewers(context.Background(), &domain.BulkReassignInactiveInput{
+		PullRequestIDs: []string{"pr-1"},
+		DryRun:         true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	require.Len(t, result.PullRequests, 1)
+	assert.Equal(t, "user-3", result.PullRequests[0].Replaced[0].NewReviewerID)
+
+	// Транзакция порции завершилась ошибкой, то есть откатилась
+	assert.Error(t, chunkErr)
+}
+
+func TestBulkReassignInactiveReviewers_FailedPullRequestDoesNotBlockOthers(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockPRRepo.On("GetOpenPRIDsWithInactiveReviewers", mock.Anything, "", []string(nil)).
+		Return([]string{"pr-broken", "pr-2"}, nil)
+
+	// pr-broken не читается - порция откатывается, PR повторяются по одному
+	mockPRRepo.On("GetByID", mock.Anything, "pr-broken").Return(nil, errors.New("connection reset"))
+	mockPRRepo.On("GetInactiveReviewers", mock.Anything, "pr-broken").Return([]string{"inactive-1"}, nil)
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-2").
+		Return(&domain.PullRequest{
+			ID:                "pr-2",
+			AuthorID:          "user-1",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"inactive-2"},
+		}, nil)
+	mockPRRepo.On("GetInactiveReviewers", mock.Anything, "pr-2").Return([]string{"inactive-2"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "inactive-2").
+		Return(&domain.User{UserID: "inactive-2", TeamName: "frontend"}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "frontend").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "frontend").Return([]domain.User{}, nil)
+	mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-2", "inactive-2").Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.BulkReassignInactiveReviewers(context.Background(), &domain.BulkReassignInactiveInput{})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.PullRequests, 2)
+
+	broken := result.PullRequests[0]
+	assert.Equal(t, "pr-broken", broken.PullRequestID)
+	require.NotNil(t, broken.Failure)
+	assert.Equal(t, domain.ErrorCodeInternalError, broken.Failure.Code)
+	assert.Equal(t, []string{"inactive-1"}, broken.Failed)
+
+	assert.Equal(t, "pr-2", result.PullRequests[1].PullRequestID)
+	assert.Equal(t, []string{"inactive-2"}, result.PullRequests[1].Removed)
+	assert.Nil(t, result.PullRequests[1].Failure)
+}
+
+func TestBulkReassignInactiveReviewers_CancelledReturnsCommittedChunks(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx, mockPRRepo := bulkTestMocks(t)
+
+	svc := service.New(mockTxMgr)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Первая порция: pr-1 и уже смерженные PR, вторая начинается с pr-2
+	prIDs := []string{"pr-1"}
+	for i := 1; i < domain.BulkReassignChunkSize; i++ {
+		prIDs = append(prIDs, fmt.Sprintf("pr-merged-%d", i))
+	}
+	prIDs = append(prIDs, "pr-2")
+
+	mockPRRepo.On("GetOpenPRIDsWithInactiveReviewers", mock.Anything, "", []string(nil)).Return(prIDs, nil)
+	mockPRRepo.On("GetByID", mock.Anything, mock.Anything).
+		Return(&domain.PullRequest{Status: domain.PullRequestStatusMerged}, nil)
+
+	calls := 0
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(func(ctx context.Context, fn func(context.Context, storage.Tx) error) error {
+			calls++
+			err := fn(ctx, mockTx)
+			// Запрос отменяется после коммита первой порции
+			if calls == 2 {
+				cancel()
+			}
+			return err
+		})
+
+	// Act
+	result, err := svc.BulkReassignInactiveReviewers(ctx, &domain.BulkReassignInactiveInput{})
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	require.NotNil(t, result)
+	require.Len(t, result.PullRequests, 1)
+	assert.Equal(t, "pr-1", result.PullRequests[0].PullRequestID)
+	require.Len(t, result.PullRequests[0].Replaced, 1)
+
+	mockPRRepo.AssertNotCalled(t, "GetByID", mock.Anything, "pr-2")
+	mockTxMgr.AssertNumberOfCalls(t, "Do", 2)
+}
+
+func TestBulkReassignInactiveReviewers_TeamNotFound(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+	mockTeamRepo.On("GetByName", mock.Anything, "ghost").Return(nil, storage.ErrNotFound)
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.BulkReassignInactiveReviewers(context.Background(), &domain.BulkReassignInactiveInput{TeamName: "ghost"})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrResourceNotFound)
+}
@@ -0,0 +1,306 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// codeOwnersTestMocks настраивает создание PR pr-001 автора user-1 из команды backend (2 ревьювера)
+// и возвращает сохранённое объяснение выбора
+func codeOwnersTestMocks(t *testing.T, teamMembers []domain.User) (*mocks.Tx, *mocks.UserRepository, **domain.SelectionExplanation) {
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "backend").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").Return(teamMembers, nil)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, "backend").Return([]domain.SelectionExclusion{}, nil)
+
+	mockPRRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", mock.AnythingOfType("string")).Return(nil)
+
+	recorded := new(*domain.SelectionExplanation)
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*recorded = args.Get(1).(*domain.SelectionExplanation)
+		}).Return(nil)
+
+	return mockTx, mockUserRepo, recorded
+}
+
+func TestCreatePullRequest_PrioritizesCodeOwnersAndRecordsMatchedRules(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx, mockUserRepo, recorded := codeOwnersTestMocks(t, testCandidates("user-2", "user-3"))
+
+	svc := service.New(mockTxMgr,
+		service.WithExternalUsers(domain.IngestionSourceGitHub, map[string]string{"api-owner": "user-7"}))
+
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "dba").
+		Return([]domain.User{{UserID: "user-8", TeamName: "dba", IsActive: true}}, nil)
+	mockUserRepo.On("GetActiveByIDs", mock.Anything, []string{"user-7"}).
+		Return([]domain.User{{UserID: "user-7", TeamName: "platform", IsActive: true}}, nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	codeOwners := "# Владельцы по умолчанию\n" +
+		"*               @backend-lead\n" +
+		"/docs/          @docs-owner\n" +
+		"*.sql           @octo/dba\n" +
+		"/internal/api/  @api-owner # API\n"
+
+	// Act
+	pr, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		ChangedFiles:    []string{"internal/api/handlers/user.go", "migrations/015_codeowners.up.sql"},
+		CodeOwners:      codeOwners,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user-7", "user-8"}, pr.AssignedReviewers)
+	assert.Equal(t, map[string]string{"user-7": "platform", "user-8": "dba"}, pr.ReviewerTeams)
+
+	// Правило "*" перекрыто более поздними правилами и в объяснение не попадает
+	explanation := *recorded
+	require.NotNil(t, explanation)
+	assert.Equal(t, []domain.CodeOwnersMatch{
+		{Line: 4, Pattern: "*.sql", Owners: []string{"@octo/dba"}, Paths: []string{"migrations/015_codeowners.up.sql"}},
+		{Line: 5, Pattern: "/internal/api/", Owners: []string{"@api-owner"}, Paths: []string{"internal/api/handlers/user.go"}},
+	}, explanation.CodeOwners)
+
+	// Владельцев хватило - команда автора не рассматривалась
+	require.Len(t, explanation.Teams, 1)
+	assert.True(t, explanation.Teams[0].CodeOwners)
+	assert.Equal(t, "backend", explanation.Teams[0].TeamName)
+	assert.Equal(t, []string{"user-8", "user-7"}, explanation.Teams[0].Candidates)
+}
+
+func TestCreatePullRequest_TopsUpCodeOwnersFromTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx, mockUserRepo, recorded := codeOwnersTestMocks(t, testCandidates("user-2", "user-7"))
+
+	svc := service.New(mockTxMgr)
+
+	// Автор владеет файлом сам, а user-9 деактивирован
+	mockUserRepo.On("GetActiveByIDs", mock.Anything, []string{"user-1", "user-7", "user-9"}).
+		Return([]domain.User{
+			{UserID: "user-7", TeamName: "backend", IsActive: true},
+			{UserID: "user-1", TeamName: "backend", IsActive: true},
+		}, nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	pr, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		ChangedFiles:    []string{"/internal/service/assignment.go"},
+		CodeOwners:      "internal/service/ @user-1 @user-7 @user-9\n",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-7", "user-2"}, pr.AssignedReviewers)
+
+	explanation := *recorded
+	require.NotNil(t, explanation)
+	require.Len(t, explanation.CodeOwners, 1)
+	assert.Equal(t, []string{"internal/service/assignment.go"}, explanation.CodeOwners[0].Paths)
+
+	require.Len(t, explanation.Teams, 2)
+	assert.True(t, explanation.Teams[0].CodeOwners)
+	assert.Equal(t, []string{"user-7"}, explanation.Teams[0].Selected)
+	assert.False(t, explanation.Teams[1].CodeOwners)
+	assert.Equal(t, []string{"user-2"}, explanation.Teams[1].Candidates)
+	assert.Equal(t, []string{"user-7", "user-2"}, explanation.Selected)
+}
+
+func TestCreatePullRequest_OverloadedCodeOwnerInTeamAssignedOnce(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+		Return(&domain.TeamSettings{
+			TeamName:       "backend",
+			ReviewerCount:  2,
+			MaxOpenReviews: 1,
+			CapacityPolicy: domain.CapacityPolicyAssignLeastLoaded,
+		}, nil)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, "backend").Return([]domain.SelectionExclusion{}, nil)
+
+	// user-7 владеет файлом и состоит в команде: оба пула видят его перегруженным
+	mockUserRepo.On("GetActiveByIDs", mock.Anything, []string{"user-7"}).
+		Return(testCandidates("user-7"), nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
+		Return(testCandidates("user-2", "user-7"), nil)
+	mockPRRepo.On("GetOpenReviewCounts", mock.Anything, []string{"user-7"}).
+		Return(map[string]int{"user-7": 1}, nil)
+	mockPRRepo.On("GetOpenReviewCounts", mock.Anything, []string{"user-2", "user-7"}).
+		Return(map[string]int{"user-2": 3, "user-7": 1}, nil)
+
+	mockPRRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", "user-7").Return(nil).Once()
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", "user-2").Return(nil).Once()
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	pr, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		ChangedFiles:    []string{"internal/service/assignment.go"},
+		CodeOwners:      "/internal/service/ @user-7\n",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-7", "user-2"}, pr.AssignedReviewers)
+}
+
+func TestCreatePullRequest_RegisteredCodeOwnersWithoutMatchFallsBackToTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx, _, recorded := codeOwnersTestMocks(t, testCandidates("user-2", "user-3"))
+	mockCodeOwnersRepo := mocks.NewCodeOwnersRepository(t)
+	mockRepositoryRepo := mocks.NewRepositoryRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Репозиторий не зарегистрирован - ревьюверы из команды автора
+	mockTx.On("RepositoryRepo").Return(mockRepositoryRepo)
+	mockRepositoryRepo.On("Get", mock.Anything, "octo-org/review-service").Return(nil, storage.ErrNotFound)
+
+	mockTx.On("CodeOwnersRepo").Return(mockCodeOwnersRepo)
+	mockCodeOwnersRepo.On("Get", mock.Anything, "octo-org/review-service").
+		Return(&domain.RepositoryCodeOwners{Repository: "octo-org/review-service", Content: "docs/* @docs-owner\n"}, nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	pr, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		Repository:      "octo-org/review-service",
+		ChangedFiles:    []string{"docs/guides/setup.md"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user-2", "user-3"}, pr.AssignedReviewers)
+	assert.Equal(t, "octo-org/review-service", pr.Repository)
+
+	// docs/* не распространяется на вложенные каталоги: CODEOWNERS применялся, но ничего не совпало
+	explanation := *recorded
+	require.NotNil(t, explanation)
+	assert.NotNil(t, explanation.CodeOwners)
+	assert.Empty(t, explanation.CodeOwners)
+	require.Len(t, explanation.Teams, 2)
+	assert.Empty(t, explanation.Teams[0].Candidates)
+	assert.ElementsMatch(t, []string{"user-2", "user-3"}, explanation.Teams[1].Selected)
+}
+
+func TestCreatePullRequest_InvalidCodeOwners(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	svc := service.New(mockTxMgr)
+
+	// Act
+	pr, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		ChangedFiles:    []string{"main.go"},
+		CodeOwners:      "*.go backend-team\n",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, pr)
+}
+
+func TestSetCodeOwners_Success(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockCodeOwnersRepo := mocks.NewCodeOwnersRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("CodeOwnersRepo").Return(mockCodeOwnersRepo)
+	mockCodeOwnersRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(codeOwners *domain.RepositoryCodeOwners) bool {
+		return codeOwners.Repository == "octo-org/review-service" && !codeOwners.UpdatedAt.IsZero()
+	})).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	codeOwners, err := svc.SetCodeOwners(context.Background(), &domain.SetCodeOwnersInput{
+		Repository: "octo-org/review-service",
+		Content:    "[Backend]\n*.go @octo/backend dev@example.com\n",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "octo-org/review-service", codeOwners.Repository)
+}
+
+func TestSetCodeOwners_RejectsInvalidContent(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	svc := service.New(mockTxMgr)
+
+	// Act
+	codeOwners, err := svc.SetCodeOwners(context.Background(), &domain.SetCodeOwnersInput{
+		Repository: "octo-org/review-service",
+		Content:    "/ @octo/backend\n",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, codeOwners)
+}
@@ -0,0 +1,155 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// rollbackTx выполняет функцию транзакции на mockTx и сохраняет её ошибку в txErr:
+// ненулевая ошибка означает, что настоящий TxManager откатил бы транзакцию
+func rollbackTx(mockTx *mocks.Tx, txErr *error) func(context.Context, func(context.Context, storage.Tx) error) error {
+	return func(ctx context.Context, fn func(context.Context, storage.Tx) error) error {
+		*txErr = fn(ctx, mockTx)
+		return *txErr
+	}
+}
+
+func TestCreatePullRequest_DryRun(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "backend").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
+		Return(testCandidates("user-2", "user-3"), nil)
+	mockPRRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", mock.AnythingOfType("string")).Return(nil)
+
+	var txErr error
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(rollbackTx(mockTx, &txErr))
+
+	// Act
+	result, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		DryRun:          true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "pr-001", result.ID)
+	assert.ElementsMatch(t, []string{"user-2", "user-3"}, result.AssignedReviewers)
+	assert.Error(t, txErr)
+}
+
+func TestCreatePullRequest_DryRunKeepsValidationErrors(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+		Return(&domain.PullRequest{ID: "pr-001"}, nil)
+
+	var txErr error
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(rollbackTx(mockTx, &txErr))
+
+	// Act
+	result, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		DryRun:          true,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrPRExists)
+	assert.Nil(t, result)
+}
+
+func TestReassignInactiveReviewers_DryRun(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx, _ := bulkTestMocks(t)
+
+	svc := service.New(mockTxMgr)
+
+	var txErr error
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(rollbackTx(mockTx, &txErr))
+
+	// Act
+	result, err := svc.ReassignInactiveReviewers(context.Background(), &domain.ReassignInactiveInput{
+		PullRequestID: "pr-1",
+		DryRun:        true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.ReassignmentDetails, 1)
+	assert.Equal(t, "inactive-1", result.ReassignmentDetails[0].OldReviewerID)
+	assert.Equal(t, "user-3", result.ReassignmentDetails[0].NewReviewerID)
+	assert.Error(t, txErr)
+}
+
+func TestDeactivateTeamMembers_DryRun(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+	mockTeamRepo.On("GetByName", mock.Anything, "backend").
+		Return(&domain.Team{
+			Name:    "backend",
+			Members: []domain.TeamMember{{UserID: "u1"}, {UserID: "u2"}},
+		}, nil)
+	mockTeamRepo.On("DeactivateAllMembers", mock.Anything, "backend").Return(2, nil)
+
+	var txErr error
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(rollbackTx(mockTx, &txErr))
+
+	// Act
+	result, err := svc.DeactivateTeamMembers(context.Background(), &domain.DeactivateTeamInput{
+		TeamName: "backend",
+		DryRun:   true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.DeactivatedUserCount)
+	assert.Error(t, txErr)
+}
@@ -0,0 +1,204 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePullRequest_RecordsSelectionExplanation(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr, service.WithDefaultStrategy(domain.SelectionStrategyRoundRobin))
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+		Return(&domain.TeamSettings{TeamName: "backend", ReviewerCount: 2, MaxOpenReviews: 3}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
+		Return(testCandidates("user-2", "user-3", "user-4"), nil)
+
+	// user-5 деактивирован, user-6 в отпуске, user-4 упёрся в лимит
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, "backend").
+		Return([]domain.SelectionExclusion{
+			{UserID: "user-5", Reason: domain.ExclusionReasonInactive},
+			{UserID: "user-6", Reason: domain.ExclusionReasonOutOfOffice},
+		}, nil)
+	mockPRRepo.On("GetOpenReviewCounts", mock.Anything, []string{"user-2", "user-3", "user-4"}).
+		Return(map[string]int{"user-2": 0, "user-3": 1, "user-4": 3}, nil)
+
+	mockPRRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", mock.AnythingOfType("string")).Return(nil)
+
+	var recorded *domain.SelectionExplanation
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			recorded = args.Get(1).(*domain.SelectionExplanation)
+		}).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	_, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	assert.Equal(t, "pr-001", recorded.PullRequestID)
+	assert.Equal(t, domain.SelectionTriggerCreated, recorded.Trigger)
+	assert.Equal(t, 2, recorded.RequestedCount)
+	assert.Equal(t, []string{"user-2", "user-3"}, recorded.Selected)
+	assert.False(t, recorded.OverCapacity)
+
+	require.Len(t, recorded.Teams, 1)
+	assert.Equal(t, "backend", recorded.Teams[0].TeamName)
+	assert.Equal(t, domain.SelectionStrategyRoundRobin, recorded.Teams[0].Strategy)
+	assert.False(t, recorded.Teams[0].Fallback)
+	assert.Equal(t, []string{"user-2", "user-3"}, recorded.Teams[0].Candidates)
+
+	assert.Equal(t, []domain.SelectionExclusion{
+		{UserID: "user-1", Reason: domain.ExclusionReasonAuthor},
+		{UserID: "user-5", Reason: domain.ExclusionReasonInactive},
+		{UserID: "user-6", Reason: domain.ExclusionReasonOutOfOffice},
+		{UserID: "user-4", Reason: domain.ExclusionReasonAtCapacity},
+	}, recorded.Excluded)
+}
+
+func TestReassignInactiveReviewers_RecordsExplanationWhenNobodySelected(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+		Return(&domain.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "user-1",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"inactive-1"},
+		}, nil)
+	mockPRRepo.On("GetInactiveReviewers", mock.Anything, "pr-1").Return([]string{"inactive-1"}, nil)
+
+	// В команде inactive-1 нет никого, кроме него самого - ревьювер снимается
+	mockUserRepo.On("GetByID", mock.Anything, "inactive-1").
+		Return(&domain.User{UserID: "inactive-1", TeamName: "frontend"}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "frontend").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "frontend").Return([]domain.User{}, nil)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, "frontend").
+		Return([]domain.SelectionExclusion{{UserID: "inactive-1", Reason: domain.ExclusionReasonInactive}}, nil)
+	mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-1", "inactive-1").Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+
+	var recorded *domain.SelectionExplanation
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			recorded = args.Get(1).(*domain.SelectionExplanation)
+		}).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.ReassignInactiveReviewers(context.Background(), &domain.ReassignInactiveInput{
+		PullRequestID: "pr-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.ReassignmentDetails, 1)
+	assert.True(t, result.ReassignmentDetails[0].WasRemoved)
+
+	require.NotNil(t, recorded)
+	assert.Equal(t, domain.SelectionTriggerInactive, recorded.Trigger)
+	assert.Equal(t, "inactive-1", recorded.ReplacedReviewerID)
+	assert.Empty(t, recorded.Selected)
+	require.Len(t, recorded.Teams, 1)
+	assert.Equal(t, "frontend", recorded.Teams[0].TeamName)
+	assert.Empty(t, recorded.Teams[0].Candidates)
+	assert.Equal(t, []domain.SelectionExclusion{
+		{UserID: "inactive-1", Reason: domain.ExclusionReasonAlreadyAssigned},
+		{UserID: "user-1", Reason: domain.ExclusionReasonAuthor},
+	}, recorded.Excluded)
+}
+
+func TestExplainPullRequest_Success(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	explanations := []domain.SelectionExplanation{
+		{ID: 1, PullRequestID: "pr-001", Trigger: domain.SelectionTriggerCreated, Selected: []string{"user-2"}},
+		{ID: 2, PullRequestID: "pr-001", Trigger: domain.SelectionTriggerManual, ReplacedReviewerID: "user-2", Selected: []string{"user-3"}},
+	}
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(&domain.PullRequest{ID: "pr-001"}, nil)
+	mockPRRepo.On("GetSelectionExplanations", mock.Anything, "pr-001").Return(explanations, nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.ExplainPullRequest(context.Background(), "pr-001")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, explanations, result)
+}
+
+func TestExplainPullRequest_NotFound(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockPRRepo.On("GetByID", mock.Anything, "missing").Return(nil, storage.ErrNotFound)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.ExplainPullRequest(context.Background(), "missing")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrResourceNotFound)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPullRequestHistory_Success(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	createdAt := time.Now()
+	expectedEvents := []domain.PullRequestEvent{
+		{ID: 1, PullRequestID: "pr-001", Type: domain.PullRequestEventCreated, ActorID: "admin", CreatedAt: createdAt},
+		{ID: 2, PullRequestID: "pr-001", Type: domain.PullRequestEventReviewerAssigned, ActorID: "admin", NewReviewerID: "user-2", CreatedAt: createdAt},
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(&domain.PullRequest{ID: "pr-001", Status: domain.PullRequestStatusOpen}, nil)
+			mockPRRepo.On("GetEvents", mock.Anything, "pr-001").Return(expectedEvents, nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	events, err := svc.GetPullRequestHistory(context.Background(), "pr-001")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expectedEvents, events)
+}
+
+func TestGetPullRequestHistory_NotFound(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-404").Return(nil, storage.ErrNotFound)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(storage.ErrNotFound)
+
+	// Act
+	events, err := svc.GetPullRequestHistory(context.Background(), "pr-404")
+
+	// Assert
+	assert.Nil(t, events)
+	assert.ErrorIs(t, err, domain.ErrResourceNotFound)
+	mockPRRepo.AssertNotCalled(t, "GetEvents", mock.Anything, mock.Anything)
+}
+
+func TestReassignPullRequest_RecordsReplacementEvent(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+			mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(&domain.PullRequest{
+					ID:                "pr-001",
+					AuthorID:          "user-1",
+					Status:            domain.PullRequestStatusOpen,
+					AssignedReviewers: []string{"user-2"},
+				}, nil)
+
+			mockUserRepo.On("GetByID", mock.Anything, "user-2").
+				Return(&domain.User{UserID: "user-2", TeamName: "backend", IsActive: true}, nil)
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").Return(nil, storage.ErrNotFound)
+			mockUserRepo.On("GetActiveByTeam", mock.Anything, "backend").
+				Return([]domain.User{
+					{UserID: "user-1", TeamName: "backend", IsActive: true},
+					{UserID: "user-2", TeamName: "backend", IsActive: true},
+					{UserID: "user-3", TeamName: "backend", IsActive: true},
+				}, nil)
+
+			mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-001", "user-2").Return(nil)
+			mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", "user-3").Return(nil)
+
+			// Замена записывается в историю в той же транзакции
+			mockPRRepo.On("AddEvent", mock.Anything, mock.MatchedBy(func(event *domain.PullRequestEvent) bool {
+				return event.PullRequestID == "pr-001" &&
+					event.Type == domain.PullRequestEventReviewerReplaced &&
+					event.OldReviewerID == "user-2" &&
+					event.NewReviewerID == "user-3" &&
+					event.Reason == domain.EventReasonManual &&
+					event.ActorID == "system" &&
+					!event.CreatedAt.IsZero()
+			})).Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.ReassignPullRequest(context.Background(), &domain.ReassignPullRequestInput{
+		PullRequestID: "pr-001",
+		OldUserID:     "user-2",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "user-3", result.ReplacedBy)
+}
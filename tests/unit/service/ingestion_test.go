@@ -0,0 +1,240 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func githubOpenedEvent() *domain.ExternalPullRequestEvent {
+	return &domain.ExternalPullRequestEvent{
+		Source:      domain.IngestionSourceGitHub,
+		DeliveryID:  "delivery-1",
+		Action:      domain.ExternalActionOpened,
+		Repository:  "octo-org/review-service",
+		Number:      42,
+		Title:       "Add reviewer load balancing",
+		AuthorLogin: "octocat",
+		Draft:       true,
+	}
+}
+
+func TestIngestPullRequestEvent_OpenedCreatesPullRequestForMappedAuthor(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockIngestionRepo := mocks.NewIngestionRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	svc := service.New(mockTxMgr,
+		service.WithExternalUsers(domain.IngestionSourceGitHub, map[string]string{"octocat": "user-1"}))
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("IngestionRepo").Return(mockIngestionRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "backend"}, nil)
+
+	var claimed *domain.IngestedDelivery
+	var staleBefore time.Time
+	mockIngestionRepo.On("ClaimDelivery", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			claimed = args.Get(1).(*domain.IngestedDelivery)
+			staleBefore = args.Get(2).(time.Time)
+		}).Return(true, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "octo-org/review-service#42").Return(nil, storage.ErrNotFound)
+
+	var created *domain.PullRequest
+	mockPRRepo.On("Create", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*domain.PullRequest)
+		}).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+
+	mockIngestionRepo.On("CompleteDelivery", mock.Anything, domain.IngestionSourceGitHub, "delivery-1").Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.IngestPullRequestEvent(context.Background(), githubOpenedEvent())
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, result.Duplicate)
+	assert.Equal(t, "octo-org/review-service#42", result.PullRequestID)
+	require.NotNil(t, result.PullRequest)
+	assert.Equal(t, domain.PullRequestStatusDraft, result.PullRequest.Status)
+
+	require.NotNil(t, created)
+	assert.Equal(t, "user-1", created.AuthorID)
+	assert.Equal(t, "Add reviewer load balancing", created.Name)
+	assert.Equal(t, "octo-org/review-service", created.Repository)
+
+	require.NotNil(t, claimed)
+	assert.Equal(t, "delivery-1", claimed.DeliveryID)
+	assert.Equal(t, domain.ExternalActionOpened, claimed.Action)
+	assert.Equal(t, "octo-org/review-service#42", claimed.PullRequestID)
+	// Перезанять можно только доставку, занятую больше срока аренды назад
+	assert.Equal(t, 5*time.Minute, claimed.ClaimedAt.Sub(staleBefore))
+}
+
+func TestIngestPullRequestEvent_DuplicateDeliveryIsSkipped(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockIngestionRepo := mocks.NewIngestionRepository(t)
+
+	svc := service.New(mockTxMgr,
+		service.WithExternalUsers(domain.IngestionSourceGitHub, map[string]string{"octocat": "user-1"}))
+
+	mockTx.On("IngestionRepo").Return(mockIngestionRepo)
+	mockIngestionRepo.On("ClaimDelivery", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx)).Once()
+
+	// Act
+	result, err := svc.IngestPullRequestEvent(context.Background(), githubOpenedEvent())
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.Duplicate)
+	assert.Nil(t, result.PullRequest)
+}
+
+func TestIngestPullRequestEvent_UnknownAuthorReleasesDelivery(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockIngestionRepo := mocks.NewIngestionRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("IngestionRepo").Return(mockIngestionRepo)
+	mockIngestionRepo.On("ClaimDelivery", mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Once()
+	mockIngestionRepo.On("ReleaseDelivery", mock.Anything, domain.IngestionSourceGitHub, "delivery-1").
+		Return(nil).Once()
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx)).Twice()
+
+	// Act
+	result, err := svc.IngestPullRequestEvent(context.Background(), githubOpenedEvent())
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUnknownExternalUser)
+	assert.Nil(t, result)
+}
+
+func TestIngestPullRequestEvent_CompleteFailureStillReportsApplied(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockIngestionRepo := mocks.NewIngestionRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("IngestionRepo").Return(mockIngestionRepo)
+
+	mockIngestionRepo.On("ClaimDelivery", mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Once()
+	mockPRRepo.On("GetByID", mock.Anything, "octo-org/review-service#42").
+		Return(&domain.PullRequest{
+			ID:       "octo-org/review-service#42",
+			AuthorID: "user-1",
+			Status:   domain.PullRequestStatusOpen,
+		}, nil)
+	mockPRRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockIngestionRepo.On("CompleteDelivery", mock.Anything, domain.IngestionSourceGitHub, "delivery-1").
+		Return(assert.AnError).Once()
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.IngestPullRequestEvent(context.Background(), &domain.ExternalPullRequestEvent{
+		Source:     domain.IngestionSourceGitHub,
+		DeliveryID: "delivery-1",
+		Action:     domain.ExternalActionClosed,
+		Repository: "octo-org/review-service",
+		Number:     42,
+	})
+
+	// Assert: действие применено, занятая доставка не снимается и повтор останется дубликатом
+	require.NoError(t, err)
+	assert.False(t, result.Duplicate)
+	assert.Equal(t, domain.PullRequestStatusClosed, result.PullRequest.Status)
+	mockIngestionRepo.AssertNotCalled(t, "ReleaseDelivery", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIngestPullRequestEvent_InvalidInput(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	svc := service.New(mockTxMgr)
+
+	event := githubOpenedEvent()
+	event.DeliveryID = ""
+
+	// Act
+	result, err := svc.IngestPullRequestEvent(context.Background(), event)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, result)
+}
+
+func TestIngestPullRequestEvent_GitLabReturnsReviewerLogins(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockIngestionRepo := mocks.NewIngestionRepository(t)
+
+	svc := service.New(mockTxMgr,
+		service.WithExternalUsers(domain.IngestionSourceGitLab, map[string]string{"alice": "user-2"}))
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("IngestionRepo").Return(mockIngestionRepo)
+
+	mockIngestionRepo.On("ClaimDelivery", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "platform/backend/review-service!17").
+		Return(&domain.PullRequest{
+			ID:                "platform/backend/review-service!17",
+			AuthorID:          "user-1",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"user-2", "user-3"},
+		}, nil)
+	mockPRRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockIngestionRepo.On("CompleteDelivery", mock.Anything, domain.IngestionSourceGitLab, "delivery-1").Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.IngestPullRequestEvent(context.Background(), &domain.ExternalPullRequestEvent{
+		Source:     domain.IngestionSourceGitLab,
+		DeliveryID: "delivery-1",
+		Action:     domain.ExternalActionClosed,
+		Repository: "platform/backend/review-service",
+		Number:     17,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "platform/backend/review-service!17", result.PullRequestID)
+	assert.Equal(t, []string{"alice"}, result.ReviewerLogins)
+	assert.Equal(t, []string{"user-3"}, result.UnmappedReviewers)
+}
@@ -0,0 +1,291 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePullRequest_Draft(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(nil, storage.ErrNotFound)
+
+			// Черновик создаётся без выбора ревьюверов, но от имени основной команды автора
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+			mockPRRepo.On("Create", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+				return pr.Status == domain.PullRequestStatusDraft && len(pr.AssignedReviewers) == 0 &&
+					pr.TeamName == "backend"
+			})).Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "WIP",
+		AuthorID:        "user-1",
+		Draft:           true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.PullRequestStatusDraft, result.Status)
+	assert.Equal(t, "backend", result.TeamName)
+	assert.Empty(t, result.AssignedReviewers)
+	mockPRRepo.AssertNotCalled(t, "AssignReviewer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMarkPullRequestReady_AssignsReviewers(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+			mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(&domain.PullRequest{
+					ID:       "pr-001",
+					AuthorID: "user-1",
+					Status:   domain.PullRequestStatusDraft,
+				}, nil)
+
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(nil, storage.ErrNotFound)
+			mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
+				Return([]domain.User{
+					{UserID: "user-2", TeamName: "backend", IsActive: true},
+					{UserID: "user-3", TeamName: "backend", IsActive: true},
+				}, nil)
+
+			mockPRRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+				return pr.Status == domain.PullRequestStatusOpen
+			})).Return(nil)
+			mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", "user-2").Return(nil)
+			mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", "user-3").Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.MarkPullRequestReady(context.Background(), &domain.MarkReadyInput{PullRequestID: "pr-001"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.PullRequestStatusOpen, result.Status)
+	assert.ElementsMatch(t, []string{"user-2", "user-3"}, result.AssignedReviewers)
+}
+
+func TestMarkPullRequestReady_NotDraft(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(&domain.PullRequest{ID: "pr-001", AuthorID: "user-1", Status: domain.PullRequestStatusOpen}, nil)
+
+			err := fn(context.Background(), mockTx)
+			assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+		}).Return(domain.ErrInvalidTransition)
+
+	// Act
+	result, err := svc.MarkPullRequestReady(context.Background(), &domain.MarkReadyInput{PullRequestID: "pr-001"})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+}
+
+func TestClosePullRequest_Open(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(&domain.PullRequest{
+					ID:                "pr-001",
+					AuthorID:          "user-1",
+					Status:            domain.PullRequestStatusOpen,
+					AssignedReviewers: []string{"user-2"},
+				}, nil)
+			mockPRRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+				return pr.Status == domain.PullRequestStatusClosed && pr.ClosedAt != nil
+			})).Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.ClosePullRequest(context.Background(), &domain.ClosePullRequestInput{PullRequestID: "pr-001"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.PullRequestStatusClosed, result.Status)
+	assert.NotNil(t, result.ClosedAt)
+	assert.Equal(t, []string{"user-2"}, result.AssignedReviewers)
+}
+
+func TestClosePullRequest_Merged(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(&domain.PullRequest{ID: "pr-001", AuthorID: "user-1", Status: domain.PullRequestStatusMerged}, nil)
+
+			err := fn(context.Background(), mockTx)
+			assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+		}).Return(domain.ErrInvalidTransition)
+
+	// Act
+	result, err := svc.ClosePullRequest(context.Background(), &domain.ClosePullRequestInput{PullRequestID: "pr-001"})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestReopenPullRequest_KeepsReviewers(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(&domain.PullRequest{
+					ID:                "pr-001",
+					AuthorID:          "user-1",
+					Status:            domain.PullRequestStatusClosed,
+					AssignedReviewers: []string{"user-2"},
+				}, nil)
+			mockPRRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+				return pr.Status == domain.PullRequestStatusOpen && pr.ClosedAt == nil
+			})).Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.ReopenPullRequest(context.Background(), &domain.ReopenPullRequestInput{PullRequestID: "pr-001"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.PullRequestStatusOpen, result.Status)
+	assert.Equal(t, []string{"user-2"}, result.AssignedReviewers)
+	mockPRRepo.AssertNotCalled(t, "AssignReviewer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMergePullRequest_Draft(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(&domain.PullRequest{ID: "pr-001", AuthorID: "user-1", Status: domain.PullRequestStatusDraft}, nil)
+
+			err := fn(context.Background(), mockTx)
+			assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+		}).Return(domain.ErrInvalidTransition)
+
+	// Act
+	result, err := svc.MergePullRequest(context.Background(), &domain.MergePullRequestInput{PullRequestID: "pr-001", Force: true})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+}
@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPullRequests_CursorPagination(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	base := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	prAt := func(id string, offset time.Duration) domain.PullRequest {
+		createdAt := base.Add(offset)
+		return domain.PullRequest{ID: id, Status: domain.PullRequestStatusOpen, CreatedAt: &createdAt}
+	}
+
+	filter := domain.PullRequestFilter{
+		Statuses:   []domain.PullRequestStatus{domain.PullRequestStatusOpen},
+		SortBy:     domain.PullRequestSortCreatedAt,
+		Descending: true,
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+
+	// Первая страница: хранилище возвращает на один PR больше лимита
+	mockPRRepo.On("List", mock.Anything, filter, (*domain.PageCursor)(nil), 3).
+		Return([]domain.PullRequest{prAt("pr-3", 3*time.Hour), prAt("pr-2", 2*time.Hour), prAt("pr-1", time.Hour)}, nil).Once()
+
+	// Вторая страница начинается после последнего PR первой
+	mockPRRepo.On("List", mock.Anything, filter, mock.MatchedBy(func(after *domain.PageCursor) bool {
+		return after != nil && after.ID == "pr-2" && after.SortValue.Equal(base.Add(2*time.Hour))
+	}), 3).
+		Return([]domain.PullRequest{prAt("pr-1", time.Hour)}, nil).Once()
+
+	// Act
+	first, err := svc.ListPullRequests(context.Background(), &domain.ListPullRequestsInput{
+		PullRequestFilter: domain.PullRequestFilter{
+			Statuses:   []domain.PullRequestStatus{domain.PullRequestStatusOpen},
+			Descending: true,
+		},
+		Limit: 2,
+	})
+	require.NoError(t, err)
+
+	second, err := svc.ListPullRequests(context.Background(), &domain.ListPullRequestsInput{
+		PullRequestFilter: filter,
+		Limit:             2,
+		Cursor:            first.NextCursor,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, first.PullRequests, 2)
+	assert.NotEmpty(t, first.NextCursor)
+	require.Len(t, second.PullRequests, 1)
+	assert.Equal(t, "pr-1", second.PullRequests[0].ID)
+	assert.Empty(t, second.NextCursor)
+}
+
+func TestListPullRequests_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input domain.ListPullRequestsInput
+	}{
+		{"negative limit", domain.ListPullRequestsInput{Limit: -1}},
+		{"limit above max", domain.ListPullRequestsInput{Limit: domain.MaxPageLimit + 1}},
+		{"unknown status", domain.ListPullRequestsInput{PullRequestFilter: domain.PullRequestFilter{
+			Statuses: []domain.PullRequestStatus{"DELETED"},
+		}}},
+		{"unknown sort field", domain.ListPullRequestsInput{PullRequestFilter: domain.PullRequestFilter{SortBy: "name"}}},
+		{"malformed cursor", domain.ListPullRequestsInput{Cursor: "not a cursor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockTxMgr := mocks.NewTxManager(t)
+			svc := service.New(mockTxMgr)
+
+			// Act
+			page, err := svc.ListPullRequests(context.Background(), &tt.input)
+
+			// Assert
+			assert.Nil(t, page)
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			mockTxMgr.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+		})
+	}
+}
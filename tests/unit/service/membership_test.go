@@ -0,0 +1,514 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePullRequest_TeamContextSelectsFromThatTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(nil, storage.ErrNotFound)
+
+	// Основная команда автора - backend, PR создаётся от имени platform
+	mockTeamRepo.On("GetMembership", mock.Anything, "platform", "user-1").
+		Return(&domain.TeamMembership{TeamName: "platform", UserID: "user-1", Role: domain.TeamRoleMember, IsActive: true}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "platform").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "platform", "user-1").
+		Return([]domain.User{
+			{UserID: "user-5", TeamName: "platform", IsActive: true},
+			{UserID: "user-6", TeamName: "platform", IsActive: true},
+		}, nil)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, "platform").Return([]domain.SelectionExclusion{}, nil)
+
+	mockPRRepo.On("Create", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+		return pr.TeamName == "platform"
+	})).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", mock.AnythingOfType("string")).Return(nil)
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	pr, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		TeamName:        "platform",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "platform", pr.TeamName)
+	assert.ElementsMatch(t, []string{"user-5", "user-6"}, pr.AssignedReviewers)
+	assert.Equal(t, map[string]string{"user-5": "platform", "user-6": "platform"}, pr.ReviewerTeams)
+}
+
+func TestCreatePullRequest_DefaultsToAuthorPrimaryTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx, _, _ := codeOwnersTestMocks(t, testCandidates("user-2", "user-3"))
+
+	svc := service.New(mockTxMgr)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	pr, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "backend", pr.TeamName)
+}
+
+func TestCreatePullRequest_AuthorNotInTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(nil, storage.ErrNotFound)
+	mockTeamRepo.On("GetMembership", mock.Anything, "platform", "user-1").Return(nil, storage.ErrNotFound)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	pr, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		TeamName:        "platform",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrNotTeamMember)
+	assert.Nil(t, pr)
+	mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestReassignPullRequest_ReplacesFromPullRequestTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+		Return(&domain.PullRequest{
+			ID:                "pr-001",
+			AuthorID:          "user-1",
+			TeamName:          "platform",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"user-2"},
+		}, nil)
+
+	// Основная команда ревьювера - backend, но он состоит и в platform, от имени которой создан PR
+	mockUserRepo.On("GetByID", mock.Anything, "user-2").
+		Return(&domain.User{UserID: "user-2", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetMembership", mock.Anything, "platform", "user-2").
+		Return(&domain.TeamMembership{TeamName: "platform", UserID: "user-2", Role: domain.TeamRoleMember, IsActive: true}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "platform").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "platform").
+		Return([]domain.User{
+			{UserID: "user-1", TeamName: "platform", IsActive: true},
+			{UserID: "user-2", TeamName: "platform", IsActive: true},
+			{UserID: "user-7", TeamName: "platform", IsActive: true},
+		}, nil)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, "platform").Return([]domain.SelectionExclusion{}, nil)
+
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-001", "user-2").Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", "user-7").Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.ReassignPullRequest(context.Background(), &domain.ReassignPullRequestInput{
+		PullRequestID: "pr-001",
+		OldUserID:     "user-2",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "user-7", result.ReplacedBy)
+	mockUserRepo.AssertNotCalled(t, "GetActiveByTeam", mock.Anything, "backend")
+}
+
+func TestCreateTeam_InvalidRole(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	svc := service.New(mockTxMgr)
+
+	// Act
+	team, err := svc.CreateTeam(context.Background(), &domain.Team{
+		Name: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", Role: "OWNER", IsActive: true},
+		},
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, team)
+}
+
+func TestAddTeamMember_NewUserRequiresUsername(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+	mockTeamRepo.On("GetByName", mock.Anything, "platform").Return(&domain.Team{Name: "platform"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "user-9").Return(nil, storage.ErrNotFound)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.AddTeamMember(context.Background(), &domain.AddTeamMemberInput{
+		TeamName: "platform",
+		UserID:   "user-9",
+		IsActive: true,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, result)
+	mockUserRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+}
+
+func TestAddTeamMember_InactiveMembershipReassignsTeamReviews(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockTeamRepo.On("GetByName", mock.Anything, "platform").Return(&domain.Team{Name: "platform"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "user-2").
+		Return(&domain.User{UserID: "user-2", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("UpsertMembership", mock.Anything, mock.MatchedBy(func(membership *domain.TeamMembership) bool {
+		return membership.TeamName == "platform" && membership.Role == domain.TeamRoleMember && !membership.IsActive
+	})).Return(nil)
+
+	// Открытые ревью участника на PR других команд не затрагиваются
+	mockPRRepo.On("GetOpenPRIDsByTeamReviewer", mock.Anything, "platform", "user-2").Return([]string{"pr-001"}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+		Return(&domain.PullRequest{
+			ID:                "pr-001",
+			AuthorID:          "user-1",
+			TeamName:          "platform",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"user-2"},
+		}, nil)
+	mockTeamRepo.On("GetMembership", mock.Anything, "platform", "user-2").
+		Return(&domain.TeamMembership{TeamName: "platform", UserID: "user-2", Role: domain.TeamRoleMember}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "platform").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "platform").
+		Return([]domain.User{
+			{UserID: "user-1", TeamName: "platform", IsActive: true},
+			{UserID: "user-7", TeamName: "platform", IsActive: true},
+		}, nil)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, "platform").Return([]domain.SelectionExclusion{}, nil)
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-001", "user-2").Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", "user-7").Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.AddTeamMember(context.Background(), &domain.AddTeamMemberInput{
+		TeamName:            "platform",
+		UserID:              "user-2",
+		IsActive:            false,
+		ReassignOpenReviews: true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "backend", result.PrimaryTeam)
+	require.Len(t, result.Reassignments, 1)
+	assert.Equal(t, "pr-001", result.Reassignments[0].PullRequestID)
+	assert.Equal(t, "user-7", result.Reassignments[0].ReassignmentDetails[0].NewReviewerID)
+}
+
+func TestRemoveTeamMember_SwitchesPrimaryTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetMemberships", mock.Anything, "user-1").
+		Return([]domain.TeamMembership{
+			{TeamName: "backend", UserID: "user-1", Role: domain.TeamRoleMember, IsActive: true},
+			{TeamName: "platform", UserID: "user-1", Role: domain.TeamRoleMember, IsActive: true},
+			{TeamName: "design", UserID: "user-1", Role: domain.TeamRoleMember, IsActive: true},
+		}, nil)
+	mockTeamRepo.On("DeleteMembership", mock.Anything, "backend", "user-1").Return(nil)
+	mockUserRepo.On("SetPrimaryTeam", mock.Anything, "user-1", "platform").Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.RemoveTeamMember(context.Background(), &domain.RemoveTeamMemberInput{
+		TeamName: "backend",
+		UserID:   "user-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, result.Membership)
+	assert.Equal(t, "platform", result.PrimaryTeam)
+	assert.Empty(t, result.Reassignments)
+}
+
+func TestRemoveTeamMember_LastTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetMemberships", mock.Anything, "user-1").
+		Return([]domain.TeamMembership{{TeamName: "backend", UserID: "user-1", Role: domain.TeamRoleMember, IsActive: true}}, nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.RemoveTeamMember(context.Background(), &domain.RemoveTeamMemberInput{
+		TeamName: "backend",
+		UserID:   "user-1",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrLastTeam)
+	assert.Nil(t, result)
+	mockTeamRepo.AssertNotCalled(t, "DeleteMembership", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMoveTeamMember_KeepsRoleAndMovesPrimaryTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockTeamRepo.On("GetByName", mock.Anything, "platform").Return(&domain.Team{Name: "platform"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetMemberships", mock.Anything, "user-1").
+		Return([]domain.TeamMembership{{TeamName: "backend", UserID: "user-1", Role: domain.TeamRoleMaintainer, IsActive: false}}, nil)
+	mockTeamRepo.On("DeleteMembership", mock.Anything, "backend", "user-1").Return(nil)
+	mockTeamRepo.On("UpsertMembership", mock.Anything, mock.MatchedBy(func(membership *domain.TeamMembership) bool {
+		return membership.TeamName == "platform" && membership.Role == domain.TeamRoleMaintainer && !membership.IsActive
+	})).Return(nil)
+	mockUserRepo.On("SetPrimaryTeam", mock.Anything, "user-1", "platform").Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.MoveTeamMember(context.Background(), &domain.MoveTeamMemberInput{
+		FromTeam: "backend",
+		ToTeam:   "platform",
+		UserID:   "user-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "platform", result.TeamName)
+	assert.Equal(t, "platform", result.PrimaryTeam)
+	require.NotNil(t, result.Membership)
+	assert.Equal(t, domain.TeamRoleMaintainer, result.Membership.Role)
+}
+
+func TestMoveTeamMember_AlreadyMember(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockTeamRepo.On("GetByName", mock.Anything, "platform").Return(&domain.Team{Name: "platform"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetMemberships", mock.Anything, "user-1").
+		Return([]domain.TeamMembership{
+			{TeamName: "backend", UserID: "user-1", Role: domain.TeamRoleMember, IsActive: true},
+			{TeamName: "platform", UserID: "user-1", Role: domain.TeamRoleMember, IsActive: true},
+		}, nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.MoveTeamMember(context.Background(), &domain.MoveTeamMemberInput{
+		FromTeam: "backend",
+		ToTeam:   "platform",
+		UserID:   "user-1",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrAlreadyTeamMember)
+	assert.Nil(t, result)
+	mockTeamRepo.AssertNotCalled(t, "DeleteMembership", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteTeam_RefusesWithOpenPRs(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(&domain.Team{Name: "backend"}, nil)
+	mockPRRepo.On("CountOpenByTeam", mock.Anything, "backend").Return(2, nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	err := svc.DeleteTeam(context.Background(), "backend")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrTeamHasOpenPRs)
+	mockTeamRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDeleteTeam_MovesPrimaryTeamOfMembers(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockTeamRepo.On("GetByName", mock.Anything, "platform").
+		Return(&domain.Team{
+			Name: "platform",
+			Members: []domain.TeamMember{
+				{UserID: "user-1", Role: domain.TeamRoleMember, IsActive: true},
+				{UserID: "user-2", Role: domain.TeamRoleMember, IsActive: true},
+			},
+		}, nil)
+	mockPRRepo.On("CountOpenByTeam", mock.Anything, "platform").Return(0, nil)
+
+	// Для user-1 команда основная, user-2 состоит в ней дополнительно
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "platform", IsActive: true}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "user-2").
+		Return(&domain.User{UserID: "user-2", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetMemberships", mock.Anything, "user-1").
+		Return([]domain.TeamMembership{
+			{TeamName: "platform", UserID: "user-1", Role: domain.TeamRoleMember, IsActive: true},
+			{TeamName: "design", UserID: "user-1", Role: domain.TeamRoleMember, IsActive: true},
+		}, nil)
+	mockUserRepo.On("SetPrimaryTeam", mock.Anything, "user-1", "design").Return(nil)
+	mockTeamRepo.On("Delete", mock.Anything, "platform").Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	err := svc.DeleteTeam(context.Background(), "platform")
+
+	// Assert
+	require.NoError(t, err)
+	mockUserRepo.AssertNotCalled(t, "SetPrimaryTeam", mock.Anything, "user-2", mock.Anything)
+}
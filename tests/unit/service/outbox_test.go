@@ -0,0 +1,259 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// outboxTestMocks настраивает одно событие, которое будет выдано ClaimDue.
+// Событие после попытки публикации сохраняется в updated.
+func outboxTestMocks(t *testing.T, event *domain.OutboxEvent, updated **domain.OutboxEvent) *mocks.TxManager {
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockOutboxRepo := mocks.NewOutboxRepository(t)
+
+	mockTx.On("OutboxRepo").Return(mockOutboxRepo)
+	mockOutboxRepo.On("ClaimDue", mock.Anything, mock.AnythingOfType("time.Time")).Return(event, nil).Once()
+	mockOutboxRepo.On("ClaimDue", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil, storage.ErrNotFound).Once()
+	mockOutboxRepo.On("Update", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*updated = args.Get(1).(*domain.OutboxEvent)
+		}).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	return mockTxMgr
+}
+
+func TestCreatePullRequest_WritesOutboxEventInTransaction(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockOutboxRepo := mocks.NewOutboxRepository(t)
+
+	svc := service.New(mockTxMgr, service.WithOutbox(time.Second, service.NewMemorySink()))
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+	mockTx.On("OutboxRepo").Return(mockOutboxRepo)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "backend").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
+		Return(testCandidates("user-2"), nil)
+	mockPRRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", "user-2").Return(nil)
+
+	var recorded *domain.OutboxEvent
+	mockOutboxRepo.On("Add", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			recorded = args.Get(1).(*domain.OutboxEvent)
+		}).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx)).Once()
+
+	// Act
+	_, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	assert.Equal(t, domain.WebhookEventPullRequestCreated, recorded.EventType)
+	assert.NotEmpty(t, recorded.EventID)
+	assert.Nil(t, recorded.PublishedAt)
+
+	var payload struct {
+		EventID   string `json:"event_id"`
+		EventType string `json:"event_type"`
+		Data      struct {
+			PullRequestID     string   `json:"pull_request_id"`
+			AssignedReviewers []string `json:"assigned_reviewers"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(recorded.Payload, &payload))
+	assert.Equal(t, recorded.EventID, payload.EventID)
+	assert.Equal(t, "pull_request.created", payload.EventType)
+	assert.Equal(t, "pr-001", payload.Data.PullRequestID)
+	assert.Equal(t, []string{"user-2"}, payload.Data.AssignedReviewers)
+}
+
+func TestCreatePullRequest_OutboxFailureRollsBackOperation(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockOutboxRepo := mocks.NewOutboxRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	svc := service.New(mockTxMgr, service.WithOutbox(time.Second, service.NewMemorySink()))
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("OutboxRepo").Return(mockOutboxRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "backend"}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(nil, storage.ErrNotFound)
+	mockPRRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockOutboxRepo.On("Add", mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		Draft:           true,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInternal)
+	assert.Nil(t, result)
+}
+
+func TestPublishOutbox_PublishesToAllSinks(t *testing.T) {
+	// Arrange
+	event := &domain.OutboxEvent{
+		ID:        3,
+		EventID:   "evt-1",
+		EventType: domain.WebhookEventPullRequestMerged,
+		Payload:   []byte(`{"event_id":"evt-1"}`),
+	}
+
+	var updated *domain.OutboxEvent
+	mockTxMgr := outboxTestMocks(t, event, &updated)
+
+	claimed := *event
+	first, second := service.NewMemorySink(), service.NewMemorySink()
+	svc := service.New(mockTxMgr, service.WithOutbox(time.Second, first, second))
+
+	// Act
+	published, err := svc.PublishOutbox(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []domain.OutboxEvent{claimed}, first.Events())
+	assert.Equal(t, []domain.OutboxEvent{claimed}, second.Events())
+
+	require.NotNil(t, updated)
+	assert.NotNil(t, updated.PublishedAt)
+	assert.Zero(t, updated.Attempts)
+}
+
+func TestPublishOutbox_RetriesWhenSinkFails(t *testing.T) {
+	// Arrange
+	event := &domain.OutboxEvent{
+		ID:        3,
+		EventID:   "evt-1",
+		EventType: domain.WebhookEventUserDeactivated,
+		Payload:   []byte(`{}`),
+		Attempts:  1,
+	}
+
+	var updated *domain.OutboxEvent
+	mockTxMgr := outboxTestMocks(t, event, &updated)
+
+	healthy, failing := service.NewMemorySink(), service.NewMemorySink()
+	failing.FailWith(errors.New("sink unavailable"))
+	svc := service.New(mockTxMgr, service.WithOutbox(time.Minute, healthy, failing))
+
+	// Act
+	before := time.Now()
+	published, err := svc.PublishOutbox(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	// Исправный приёмник получит событие повторно при следующей попытке
+	assert.Len(t, healthy.Events(), 1)
+	assert.Empty(t, failing.Events())
+
+	require.NotNil(t, updated)
+	assert.Nil(t, updated.PublishedAt)
+	assert.Equal(t, 2, updated.Attempts)
+	assert.Contains(t, updated.LastError, "memory: sink unavailable")
+	assert.WithinDuration(t, before.Add(2*time.Minute), updated.NextAttemptAt, 5*time.Second)
+}
+
+func TestPublishOutbox_DisabledWithoutOutbox(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	svc := service.New(mockTxMgr)
+
+	// Act
+	published, err := svc.PublishOutbox(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Zero(t, published)
+}
+
+func TestWebhookSink_QueuesDeliveryPerSubscription(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockWebhookRepo := mocks.NewWebhookRepository(t)
+
+	mockTx.On("WebhookRepo").Return(mockWebhookRepo)
+	mockWebhookRepo.On("GetSubscriptionsForEvent", mock.Anything, domain.WebhookEventTeamDeactivated).
+		Return([]domain.WebhookSubscription{{ID: 7}, {ID: 9}}, nil)
+
+	var queued []*domain.WebhookDelivery
+	mockWebhookRepo.On("CreateDelivery", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			queued = append(queued, args.Get(1).(*domain.WebhookDelivery))
+		}).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	sink := service.NewWebhookSink(mockTxMgr)
+
+	// Act
+	err := sink.Publish(context.Background(), domain.OutboxEvent{
+		EventID:   "evt-1",
+		EventType: domain.WebhookEventTeamDeactivated,
+		Payload:   []byte(`{"event_id":"evt-1"}`),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, queued, 2)
+	for i, subscriptionID := range []int64{7, 9} {
+		assert.Equal(t, subscriptionID, queued[i].SubscriptionID)
+		assert.Equal(t, "evt-1", queued[i].EventID)
+		assert.Equal(t, domain.WebhookDeliveryPending, queued[i].Status)
+		assert.Equal(t, []byte(`{"event_id":"evt-1"}`), queued[i].Payload)
+	}
+}
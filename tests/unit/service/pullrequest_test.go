@@ -20,6 +20,7 @@ func TestCreatePullRequest_Success(t *testing.T) {
 	mockTx := mocks.NewTx(t)
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	svc := service.New(mockTxMgr)
 
@@ -40,14 +41,24 @@ func TestCreatePullRequest_Success(t *testing.T) {
 			fn := args.Get(1).(func(context.Context, storage.Tx) error)
 
 			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
 			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+			mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
 
 			// PR не существует
 			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
 				Return(nil, storage.ErrNotFound)
 
+			// Автор и настройки его команды (не заданы - используются значения по умолчанию)
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", Username: "Author", TeamName: "backend", IsActive: true}, nil)
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(nil, storage.ErrNotFound)
+
 			// Возвращаем активных пользователей команды
-			mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "user-1").
+			mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
 				Return(activeUsers, nil)
 
 			// Ожидаем создание PR
@@ -87,6 +98,7 @@ func TestCreatePullRequest_NoActiveMembers(t *testing.T) {
 	mockTx := mocks.NewTx(t)
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	svc := service.New(mockTxMgr)
 
@@ -102,13 +114,23 @@ func TestCreatePullRequest_NoActiveMembers(t *testing.T) {
 			fn := args.Get(1).(func(context.Context, storage.Tx) error)
 
 			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
 			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+			mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
 
 			mockPRRepo.On("GetByID", mock.Anything, "pr-002").
 				Return(nil, storage.ErrNotFound)
 
+			// Автор и настройки его команды (не заданы - используются значения по умолчанию)
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", Username: "Author", TeamName: "backend", IsActive: true}, nil)
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(nil, storage.ErrNotFound)
+
 			// Нет активных членов команды (кроме автора)
-			mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "user-1").
+			mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
 				Return([]domain.User{}, nil)
 
 			// Ожидаем создание PR без ревьюверов
@@ -175,6 +197,8 @@ func TestMergePullRequest_Success(t *testing.T) {
 	mockTxMgr := mocks.NewTxManager(t)
 	mockTx := mocks.NewTx(t)
 	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	svc := service.New(mockTxMgr)
 
@@ -194,10 +218,19 @@ func TestMergePullRequest_Success(t *testing.T) {
 			fn := args.Get(1).(func(context.Context, storage.Tx) error)
 
 			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
 
 			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
 				Return(existingPR, nil)
 
+			// Команда не требует одобрений
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(nil, storage.ErrNotFound)
+
 			mockPRRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
 				return pr.ID == "pr-001" &&
 					pr.Status == domain.PullRequestStatusMerged &&
@@ -259,6 +292,194 @@ func TestMergePullRequest_Idempotent(t *testing.T) {
 	assert.Equal(t, domain.PullRequestStatusMerged, result.Status)
 }
 
+func TestMergePullRequest_NotApproved(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Одно одобрение из двух требуемых
+	existingPR := &domain.PullRequest{
+		ID:                "pr-001",
+		AuthorID:          "user-1",
+		Status:            domain.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user-2", "user-3"},
+		ReviewerStates: []domain.ReviewerState{
+			{ReviewerID: "user-2", State: domain.ReviewStateApproved},
+			{ReviewerID: "user-3", State: domain.ReviewStatePending},
+		},
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(existingPR, nil)
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(&domain.TeamSettings{TeamName: "backend", ReviewerCount: 2, RequiredApprovals: 2}, nil)
+
+			err := fn(context.Background(), mockTx)
+			assert.ErrorIs(t, err, domain.ErrNotApproved)
+		}).Return(domain.ErrNotApproved)
+
+	// Act
+	result, err := svc.MergePullRequest(context.Background(), &domain.MergePullRequestInput{PullRequestID: "pr-001"})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrNotApproved)
+	mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestMergePullRequest_ChangesRequested(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Запрошенные изменения блокируют merge независимо от настроек команды
+	existingPR := &domain.PullRequest{
+		ID:                "pr-001",
+		AuthorID:          "user-1",
+		Status:            domain.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user-2", "user-3"},
+		ReviewerStates: []domain.ReviewerState{
+			{ReviewerID: "user-2", State: domain.ReviewStateApproved},
+			{ReviewerID: "user-3", State: domain.ReviewStateChangesRequested},
+		},
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(existingPR, nil)
+
+			err := fn(context.Background(), mockTx)
+			assert.ErrorIs(t, err, domain.ErrNotApproved)
+		}).Return(domain.ErrNotApproved)
+
+	// Act
+	result, err := svc.MergePullRequest(context.Background(), &domain.MergePullRequestInput{PullRequestID: "pr-001"})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrNotApproved)
+}
+
+func TestMergePullRequest_Force(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	existingPR := &domain.PullRequest{
+		ID:                "pr-001",
+		AuthorID:          "user-1",
+		Status:            domain.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user-2"},
+		ReviewerStates: []domain.ReviewerState{
+			{ReviewerID: "user-2", State: domain.ReviewStateChangesRequested},
+		},
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(existingPR, nil)
+			mockPRRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+				return pr.Status == domain.PullRequestStatusMerged && pr.MergeForced
+			})).Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.MergePullRequest(context.Background(), &domain.MergePullRequestInput{
+		PullRequestID: "pr-001",
+		Force:         true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.PullRequestStatusMerged, result.Status)
+	assert.True(t, result.MergeForced)
+}
+
+func TestMergePullRequest_ForceOnApprovedIsNotForced(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+		Return(&domain.PullRequest{ID: "pr-001", AuthorID: "user-1", Status: domain.PullRequestStatusOpen}, nil)
+
+	// Команда не требует одобрений - проверка проходит и без force
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "backend").Return(nil, storage.ErrNotFound)
+
+	mockPRRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+		return pr.Status == domain.PullRequestStatusMerged && !pr.MergeForced
+	})).Return(nil)
+
+	var recorded *domain.PullRequestEvent
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			recorded = args.Get(1).(*domain.PullRequestEvent)
+		}).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	result, err := svc.MergePullRequest(context.Background(), &domain.MergePullRequestInput{
+		PullRequestID: "pr-001",
+		Force:         true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.PullRequestStatusMerged, result.Status)
+	assert.False(t, result.MergeForced)
+
+	require.NotNil(t, recorded)
+	assert.Equal(t, domain.PullRequestEventMerged, recorded.Type)
+	assert.Empty(t, recorded.Reason)
+}
+
 func TestReassignPullRequest_AfterMerge_ShouldFail(t *testing.T) {
 	// Arrange
 	mockTxMgr := mocks.NewTxManager(t)
@@ -300,3 +521,370 @@ func TestReassignPullRequest_AfterMerge_ShouldFail(t *testing.T) {
 	assert.Nil(t, result)
 	assert.ErrorIs(t, err, domain.ErrReassignOnMerged)
 }
+
+func TestCreatePullRequest_NotEnoughReviewers(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	input := &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-003",
+		PullRequestName: "Needs two reviewers",
+		AuthorID:        "user-1",
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+			mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-003").
+				Return(nil, storage.ErrNotFound)
+
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", Username: "Author", TeamName: "backend", IsActive: true}, nil)
+
+			// Команда требует минимум двух ревьюверов
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(&domain.TeamSettings{TeamName: "backend", ReviewerCount: 2, MinReviewers: 2}, nil)
+
+			// Доступен только один кандидат
+			mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
+				Return([]domain.User{
+					{UserID: "user-2", Username: "Bob", TeamName: "backend", IsActive: true},
+				}, nil)
+
+			// PR не должен быть создан
+			err := fn(context.Background(), mockTx)
+			assert.ErrorIs(t, err, domain.ErrNotEnoughReviewers)
+		}).Return(domain.ErrNotEnoughReviewers)
+
+	// Act
+	result, err := svc.CreatePullRequest(context.Background(), input)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrNotEnoughReviewers)
+	mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreatePullRequest_FallbackTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	input := &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-004",
+		PullRequestName: "Design tweaks",
+		AuthorID:        "user-1",
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+			mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-004").
+				Return(nil, storage.ErrNotFound)
+
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", Username: "Author", TeamName: "design", IsActive: true}, nil)
+
+			// Команда дизайна в отпуске, резервные команды: сначала frontend, затем backend
+			mockTeamRepo.On("GetSettings", mock.Anything, "design").
+				Return(&domain.TeamSettings{
+					TeamName:      "design",
+					ReviewerCount: 2,
+					FallbackTeams: []string{"frontend", "backend"},
+				}, nil)
+			mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "design", "user-1").
+				Return([]domain.User{}, nil)
+
+			// В frontend только один активный участник, второго добираем из backend
+			mockUserRepo.On("GetActiveByTeam", mock.Anything, "frontend").
+				Return([]domain.User{
+					{UserID: "user-2", Username: "Bob", TeamName: "frontend", IsActive: true},
+				}, nil)
+			mockTeamRepo.On("GetSettings", mock.Anything, "frontend").
+				Return(nil, storage.ErrNotFound)
+			mockUserRepo.On("GetActiveByTeam", mock.Anything, "backend").
+				Return([]domain.User{
+					{UserID: "user-3", Username: "Charlie", TeamName: "backend", IsActive: true},
+				}, nil)
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(nil, storage.ErrNotFound)
+
+			mockPRRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			mockPRRepo.On("AssignReviewer", mock.Anything, "pr-004", mock.AnythingOfType("string")).
+				Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.CreatePullRequest(context.Background(), input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-2", "user-3"}, result.AssignedReviewers)
+	assert.Equal(t, map[string]string{"user-2": "frontend", "user-3": "backend"}, result.ReviewerTeams)
+}
+
+func TestCreatePullRequest_SkipsReviewersAtCapacity(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	input := &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-005",
+		PullRequestName: "Busy team",
+		AuthorID:        "user-1",
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+			mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-005").
+				Return(nil, storage.ErrNotFound)
+
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", Username: "Author", TeamName: "backend", IsActive: true}, nil)
+
+			// Командный лимит - 2 открытых ревью, у Charlie личный лимит 5
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(&domain.TeamSettings{TeamName: "backend", ReviewerCount: 2, MaxOpenReviews: 2}, nil)
+			mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
+				Return([]domain.User{
+					{UserID: "user-2", Username: "Bob", TeamName: "backend", IsActive: true},
+					{UserID: "user-3", Username: "Charlie", TeamName: "backend", IsActive: true, MaxOpenReviews: 5},
+					{UserID: "user-4", Username: "Dave", TeamName: "backend", IsActive: true},
+				}, nil)
+
+			// Bob упёрся в лимит команды, Charlie - нет благодаря личному лимиту
+			mockPRRepo.On("GetOpenReviewCounts", mock.Anything, []string{"user-2", "user-3", "user-4"}).
+				Return(map[string]int{"user-2": 2, "user-3": 4, "user-4": 1}, nil)
+
+			mockPRRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			mockPRRepo.On("AssignReviewer", mock.Anything, "pr-005", mock.AnythingOfType("string")).
+				Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.CreatePullRequest(context.Background(), input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user-3", "user-4"}, result.AssignedReviewers)
+}
+
+func TestCreatePullRequest_AllAtCapacity_Reject(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	input := &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-006",
+		PullRequestName: "Nobody is free",
+		AuthorID:        "user-1",
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+			mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-006").
+				Return(nil, storage.ErrNotFound)
+
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", Username: "Author", TeamName: "backend", IsActive: true}, nil)
+
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(&domain.TeamSettings{
+					TeamName:       "backend",
+					ReviewerCount:  2,
+					MaxOpenReviews: 1,
+					CapacityPolicy: domain.CapacityPolicyReject,
+				}, nil)
+			mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
+				Return([]domain.User{
+					{UserID: "user-2", Username: "Bob", TeamName: "backend", IsActive: true},
+					{UserID: "user-3", Username: "Charlie", TeamName: "backend", IsActive: true},
+				}, nil)
+			mockPRRepo.On("GetOpenReviewCounts", mock.Anything, []string{"user-2", "user-3"}).
+				Return(map[string]int{"user-2": 1, "user-3": 3}, nil)
+
+			err := fn(context.Background(), mockTx)
+			assert.ErrorIs(t, err, domain.ErrAllAtCapacity)
+		}).Return(domain.ErrAllAtCapacity)
+
+	// Act
+	result, err := svc.CreatePullRequest(context.Background(), input)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrAllAtCapacity)
+	mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreatePullRequest_AllAtCapacity_AssignLeastLoaded(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	input := &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-007",
+		PullRequestName: "Urgent fix",
+		AuthorID:        "user-1",
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+			mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-007").
+				Return(nil, storage.ErrNotFound)
+
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", Username: "Author", TeamName: "backend", IsActive: true}, nil)
+
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(&domain.TeamSettings{
+					TeamName:       "backend",
+					ReviewerCount:  1,
+					MaxOpenReviews: 1,
+					CapacityPolicy: domain.CapacityPolicyAssignLeastLoaded,
+				}, nil)
+			mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
+				Return([]domain.User{
+					{UserID: "user-2", Username: "Bob", TeamName: "backend", IsActive: true},
+					{UserID: "user-3", Username: "Charlie", TeamName: "backend", IsActive: true},
+				}, nil)
+			mockPRRepo.On("GetOpenReviewCounts", mock.Anything, []string{"user-2", "user-3"}).
+				Return(map[string]int{"user-2": 4, "user-3": 2}, nil)
+
+			mockPRRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			mockPRRepo.On("AssignReviewer", mock.Anything, "pr-007", "user-3").Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.CreatePullRequest(context.Background(), input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-3"}, result.AssignedReviewers)
+}
+
+func TestGetPullRequest_WithReviewerDetails(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(&domain.PullRequest{
+					ID:                "pr-001",
+					Name:              "Feature",
+					AuthorID:          "user-1",
+					Status:            domain.PullRequestStatusOpen,
+					AssignedReviewers: []string{"user-3", "user-2"},
+				}, nil)
+
+			// Пользователи возвращаются в произвольном порядке
+			mockUserRepo.On("GetByIDs", mock.Anything, []string{"user-1", "user-3", "user-2"}).
+				Return([]domain.User{
+					{UserID: "user-2", Username: "Bob", TeamName: "backend", IsActive: false},
+					{UserID: "user-1", Username: "Alice", TeamName: "backend", IsActive: true},
+					{UserID: "user-3", Username: "Carol", TeamName: "platform", IsActive: true},
+				}, nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.GetPullRequest(context.Background(), "pr-001")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", result.Author.Username)
+	require.Len(t, result.Reviewers, 2)
+	assert.Equal(t, "Carol", result.Reviewers[0].Username)
+	assert.Equal(t, "Bob", result.Reviewers[1].Username)
+	assert.False(t, result.Reviewers[1].IsActive)
+}
@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePullRequest_RepositoryOwnerTeamsReplaceAuthorTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockRepositoryRepo := mocks.NewRepositoryRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+	mockTx.On("RepositoryRepo").Return(mockRepositoryRepo)
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockRepositoryRepo.On("Get", mock.Anything, "octo-org/web-app").
+		Return(&domain.Repository{Name: "octo-org/web-app", OwnerTeams: []string{"frontend", "design"}}, nil)
+
+	// Во frontend один доступный участник, второго ревьювера добирает design - следующая команда-владелец
+	mockTeamRepo.On("GetSettings", mock.Anything, "frontend").
+		Return(&domain.TeamSettings{TeamName: "frontend", ReviewerCount: 2, MinReviewers: 2, FallbackTeams: []string{"design", "qa"}}, nil)
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "frontend").
+		Return([]domain.User{{UserID: "user-5", TeamName: "frontend", IsActive: true}}, nil)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, "frontend").Return([]domain.SelectionExclusion{}, nil)
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "design").
+		Return([]domain.User{{UserID: "user-6", TeamName: "design", IsActive: true}}, nil)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, "design").Return([]domain.SelectionExclusion{}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "design").Return(nil, storage.ErrNotFound)
+
+	mockPRRepo.On("Create", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+		return pr.Repository == "octo-org/web-app"
+	})).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", mock.AnythingOfType("string")).Return(nil)
+
+	var recorded *domain.SelectionExplanation
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			recorded = args.Get(1).(*domain.SelectionExplanation)
+		}).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	pr, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Fix header layout",
+		AuthorID:        "user-1",
+		Repository:      "octo-org/web-app",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-5", "user-6"}, pr.AssignedReviewers)
+	assert.Equal(t, map[string]string{"user-5": "frontend", "user-6": "design"}, pr.ReviewerTeams)
+
+	require.NotNil(t, recorded)
+	assert.Equal(t, "octo-org/web-app", recorded.Repository)
+	require.Len(t, recorded.Teams, 2)
+	assert.Equal(t, "frontend", recorded.Teams[0].TeamName)
+	assert.False(t, recorded.Teams[0].Fallback)
+	assert.Equal(t, "design", recorded.Teams[1].TeamName)
+	assert.True(t, recorded.Teams[1].Fallback)
+
+	// Команда автора не рассматривалась
+	mockUserRepo.AssertNotCalled(t, "GetActiveTeamMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreatePullRequest_RepositoryWithoutOwnerTeamsUsesAuthorTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx, _, recorded := codeOwnersTestMocks(t, testCandidates("user-2", "user-3"))
+	mockRepositoryRepo := mocks.NewRepositoryRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("RepositoryRepo").Return(mockRepositoryRepo)
+	mockRepositoryRepo.On("Get", mock.Anything, "octo-org/review-service").
+		Return(&domain.Repository{Name: "octo-org/review-service", OwnerTeams: []string{}}, nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	pr, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+		Repository:      "octo-org/review-service",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user-2", "user-3"}, pr.AssignedReviewers)
+
+	explanation := *recorded
+	require.NotNil(t, explanation)
+	assert.Empty(t, explanation.Repository)
+	assert.Equal(t, "backend", explanation.Teams[0].TeamName)
+}
+
+func TestSetRepository_KeepsCreatedAt(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockRepositoryRepo := mocks.NewRepositoryRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	createdAt := time.Date(2025, 11, 1, 10, 0, 0, 0, time.UTC)
+
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+	mockTx.On("RepositoryRepo").Return(mockRepositoryRepo)
+	mockTeamRepo.On("GetByName", mock.Anything, "frontend").Return(&domain.Team{Name: "frontend"}, nil)
+	mockTeamRepo.On("GetByName", mock.Anything, "design").Return(&domain.Team{Name: "design"}, nil)
+	mockRepositoryRepo.On("Get", mock.Anything, "octo-org/web-app").
+		Return(&domain.Repository{Name: "octo-org/web-app", OwnerTeams: []string{"backend"}, CreatedAt: createdAt}, nil)
+	mockRepositoryRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(repository *domain.Repository) bool {
+		return repository.CreatedAt.Equal(createdAt) && repository.UpdatedAt.After(createdAt)
+	})).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	repository, err := svc.SetRepository(context.Background(), &domain.SetRepositoryInput{
+		Name:       "octo-org/web-app",
+		OwnerTeams: []string{"frontend", "design"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "design"}, repository.OwnerTeams)
+	assert.Equal(t, createdAt, repository.CreatedAt)
+}
+
+func TestSetRepository_DuplicateOwnerTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	svc := service.New(mockTxMgr)
+
+	// Act
+	repository, err := svc.SetRepository(context.Background(), &domain.SetRepositoryInput{
+		Name:       "octo-org/web-app",
+		OwnerTeams: []string{"frontend", "frontend"},
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, repository)
+}
+
+func TestSetRepository_UnknownOwnerTeam(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+	mockTeamRepo.On("GetByName", mock.Anything, "frontend").Return(nil, storage.ErrNotFound)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	// Act
+	repository, err := svc.SetRepository(context.Background(), &domain.SetRepositoryInput{
+		Name:       "octo-org/web-app",
+		OwnerTeams: []string{"frontend"},
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrResourceNotFound)
+	assert.Nil(t, repository)
+}
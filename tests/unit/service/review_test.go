@@ -0,0 +1,166 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitReview_Approve(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	assignedAt := time.Now().Add(-time.Hour)
+	existingPR := &domain.PullRequest{
+		ID:                "pr-001",
+		Name:              "Feature",
+		AuthorID:          "user-1",
+		Status:            domain.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user-2", "user-3"},
+		ReviewerStates: []domain.ReviewerState{
+			{ReviewerID: "user-2", State: domain.ReviewStatePending, UpdatedAt: assignedAt},
+			{ReviewerID: "user-3", State: domain.ReviewStatePending, UpdatedAt: assignedAt},
+		},
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").Return(existingPR, nil)
+			mockPRRepo.On("SetReviewState", mock.Anything, "pr-001", "user-3", domain.ReviewStateApproved, mock.AnythingOfType("time.Time")).
+				Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.SubmitReview(context.Background(), &domain.SubmitReviewInput{
+		PullRequestID: "pr-001",
+		ReviewerID:    "user-3",
+		State:         domain.ReviewStateApproved,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.ReviewerStates, 2)
+	assert.Equal(t, domain.ReviewStatePending, result.ReviewerStates[0].State)
+	assert.Equal(t, "user-3", result.ReviewerStates[1].ReviewerID)
+	assert.Equal(t, domain.ReviewStateApproved, result.ReviewerStates[1].State)
+	assert.True(t, result.ReviewerStates[1].UpdatedAt.After(assignedAt))
+}
+
+func TestSubmitReview_NotAssigned(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(&domain.PullRequest{
+					ID:                "pr-001",
+					AuthorID:          "user-1",
+					Status:            domain.PullRequestStatusOpen,
+					AssignedReviewers: []string{"user-2"},
+				}, nil)
+
+			err := fn(context.Background(), mockTx)
+			assert.ErrorIs(t, err, domain.ErrReviewerMissing)
+		}).Return(domain.ErrReviewerMissing)
+
+	// Act
+	result, err := svc.SubmitReview(context.Background(), &domain.SubmitReviewInput{
+		PullRequestID: "pr-001",
+		ReviewerID:    "user-9",
+		State:         domain.ReviewStateChangesRequested,
+	})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrReviewerMissing)
+	mockPRRepo.AssertNotCalled(t, "SetReviewState", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSubmitReview_OnMerged(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(&domain.PullRequest{
+					ID:                "pr-001",
+					AuthorID:          "user-1",
+					Status:            domain.PullRequestStatusMerged,
+					AssignedReviewers: []string{"user-2"},
+				}, nil)
+
+			err := fn(context.Background(), mockTx)
+			assert.ErrorIs(t, err, domain.ErrReviewOnMerged)
+		}).Return(domain.ErrReviewOnMerged)
+
+	// Act
+	result, err := svc.SubmitReview(context.Background(), &domain.SubmitReviewInput{
+		PullRequestID: "pr-001",
+		ReviewerID:    "user-2",
+		State:         domain.ReviewStateApproved,
+	})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrReviewOnMerged)
+}
+
+func TestSubmitReview_PendingIsRejected(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Act
+	result, err := svc.SubmitReview(context.Background(), &domain.SubmitReviewInput{
+		PullRequestID: "pr-001",
+		ReviewerID:    "user-2",
+		State:         domain.ReviewStatePending,
+	})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockTxMgr.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,291 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testCandidates(ids ...string) []domain.User {
+	users := make([]domain.User, len(ids))
+	for i, id := range ids {
+		users[i] = domain.User{UserID: id, Username: id, TeamName: "backend", IsActive: true}
+	}
+	return users
+}
+
+func TestRandomSelector_NoDuplicates(t *testing.T) {
+	selector := service.NewRandomSelector()
+
+	selected, err := selector.Select(context.Background(), &service.SelectionRequest{
+		TeamName:   "backend",
+		Candidates: testCandidates("u1", "u2", "u3"),
+		Count:      5,
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, selected, 3)
+	assert.ElementsMatch(t, []string{"u1", "u2", "u3"}, []string{selected[0].UserID, selected[1].UserID, selected[2].UserID})
+}
+
+func TestRoundRobinSelector_RotatesPerTeam(t *testing.T) {
+	selector := service.NewRoundRobinSelector()
+	candidates := testCandidates("u3", "u1", "u2")
+
+	var picked []string
+	for i := 0; i < 4; i++ {
+		selected, err := selector.Select(context.Background(), &service.SelectionRequest{
+			TeamName:   "backend",
+			Candidates: candidates,
+			Count:      1,
+		})
+		require.NoError(t, err)
+		require.Len(t, selected, 1)
+		picked = append(picked, selected[0].UserID)
+	}
+
+	assert.Equal(t, []string{"u1", "u2", "u3", "u1"}, picked)
+
+	// Очередь другой команды независима
+	selected, err := selector.Select(context.Background(), &service.SelectionRequest{
+		TeamName:   "frontend",
+		Candidates: candidates,
+		Count:      2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "u1", selected[0].UserID)
+	assert.Equal(t, "u2", selected[1].UserID)
+}
+
+func TestLeastLoadedSelector_PrefersFewestOpenReviews(t *testing.T) {
+	selector := service.NewLeastLoadedSelector()
+
+	selected, err := selector.Select(context.Background(), &service.SelectionRequest{
+		TeamName:   "backend",
+		Candidates: testCandidates("u1", "u2", "u3"),
+		Count:      2,
+		OpenReviews: map[string]int{
+			"u1": 5,
+			"u2": 0,
+			"u3": 1,
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+	assert.Equal(t, "u2", selected[0].UserID)
+	assert.Equal(t, "u3", selected[1].UserID)
+}
+
+func TestLeastLoadedSelector_RandomTieBreak(t *testing.T) {
+	selector := service.NewLeastLoadedSelector()
+
+	// При равной нагрузке каждый из кандидатов должен хотя бы раз оказаться первым
+	firstPicked := make(map[string]bool)
+	for i := 0; i < 100 && len(firstPicked) < 3; i++ {
+		selected, err := selector.Select(context.Background(), &service.SelectionRequest{
+			TeamName:    "backend",
+			Candidates:  testCandidates("u1", "u2", "u3"),
+			Count:       1,
+			OpenReviews: map[string]int{"u1": 1, "u2": 1, "u3": 1},
+		})
+		require.NoError(t, err)
+		require.Len(t, selected, 1)
+		firstPicked[selected[0].UserID] = true
+	}
+
+	assert.Len(t, firstPicked, 3)
+}
+
+func TestWeightedSelector_SkipsZeroWeight(t *testing.T) {
+	selector := service.NewWeightedSelector(map[string]int{
+		"u1": 0,
+		"u2": 10,
+	})
+
+	for i := 0; i < 20; i++ {
+		selected, err := selector.Select(context.Background(), &service.SelectionRequest{
+			TeamName:   "backend",
+			Candidates: testCandidates("u1", "u2", "u3"),
+			Count:      3,
+		})
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		for _, user := range selected {
+			assert.NotEqual(t, "u1", user.UserID)
+		}
+	}
+}
+
+func TestValidateStrategies(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []service.Option
+		wantErr string
+	}{
+		{name: "defaults"},
+		{
+			name: "registered strategies",
+			opts: []service.Option{
+				service.WithDefaultStrategy(domain.SelectionStrategyLeastLoaded),
+				service.WithTeamStrategies(map[string]domain.SelectionStrategy{"backend": domain.SelectionStrategyRoundRobin}),
+			},
+		},
+		{
+			name:    "unknown default strategy",
+			opts:    []service.Option{service.WithDefaultStrategy("least_load")},
+			wantErr: `unknown default selection strategy "least_load"`,
+		},
+		{
+			name: "unknown team strategy",
+			opts: []service.Option{
+				service.WithTeamStrategies(map[string]domain.SelectionStrategy{"backend": "roundrobin"}),
+			},
+			wantErr: `unknown selection strategy "roundrobin" for team "backend"`,
+		},
+		{
+			name: "custom selector",
+			opts: []service.Option{
+				service.WithSelector("custom", service.NewRandomSelector()),
+				service.WithDefaultStrategy("custom"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.New(mocks.NewTxManager(t), tt.opts...)
+
+			err := svc.ValidateStrategies()
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCreatePullRequest_LeastLoadedStrategy(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr,
+		service.WithTeamStrategies(map[string]domain.SelectionStrategy{
+			"backend": domain.SelectionStrategyLeastLoaded,
+		}),
+	)
+
+	input := &domain.CreatePullRequestInput{
+		PullRequestID:   "pr-001",
+		PullRequestName: "Add feature",
+		AuthorID:        "user-1",
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+			mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-001").
+				Return(nil, storage.ErrNotFound)
+
+			// Автор и настройки его команды (не заданы - используются значения по умолчанию)
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").
+				Return(&domain.User{UserID: "user-1", Username: "Author", TeamName: "backend", IsActive: true}, nil)
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(nil, storage.ErrNotFound)
+
+			mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
+				Return(testCandidates("user-2", "user-3", "user-4"), nil)
+
+			// user-2 перегружен, остальные свободны
+			mockPRRepo.On("GetOpenReviewCounts", mock.Anything, []string{"user-2", "user-3", "user-4"}).
+				Return(map[string]int{"user-2": 5, "user-3": 0, "user-4": 1}, nil)
+
+			mockPRRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			mockPRRepo.On("AssignReviewer", mock.Anything, "pr-001", mock.AnythingOfType("string")).
+				Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.CreatePullRequest(context.Background(), input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-3", "user-4"}, result.AssignedReviewers)
+}
+
+func TestCreatePullRequest_RoundRobinDryRunKeepsRotation(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr, service.WithDefaultStrategy(domain.SelectionStrategyRoundRobin))
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockPRRepo.On("GetByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").
+		Return(&domain.User{UserID: "user-1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "backend").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "user-1").
+		Return(testCandidates("user-2", "user-3", "user-4", "user-5"), nil)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, "backend").Return([]domain.SelectionExclusion{}, nil)
+	mockPRRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil)
+
+	// Пробный запуск откатывается: Do возвращает ошибку функции транзакции
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	create := func(prID string, dryRun bool) []string {
+		pr, err := svc.CreatePullRequest(context.Background(), &domain.CreatePullRequestInput{
+			PullRequestID:   prID,
+			PullRequestName: "Add feature",
+			AuthorID:        "user-1",
+			DryRun:          dryRun,
+		})
+		require.NoError(t, err)
+		return pr.AssignedReviewers
+	}
+
+	// Act
+	preview := create("pr-001", true)
+	first := create("pr-001", false)
+	second := create("pr-002", false)
+
+	// Assert
+	assert.Equal(t, []string{"user-2", "user-3"}, preview)
+	assert.Equal(t, preview, first)
+	assert.Equal(t, []string{"user-4", "user-5"}, second)
+}
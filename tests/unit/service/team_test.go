@@ -38,10 +38,11 @@ func TestCreateTeam_Success(t *testing.T) {
 			mockTx.On("TeamRepo").Return(mockTeamRepo)
 
 			// Ожидаем создание команды (проверка дубликата на уровне БД)
-			mockTeamRepo.On("Create", mock.Anything, team, mock.MatchedBy(func(users []domain.User) bool {
-				return len(users) == 2 &&
-					users[0].UserID == "u1" &&
-					users[1].UserID == "u2"
+			// Роль по умолчанию - MEMBER
+			mockTeamRepo.On("Create", mock.Anything, team, mock.MatchedBy(func(members []domain.TeamMember) bool {
+				return len(members) == 2 &&
+					members[0].UserID == "u1" && members[0].Role == domain.TeamRoleMember &&
+					members[1].UserID == "u2" && members[1].Role == domain.TeamRoleMember
 			})).Return(nil)
 
 			_ = fn(context.Background(), mockTx)
@@ -206,3 +207,129 @@ func TestDeactivateTeamMembers_Success(t *testing.T) {
 	assert.Equal(t, "backend", result.TeamName)
 	assert.Equal(t, 5, result.DeactivatedUserCount)
 }
+
+func TestSetTeamSettings_PartialUpdate(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	reviewerCount := 3
+	input := &domain.SetTeamSettingsInput{
+		TeamName:      "backend",
+		ReviewerCount: &reviewerCount,
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockTeamRepo.On("GetByName", mock.Anything, "backend").
+				Return(&domain.Team{Name: "backend"}, nil)
+
+			// Текущие настройки команды
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(&domain.TeamSettings{
+					TeamName:          "backend",
+					ReviewerCount:     2,
+					MinReviewers:      1,
+					SelectionStrategy: domain.SelectionStrategyRoundRobin,
+				}, nil)
+
+			// Незаданные поля сохраняют текущее значение
+			mockTeamRepo.On("UpsertSettings", mock.Anything, mock.MatchedBy(func(ts *domain.TeamSettings) bool {
+				return ts.ReviewerCount == 3 &&
+					ts.MinReviewers == 1 &&
+					ts.SelectionStrategy == domain.SelectionStrategyRoundRobin
+			})).Return(nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil) // Act
+	result, err := svc.SetTeamSettings(context.Background(), input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.ReviewerCount)
+	assert.Equal(t, 1, result.MinReviewers)
+}
+
+func TestSetTeamSettings_MinReviewersAboveCount(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	minReviewers := 3
+	input := &domain.SetTeamSettingsInput{
+		TeamName:     "backend",
+		MinReviewers: &minReviewers,
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockTeamRepo.On("GetByName", mock.Anything, "backend").
+				Return(&domain.Team{Name: "backend"}, nil)
+
+			// Настройки не заданы - по умолчанию 2 ревьювера
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(nil, storage.ErrNotFound)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(domain.ErrInvalidInput)
+
+	// Act
+	result, err := svc.SetTeamSettings(context.Background(), input)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestSetTeamSettings_FallbackToItself(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	input := &domain.SetTeamSettingsInput{
+		TeamName:      "backend",
+		FallbackTeams: []string{"frontend", "backend"},
+	}
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			mockTeamRepo.On("GetByName", mock.Anything, "backend").
+				Return(&domain.Team{Name: "backend"}, nil)
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(nil, storage.ErrNotFound)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(domain.ErrInvalidInput)
+
+	// Act
+	result, err := svc.SetTeamSettings(context.Background(), input)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockTeamRepo.AssertNotCalled(t, "UpsertSettings", mock.Anything, mock.Anything)
+}
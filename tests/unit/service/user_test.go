@@ -3,6 +3,7 @@ package service_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"avitoTechAutumn2025/internal/domain"
 	"avitoTechAutumn2025/internal/mocks"
@@ -49,13 +50,13 @@ func TestSetUserIsActive_Activate(t *testing.T) {
 		}).Return(nil)
 
 	// Act
-	result, err := svc.SetUserIsActive(context.Background(), "user-1", true)
+	result, err := svc.SetUserIsActive(context.Background(), &domain.SetUserActiveInput{UserID: "user-1", IsActive: true})
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, "user-1", result.UserID)
-	assert.True(t, result.IsActive)
+	assert.Equal(t, "user-1", result.User.UserID)
+	assert.True(t, result.User.IsActive)
 }
 
 func TestSetUserIsActive_Deactivate(t *testing.T) {
@@ -91,13 +92,92 @@ func TestSetUserIsActive_Deactivate(t *testing.T) {
 		}).Return(nil)
 
 	// Act
-	result, err := svc.SetUserIsActive(context.Background(), "user-1", false)
+	result, err := svc.SetUserIsActive(context.Background(), &domain.SetUserActiveInput{UserID: "user-1", IsActive: false})
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, "user-1", result.UserID)
-	assert.False(t, result.IsActive)
+	assert.Equal(t, "user-1", result.User.UserID)
+	assert.False(t, result.User.IsActive)
+	assert.Empty(t, result.Reassignments)
+}
+
+func TestSetUserIsActive_DeactivateReassignsOpenReviews(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockUserRepo.On("GetByID", mock.Anything, "user-2").
+		Return(&domain.User{UserID: "user-2", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+		return user.UserID == "user-2" && !user.IsActive
+	})).Return(nil)
+
+	// На pr-1 пользователь заменяется коллегой, на pr-2 замены нет и он снимается
+	mockPRRepo.On("GetOpenPRIDsByReviewers", mock.Anything, []string{"user-2"}).
+		Return([]string{"pr-1", "pr-2"}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+		Return(&domain.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "user-1",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"user-2"},
+		}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-2").
+		Return(&domain.PullRequest{
+			ID:                "pr-2",
+			AuthorID:          "user-3",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"user-2", "user-1"},
+		}, nil)
+	mockPRRepo.On("GetInactiveReviewers", mock.Anything, "pr-1").Return([]string{"user-2"}, nil)
+	mockPRRepo.On("GetInactiveReviewers", mock.Anything, "pr-2").Return([]string{"user-2"}, nil)
+
+	mockTeamRepo.On("GetSettings", mock.Anything, "backend").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "backend").
+		Return(testCandidates("user-1", "user-3"), nil)
+
+	mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-1", "user-2").Return(nil)
+	mockPRRepo.On("AssignReviewer", mock.Anything, "pr-1", "user-3").Return(nil)
+	mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-2", "user-2").Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+			require.NoError(t, fn(context.Background(), mockTx))
+		}).Return(nil)
+
+	// Act
+	result, err := svc.SetUserIsActive(context.Background(), &domain.SetUserActiveInput{
+		UserID:              "user-2",
+		IsActive:            false,
+		ReassignOpenReviews: true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, result.User.IsActive)
+	require.Len(t, result.Reassignments, 2)
+
+	assert.Equal(t, "pr-1", result.Reassignments[0].PullRequestID)
+	require.Len(t, result.Reassignments[0].ReassignmentDetails, 1)
+	assert.Equal(t, "user-3", result.Reassignments[0].ReassignmentDetails[0].NewReviewerID)
+
+	assert.Equal(t, "pr-2", result.Reassignments[1].PullRequestID)
+	require.Len(t, result.Reassignments[1].ReassignmentDetails, 1)
+	assert.True(t, result.Reassignments[1].ReassignmentDetails[0].WasRemoved)
 }
 
 func TestSetUserIsActive_UserNotFound(t *testing.T) {
@@ -123,7 +203,7 @@ func TestSetUserIsActive_UserNotFound(t *testing.T) {
 		}).Return(storage.ErrNotFound)
 
 	// Act
-	result, err := svc.SetUserIsActive(context.Background(), "nonexistent", true)
+	result, err := svc.SetUserIsActive(context.Background(), &domain.SetUserActiveInput{UserID: "nonexistent", IsActive: true})
 
 	// Assert
 	assert.Error(t, err)
@@ -166,12 +246,12 @@ func TestSetUserIsActive_Idempotent(t *testing.T) {
 		}).Return(nil)
 
 	// Act - Активируем уже активного пользователя
-	result, err := svc.SetUserIsActive(context.Background(), "user-1", true)
+	result, err := svc.SetUserIsActive(context.Background(), &domain.SetUserActiveInput{UserID: "user-1", IsActive: true})
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.True(t, result.IsActive)
+	assert.True(t, result.User.IsActive)
 }
 
 func TestGetReviewerAssignments_Success(t *testing.T) {
@@ -204,21 +284,23 @@ func TestGetReviewerAssignments_Success(t *testing.T) {
 
 			mockTx.On("PullRequestRepo").Return(mockPRRepo)
 
-			mockPRRepo.On("GetPRsReviewedByUser", mock.Anything, "reviewer-1").
+			mockPRRepo.On("GetPRsReviewedByUser", mock.Anything, "reviewer-1",
+				[]domain.PullRequestStatus(nil), (*domain.PageCursor)(nil), domain.DefaultPageLimit+1).
 				Return(expectedPRs, nil)
 
 			_ = fn(context.Background(), mockTx)
 		}).Return(nil)
 
 	// Act
-	result, err := svc.GetReviewerAssignments(context.Background(), "reviewer-1")
+	result, err := svc.GetReviewerAssignments(context.Background(), &domain.ReviewerAssignmentsInput{UserID: "reviewer-1"})
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, 2, len(result))
-	assert.Equal(t, "pr-001", result[0].ID)
-	assert.Equal(t, "pr-002", result[1].ID)
+	assert.Equal(t, 2, len(result.PullRequests))
+	assert.Equal(t, "pr-001", result.PullRequests[0].ID)
+	assert.Equal(t, "pr-002", result.PullRequests[1].ID)
+	assert.Empty(t, result.NextCursor)
 }
 
 func TestGetReviewerAssignments_EmptyList(t *testing.T) {
@@ -237,19 +319,20 @@ func TestGetReviewerAssignments_EmptyList(t *testing.T) {
 			mockTx.On("PullRequestRepo").Return(mockPRRepo)
 
 			// Пользователь не назначен ни на один PR
-			mockPRRepo.On("GetPRsReviewedByUser", mock.Anything, "user-without-prs").
+			mockPRRepo.On("GetPRsReviewedByUser", mock.Anything, "user-without-prs",
+				[]domain.PullRequestStatus(nil), (*domain.PageCursor)(nil), domain.DefaultPageLimit+1).
 				Return([]domain.PullRequestShort{}, nil)
 
 			_ = fn(context.Background(), mockTx)
 		}).Return(nil)
 
 	// Act
-	result, err := svc.GetReviewerAssignments(context.Background(), "user-without-prs")
+	result, err := svc.GetReviewerAssignments(context.Background(), &domain.ReviewerAssignmentsInput{UserID: "user-without-prs"})
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, 0, len(result))
+	assert.Equal(t, 0, len(result.PullRequests))
 }
 
 func TestGetReviewerAssignments_IncludesMergedPRs(t *testing.T) {
@@ -282,28 +365,167 @@ func TestGetReviewerAssignments_IncludesMergedPRs(t *testing.T) {
 
 			mockTx.On("PullRequestRepo").Return(mockPRRepo)
 
-			mockPRRepo.On("GetPRsReviewedByUser", mock.Anything, "reviewer-1").
+			mockPRRepo.On("GetPRsReviewedByUser", mock.Anything, "reviewer-1",
+				[]domain.PullRequestStatus(nil), (*domain.PageCursor)(nil), domain.DefaultPageLimit+1).
 				Return(expectedPRs, nil)
 
 			_ = fn(context.Background(), mockTx)
 		}).Return(nil)
 
 	// Act
-	result, err := svc.GetReviewerAssignments(context.Background(), "reviewer-1")
+	result, err := svc.GetReviewerAssignments(context.Background(), &domain.ReviewerAssignmentsInput{UserID: "reviewer-1"})
 
 	// Assert - Важно: должны возвращаться и OPEN и MERGED PR
 	require.NoError(t, err)
-	assert.Equal(t, 2, len(result))
+	assert.Equal(t, 2, len(result.PullRequests))
 
 	// Проверяем что есть оба статуса
 	statuses := make(map[domain.PullRequestStatus]bool)
-	for _, pr := range result {
+	for _, pr := range result.PullRequests {
 		statuses[pr.Status] = true
 	}
 	assert.True(t, statuses[domain.PullRequestStatusOpen])
 	assert.True(t, statuses[domain.PullRequestStatusMerged])
 }
 
+func TestGetReviewerAssignments_ReturnsNextCursor(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	createdAt := time.Date(2025, 11, 1, 10, 0, 0, 0, time.UTC)
+	statuses := []domain.PullRequestStatus{domain.PullRequestStatusOpen}
+
+	// Репозиторий вернул на один PR больше лимита - есть следующая страница
+	repoPRs := []domain.PullRequestShort{
+		{ID: "pr-001", Status: domain.PullRequestStatusOpen, CreatedAt: createdAt},
+		{ID: "pr-002", Status: domain.PullRequestStatusOpen, CreatedAt: createdAt.Add(time.Hour)},
+		{ID: "pr-003", Status: domain.PullRequestStatusOpen, CreatedAt: createdAt.Add(2 * time.Hour)},
+	}
+
+	// Setup expectations: первая страница, затем страница после курсора
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockPRRepo.On("GetPRsReviewedByUser", mock.Anything, "reviewer-1",
+		statuses, (*domain.PageCursor)(nil), 3).
+		Return(repoPRs, nil).Once()
+	mockPRRepo.On("GetPRsReviewedByUser", mock.Anything, "reviewer-1", statuses,
+		&domain.PageCursor{SortValue: createdAt.Add(time.Hour), ID: "pr-002"}, 3).
+		Return(repoPRs[2:], nil).Once()
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+			require.NoError(t, fn(context.Background(), mockTx))
+		}).Return(nil)
+
+	input := &domain.ReviewerAssignmentsInput{
+		UserID:   "reviewer-1",
+		Statuses: statuses,
+		Limit:    2,
+	}
+
+	// Act
+	first, err := svc.GetReviewerAssignments(context.Background(), input)
+	require.NoError(t, err)
+
+	input.Cursor = first.NextCursor
+	second, err := svc.GetReviewerAssignments(context.Background(), input)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, first.PullRequests, 2)
+	assert.Equal(t, "pr-002", first.PullRequests[1].ID)
+	assert.NotEmpty(t, first.NextCursor)
+
+	require.Len(t, second.PullRequests, 1)
+	assert.Equal(t, "pr-003", second.PullRequests[0].ID)
+	assert.Empty(t, second.NextCursor)
+}
+
+func TestGetReviewerAssignments_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *domain.ReviewerAssignmentsInput
+	}{
+		{"unknown status", &domain.ReviewerAssignmentsInput{UserID: "reviewer-1", Statuses: []domain.PullRequestStatus{"DONE"}}},
+		{"limit too large", &domain.ReviewerAssignmentsInput{UserID: "reviewer-1", Limit: domain.MaxPageLimit + 1}},
+		{"broken cursor", &domain.ReviewerAssignmentsInput{UserID: "reviewer-1", Cursor: "not-a-cursor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockTxMgr := mocks.NewTxManager(t)
+			svc := service.New(mockTxMgr)
+
+			// Act
+			result, err := svc.GetReviewerAssignments(context.Background(), tt.input)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			mockTxMgr.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetReviewCapacity_TeamDefaultLimit(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Setup expectations
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+
+			mockTx.On("PullRequestRepo").Return(mockPRRepo)
+			mockTx.On("UserRepo").Return(mockUserRepo)
+			mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+			// Личный лимит не задан - действует лимит команды
+			mockUserRepo.On("GetByID", mock.Anything, "reviewer-1").
+				Return(&domain.User{UserID: "reviewer-1", Username: "Bob", TeamName: "backend", IsActive: true}, nil)
+			mockTeamRepo.On("GetSettings", mock.Anything, "backend").
+				Return(&domain.TeamSettings{TeamName: "backend", ReviewerCount: 2, MaxOpenReviews: 3}, nil)
+			mockPRRepo.On("GetOpenReviewCounts", mock.Anything, []string{"reviewer-1"}).
+				Return(map[string]int{"reviewer-1": 2}, nil)
+
+			_ = fn(context.Background(), mockTx)
+		}).Return(nil)
+
+	// Act
+	result, err := svc.GetReviewCapacity(context.Background(), "reviewer-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.OpenReviews)
+	assert.Equal(t, 3, result.MaxOpenReviews)
+}
+
+func TestSetUserReviewLimit_Negative(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+
+	svc := service.New(mockTxMgr)
+
+	// Act
+	result, err := svc.SetUserReviewLimit(context.Background(), "user-1", -1)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockTxMgr.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+}
+
 func TestDeactivateTeamMembers_WithOpenPRs(t *testing.T) {
 	// Arrange
 	mockTxMgr := mocks.NewTxManager(t)
@@ -347,6 +569,73 @@ func TestDeactivateTeamMembers_WithOpenPRs(t *testing.T) {
 	assert.Equal(t, 3, result.DeactivatedUserCount)
 }
 
+func TestDeactivateTeamMembers_ReassignOpenReviews(t *testing.T) {
+	// Arrange
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.New(mockTxMgr)
+
+	mockTx.On("PullRequestRepo").Return(mockPRRepo)
+	mockPRRepo.On("AddEvent", mock.Anything, mock.Anything).Return(nil)
+	mockTx.On("UserRepo").Return(mockUserRepo)
+	mockUserRepo.On("GetUnavailableByTeam", mock.Anything, mock.Anything).Return([]domain.SelectionExclusion{}, nil).Maybe()
+	mockPRRepo.On("AddSelectionExplanation", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockTx.On("TeamRepo").Return(mockTeamRepo)
+
+	mockTeamRepo.On("GetByName", mock.Anything, "backend").
+		Return(&domain.Team{
+			Name:    "backend",
+			Members: []domain.TeamMember{{UserID: "u1"}, {UserID: "u2"}},
+		}, nil)
+	mockTeamRepo.On("DeactivateAllMembers", mock.Anything, "backend").Return(2, nil)
+
+	// Оба ревьювера PR из деактивированной команды, активных коллег не осталось - оба снимаются
+	mockPRRepo.On("GetOpenPRIDsByReviewers", mock.Anything, []string{"u1", "u2"}).
+		Return([]string{"pr-1"}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+		Return(&domain.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "author",
+			Status:            domain.PullRequestStatusOpen,
+			AssignedReviewers: []string{"u1", "u2"},
+		}, nil)
+	mockPRRepo.On("GetInactiveReviewers", mock.Anything, "pr-1").Return([]string{"u1", "u2"}, nil)
+
+	mockUserRepo.On("GetByID", mock.Anything, mock.Anything).
+		Return(&domain.User{TeamName: "backend"}, nil)
+	mockTeamRepo.On("GetSettings", mock.Anything, "backend").Return(nil, storage.ErrNotFound)
+	mockUserRepo.On("GetActiveByTeam", mock.Anything, "backend").Return([]domain.User{}, nil)
+
+	mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-1", "u1").Return(nil)
+	mockPRRepo.On("UnassignReviewer", mock.Anything, "pr-1", "u2").Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context, storage.Tx) error)
+			require.NoError(t, fn(context.Background(), mockTx))
+		}).Return(nil)
+
+	// Act
+	result, err := svc.DeactivateTeamMembers(context.Background(), &domain.DeactivateTeamInput{
+		TeamName:            "backend",
+		ReassignOpenReviews: true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.DeactivatedUserCount)
+	require.Len(t, result.Reassignments, 1)
+	assert.Equal(t, "pr-1", result.Reassignments[0].PullRequestID)
+	require.Len(t, result.Reassignments[0].ReassignmentDetails, 2)
+	for _, detail := range result.Reassignments[0].ReassignmentDetails {
+		assert.True(t, detail.WasRemoved)
+	}
+}
+
 func TestDeactivateTeamMembers_TeamNotFound(t *testing.T) {
 	// Arrange
 	mockTxMgr := mocks.NewTxManager(t)
@@ -0,0 +1,232 @@
+package service_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"avitoTechAutumn2025/internal/domain"
+	"avitoTechAutumn2025/internal/mocks"
+	"avitoTechAutumn2025/internal/service"
+	"avitoTechAutumn2025/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookTestMocks настраивает одну доставку, которая будет выдана ClaimDueDelivery, и подписку с адресом url.
+// Обновлённая после попытки доставка сохраняется в updated.
+func webhookTestMocks(t *testing.T, url string, delivery *domain.WebhookDelivery, updated **domain.WebhookDelivery) *mocks.TxManager {
+	mockTxMgr := mocks.NewTxManager(t)
+	mockTx := mocks.NewTx(t)
+	mockWebhookRepo := mocks.NewWebhookRepository(t)
+
+	mockTx.On("WebhookRepo").Return(mockWebhookRepo)
+	mockWebhookRepo.On("ClaimDueDelivery", mock.Anything, mock.AnythingOfType("time.Time")).Return(delivery, nil).Once()
+	mockWebhookRepo.On("ClaimDueDelivery", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil, storage.ErrNotFound).Once()
+	mockWebhookRepo.On("GetSubscription", mock.Anything, delivery.SubscriptionID).
+		Return(&domain.WebhookSubscription{ID: delivery.SubscriptionID, URL: url, Secret: "s3cret"}, nil)
+	mockWebhookRepo.On("UpdateDelivery", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*updated = args.Get(1).(*domain.WebhookDelivery)
+		}).Return(nil)
+
+	mockTxMgr.On("Do", mock.Anything, mock.AnythingOfType("func(context.Context, storage.Tx) error")).
+		Return(runInTx(mockTx))
+
+	return mockTxMgr
+}
+
+func TestDeliverWebhooks_SignsAndDelivers(t *testing.T) {
+	// Arrange
+	payload := []byte(`{"event_type":"pull_request.merged"}`)
+
+	var received *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	var updated *domain.WebhookDelivery
+	mockTxMgr := webhookTestMocks(t, receiver.URL, &domain.WebhookDelivery{
+		ID:             11,
+		SubscriptionID: 7,
+		EventType:      domain.WebhookEventPullRequestMerged,
+		Payload:        payload,
+		Status:         domain.WebhookDeliveryPending,
+	}, &updated)
+
+	svc := service.New(mockTxMgr, service.WithWebhooks(receiver.Client(), 3, time.Second))
+
+	// Act
+	attempted, err := svc.DeliverWebhooks(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+
+	require.NotNil(t, received)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "pull_request.merged", received.Header.Get(service.WebhookEventHeader))
+	assert.Equal(t, "11", received.Header.Get(service.WebhookDeliveryHeader))
+	assert.Equal(t, payload, body)
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(payload)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), received.Header.Get(service.WebhookSignatureHeader))
+
+	require.NotNil(t, updated)
+	assert.Equal(t, domain.WebhookDeliveryDelivered, updated.Status)
+	assert.Equal(t, 1, updated.Attempts)
+	assert.Equal(t, http.StatusNoContent, updated.LastStatusCode)
+	assert.NotNil(t, updated.DeliveredAt)
+}
+
+func TestDeliverWebhooks_RetriesWithExponentialBackoff(t *testing.T) {
+	// Arrange
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	// Две попытки уже были: третья неудачная откладывает следующую на backoff * 2^2
+	var updated *domain.WebhookDelivery
+	mockTxMgr := webhookTestMocks(t, receiver.URL, &domain.WebhookDelivery{
+		ID:             11,
+		SubscriptionID: 7,
+		EventType:      domain.WebhookEventPullRequestCreated,
+		Payload:        []byte(`{}`),
+		Status:         domain.WebhookDeliveryPending,
+		Attempts:       2,
+	}, &updated)
+
+	svc := service.New(mockTxMgr, service.WithWebhooks(receiver.Client(), 5, time.Minute))
+
+	// Act
+	before := time.Now()
+	_, err := svc.DeliverWebhooks(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, domain.WebhookDeliveryPending, updated.Status)
+	assert.Equal(t, 3, updated.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, updated.LastStatusCode)
+	assert.NotEmpty(t, updated.LastError)
+	assert.WithinDuration(t, before.Add(4*time.Minute), updated.NextAttemptAt, 5*time.Second)
+	assert.Nil(t, updated.DeliveredAt)
+}
+
+func TestDeliverWebhooks_CapsBackoffForHighAttemptCount(t *testing.T) {
+	// Arrange
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	// Без ограничения сдвиг на 99 переполнил бы time.Duration и повтор ушёл бы без задержки
+	var updated *domain.WebhookDelivery
+	mockTxMgr := webhookTestMocks(t, receiver.URL, &domain.WebhookDelivery{
+		ID:             11,
+		SubscriptionID: 7,
+		EventType:      domain.WebhookEventPullRequestCreated,
+		Payload:        []byte(`{}`),
+		Status:         domain.WebhookDeliveryPending,
+		Attempts:       99,
+	}, &updated)
+
+	svc := service.New(mockTxMgr, service.WithWebhooks(receiver.Client(), 1000, time.Second))
+
+	// Act
+	before := time.Now()
+	_, err := svc.DeliverWebhooks(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, domain.WebhookDeliveryPending, updated.Status)
+	assert.Equal(t, 100, updated.Attempts)
+	assert.WithinDuration(t, before.Add(1024*time.Second), updated.NextAttemptAt, 5*time.Second)
+}
+
+func TestDeliverWebhooks_FailsAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	var updated *domain.WebhookDelivery
+	mockTxMgr := webhookTestMocks(t, receiver.URL, &domain.WebhookDelivery{
+		ID:             11,
+		SubscriptionID: 7,
+		EventType:      domain.WebhookEventTeamDeactivated,
+		Payload:        []byte(`{}`),
+		Status:         domain.WebhookDeliveryPending,
+		Attempts:       2,
+	}, &updated)
+
+	svc := service.New(mockTxMgr, service.WithWebhooks(receiver.Client(), 3, time.Second))
+
+	// Act
+	_, err := svc.DeliverWebhooks(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, domain.WebhookDeliveryFailed, updated.Status)
+	assert.Equal(t, 3, updated.Attempts)
+}
+
+func TestCreateWebhook_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input domain.CreateWebhookInput
+	}{
+		{
+			name:  "relative url",
+			input: domain.CreateWebhookInput{URL: "/hooks", Secret: "s3cret"},
+		},
+		{
+			name:  "unsupported scheme",
+			input: domain.CreateWebhookInput{URL: "ftp://ci.example.com/hooks", Secret: "s3cret"},
+		},
+		{
+			name:  "empty secret",
+			input: domain.CreateWebhookInput{URL: "https://ci.example.com/hooks"},
+		},
+		{
+			name: "unknown event type",
+			input: domain.CreateWebhookInput{
+				URL:        "https://ci.example.com/hooks",
+				Secret:     "s3cret",
+				EventTypes: []domain.WebhookEventType{"pull_request.renamed"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockTxMgr := mocks.NewTxManager(t)
+			svc := service.New(mockTxMgr)
+
+			// Act
+			result, err := svc.CreateWebhook(context.Background(), &tt.input)
+
+			// Assert
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			assert.Nil(t, result)
+		})
+	}
+}